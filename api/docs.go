@@ -0,0 +1,120 @@
+package api
+
+import (
+	_ "embed"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/problem"
+	swaggerFiles "github.com/swaggo/files/v2"
+)
+
+//go:embed docs.html
+var docsPage []byte
+
+// assets of Swagger UI used by the docs page
+var docsAssets = map[string]bool{
+	"swagger-ui.css":                  true,
+	"index.css":                       true,
+	"swagger-ui-bundle.js":            true,
+	"swagger-ui-standalone-preset.js": true,
+	"favicon-16x16.png":               true,
+	"favicon-32x32.png":               true,
+}
+
+// HandleDocs serves Swagger UI displaying the specs of all versions from /api/<version>/openapi.json
+func HandleDocs(ctx *gin.Context) {
+	ctx.Data(http.StatusOK, "text/html; charset=utf-8", docsPage)
+}
+
+// HandleDocsAssets serves the scripts and styles of the docs page, it is registered as /docs/*filepath
+func HandleDocsAssets(ctx *gin.Context) {
+	file := strings.TrimPrefix(ctx.Param("filepath"), "/")
+	if file == "" {
+		// assets are referenced relative to /docs
+		ctx.Redirect(http.StatusMovedPermanently, "../docs")
+		return
+	}
+	if !docsAssets[file] {
+		problem.Respond(ctx, problem.NotFound("route", "No resource is available at the requested path."))
+		return
+	}
+	ctx.FileFromFS(file, http.FS(swaggerFiles.FS))
+}
+
+// HandleOpenApiJson serves the spec of the version as JSON, relative server URLs
+// are resolved against the host and base path the client used to reach the service
+func (this *Version) HandleOpenApiJson(ctx *gin.Context) {
+	spec, err := this.parse()
+	if err != nil {
+		problem.Respond(ctx, problem.Internal(err))
+		return
+	}
+
+	// shallow copy, the parsed spec is shared by all requests
+	response := make(map[string]interface{}, len(spec))
+	for key, value := range spec {
+		response[key] = value
+	}
+	if servers, ok := spec["servers"].([]interface{}); ok {
+		rewritten := make([]interface{}, len(servers))
+		for i, server := range servers {
+			rewritten[i] = rewriteServer(ctx, server)
+		}
+		response["servers"] = rewritten
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+func rewriteServer(ctx *gin.Context, server interface{}) interface{} {
+	fields, ok := server.(map[string]interface{})
+	if !ok {
+		return server
+	}
+	serverURL, ok := fields["url"].(string)
+	if !ok {
+		return server
+	}
+	parsed, err := url.Parse(serverURL)
+	if err != nil || parsed.IsAbs() {
+		return server
+	}
+
+	rewritten := make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		rewritten[key] = value
+	}
+	rewritten["url"] = requestOrigin(ctx) + "/" + strings.TrimPrefix(serverURL, "/")
+	return rewritten
+}
+
+// requestOrigin returns scheme, host and base path of the service as seen by the client,
+// the forwarded headers are set by the reverse proxy in front of the service
+func requestOrigin(ctx *gin.Context) string {
+	scheme := "http"
+	if ctx.Request.TLS != nil {
+		scheme = "https"
+	}
+	if forwarded := forwardedHeader(ctx, "X-Forwarded-Proto"); forwarded != "" {
+		scheme = forwarded
+	}
+
+	host := ctx.Request.Host
+	if forwarded := forwardedHeader(ctx, "X-Forwarded-Host"); forwarded != "" {
+		host = forwarded
+	}
+
+	prefix := strings.Trim(forwardedHeader(ctx, "X-Forwarded-Prefix"), "/")
+	if prefix != "" {
+		prefix = "/" + prefix
+	}
+	return scheme + "://" + host + prefix
+}
+
+// forwardedHeader returns value set by the closest proxy to the client
+func forwardedHeader(ctx *gin.Context, name string) string {
+	value, _, _ := strings.Cut(ctx.GetHeader(name), ",")
+	return strings.TrimSpace(value)
+}
@@ -1,15 +1,54 @@
 package api
 
 import (
-    _ "embed"
-    "net/http"
+	_ "embed"
+	"net/http"
+	"sync"
 
-    "github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin"
+	"gopkg.in/yaml.v3"
 )
 
 //go:embed fpjp.openapi.yaml
 var openapiSpec []byte
 
-func HandleOpenApi(ctx *gin.Context) {
-    ctx.Data(http.StatusOK, "application/yaml", openapiSpec)
-}
\ No newline at end of file
+//go:embed fpjp.v2.openapi.yaml
+var openapiSpecV2 []byte
+
+// Version is one version of the API described by its own OpenAPI spec
+type Version struct {
+	// name used in the base path of the version, e.g. v1 for /api/v1
+	Name string
+	spec []byte
+	// spec is parsed once for the JSON rendering
+	parse func() (map[string]interface{}, error)
+}
+
+var (
+	V1 = newVersion("v1", openapiSpec)
+	V2 = newVersion("v2", openapiSpecV2)
+)
+
+// Versions lists the versions of the API served by the service, the oldest first
+var Versions = []*Version{V1, V2}
+
+func newVersion(name string, spec []byte) *Version {
+	return &Version{
+		Name: name,
+		spec: spec,
+		parse: sync.OnceValues(func() (map[string]interface{}, error) {
+			parsed := map[string]interface{}{}
+			err := yaml.Unmarshal(spec, &parsed)
+			return parsed, err
+		}),
+	}
+}
+
+// Specification returns the embedded OpenAPI spec of the version
+func (this *Version) Specification() []byte {
+	return this.spec
+}
+
+func (this *Version) HandleOpenApi(ctx *gin.Context) {
+	ctx.Data(http.StatusOK, "application/yaml", this.spec)
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/fpjp"
+)
+
+// fpjp-admin is a maintenance tool working directly with the service database,
+// it is configured by the same AMBULANCE_API_MONGODB_* variables as the service
+
+type command struct {
+	name        string
+	description string
+	run         func(ctx context.Context, client *db_service.MongoClient, args []string) error
+}
+
+var commands = []command{
+	{"seed", "insert documents from YAML or JSON fixture file", runSeed},
+	{"export", "export collections to extended JSON files", runExport},
+	{"import", "import collections from extended JSON files", runImport},
+	{"migrate", "apply pending database migrations or show their status", runMigrate},
+	{"verify", "check duplicate ids and references between documents", runVerify},
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: fpjp-admin [-v] <command> [options]\n\nCommands:\n")
+	for _, command := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", command.name, command.description)
+	}
+	fmt.Fprintf(os.Stderr, "\nRun fpjp-admin <command> -h for options of the command.\n")
+	// there is no command creating users, the service has no user store to create them in
+	fmt.Fprintf(os.Stderr, "\nUsers are not managed by fpjp-admin, the service identifies them by the X-Forwarded-User\n"+
+		"and X-Forwarded-Groups headers of the authenticating proxy, create users and admin group\n"+
+		"memberships in the identity provider of the proxy.\n")
+}
+
+func main() {
+	verbose := flag.Bool("v", false, "log database operations")
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	level := slog.LevelWarn
+	if *verbose {
+		level = slog.LevelDebug
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})))
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	name := flag.Arg(0)
+	for _, command := range commands {
+		if command.name != name {
+			continue
+		}
+		client := db_service.NewMongoClient(db_service.MongoClientConfig{})
+		err := command.run(ctx, client, flag.Args()[1:])
+		client.Disconnect(context.Background())
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v: %v\n", name, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+	usage()
+	os.Exit(2)
+}
+
+// services of the collections managed by the API, configured the same way as in the service
+type services struct {
+	departments  db_service.DbService[fpjp.Department]
+	rooms        db_service.DbService[fpjp.Room]
+	equipment    db_service.DbService[fpjp.Equipment]
+	requests     db_service.DbService[fpjp.Request]
+	movements    db_service.DbService[fpjp.StockMovement]
+	reservations db_service.DbService[fpjp.Reservation]
+}
+
+func newServices(client *db_service.MongoClient) services {
+	return services{
+		departments: db_service.NewMongoService[fpjp.Department](db_service.MongoServiceConfig{
+			Client:     client,
+			Collection: "departments",
+		}),
+		rooms: db_service.NewMongoService[fpjp.Room](db_service.MongoServiceConfig{
+			Client:     client,
+			Collection: "rooms",
+		}),
+		equipment: db_service.NewMongoService[fpjp.Equipment](db_service.MongoServiceConfig{
+			Client:     client,
+			Collection: "equipment",
+			Versioned:  true,
+		}),
+		requests: db_service.NewMongoService[fpjp.Request](db_service.MongoServiceConfig{
+			Client:     client,
+			Collection: "requests",
+			Versioned:  true,
+		}),
+		movements: db_service.NewMongoService[fpjp.StockMovement](db_service.MongoServiceConfig{
+			Client:     client,
+			Collection: "stock_movements",
+		}),
+		reservations: db_service.NewMongoService[fpjp.Reservation](db_service.MongoServiceConfig{
+			Client:     client,
+			Collection: "reservations",
+		}),
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/migrations"
+)
+
+func runMigrate(ctx context.Context, client *db_service.MongoClient, args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	status := flags.Bool("status", false, "only list applied and pending migrations")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	db, err := client.Database(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !*status {
+		applied, err := migrations.Run(ctx, db, migrations.All)
+		for _, id := range applied {
+			fmt.Printf("applied %v\n", id)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Println("database is up to date")
+		}
+		return err
+	}
+
+	applied, err := migrations.Applied(ctx, db)
+	if err != nil {
+		return err
+	}
+	for _, migration := range migrations.All {
+		if record, ok := applied[migration.Id]; ok {
+			fmt.Printf("%-28s applied %v\n", migration.Id, record.AppliedAt.Format(time.RFC3339))
+		} else {
+			fmt.Printf("%-28s pending\n", migration.Id)
+		}
+	}
+	return nil
+}
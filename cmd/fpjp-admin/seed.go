@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/fixtures"
+)
+
+func runSeed(ctx context.Context, client *db_service.MongoClient, args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	file := flags.String("file", "", "fixture file (.yaml, .yml or .json), built-in sample data if empty")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var fixture *fixtures.Fixture
+	var err error
+	if *file == "" {
+		fixture, err = fixtures.InitialData()
+	} else {
+		fixture, err = fixtures.Load(*file)
+	}
+	if err != nil {
+		return err
+	}
+
+	services := newServices(client)
+	result, err := fixtures.Seed(ctx, fixtures.Services{
+		Departments:  services.departments,
+		Rooms:        services.rooms,
+		Equipment:    services.equipment,
+		Requests:     services.requests,
+		Reservations: services.reservations,
+	}, fixture)
+
+	collections := []string{}
+	for collection := range result.Inserted {
+		collections = append(collections, collection)
+	}
+	for collection := range result.Skipped {
+		if _, ok := result.Inserted[collection]; !ok {
+			collections = append(collections, collection)
+		}
+	}
+	sort.Strings(collections)
+	for _, collection := range collections {
+		fmt.Printf("%-14s inserted %d, skipped %d existing\n", collection, result.Inserted[collection], result.Skipped[collection])
+	}
+	return err
+}
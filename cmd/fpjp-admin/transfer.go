@@ -0,0 +1,208 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// collections are exported one document per line in canonical extended JSON,
+// so that types like dates survive the round trip
+const exportExtension = ".jsonl"
+
+const importBatchSize = 500
+
+// lock of the migration runner is never exported
+const migrationLockCollection = "schema_migrations_lock"
+
+func collectionsFlag(flags *flag.FlagSet) *string {
+	return flags.String("collections", "", "comma separated collections, all collections if empty")
+}
+
+func splitCollections(value string) []string {
+	collections := []string{}
+	for _, collection := range strings.Split(value, ",") {
+		if collection = strings.TrimSpace(collection); collection != "" {
+			collections = append(collections, collection)
+		}
+	}
+	return collections
+}
+
+func runExport(ctx context.Context, client *db_service.MongoClient, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	dir := flags.String("dir", ".", "directory to write <collection>"+exportExtension+" files to")
+	only := collectionsFlag(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	db, err := client.Database(ctx)
+	if err != nil {
+		return err
+	}
+	collections := splitCollections(*only)
+	if len(collections) == 0 {
+		collections, err = db.ListCollectionNames(ctx, bson.M{"name": bson.M{"$ne": migrationLockCollection}})
+		if err != nil {
+			return err
+		}
+		sort.Strings(collections)
+	}
+	if err := os.MkdirAll(*dir, 0o755); err != nil {
+		return err
+	}
+
+	for _, collection := range collections {
+		count, err := exportCollection(ctx, db.Collection(collection), filepath.Join(*dir, collection+exportExtension))
+		if err != nil {
+			return fmt.Errorf("failed to export %v: %w", collection, err)
+		}
+		fmt.Printf("%-24s exported %d documents\n", collection, count)
+	}
+	return nil
+}
+
+func exportCollection(ctx context.Context, collection *mongo.Collection, path string) (int, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	writer := bufio.NewWriter(file)
+
+	cursor, err := collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	count := 0
+	for cursor.Next(ctx) {
+		line, err := bson.MarshalExtJSON(cursor.Current, true, false)
+		if err != nil {
+			return count, err
+		}
+		writer.Write(line)
+		writer.WriteByte('\n')
+		count++
+	}
+	if err := cursor.Err(); err != nil {
+		return count, err
+	}
+	if err := writer.Flush(); err != nil {
+		return count, err
+	}
+	return count, file.Close()
+}
+
+func runImport(ctx context.Context, client *db_service.MongoClient, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	dir := flags.String("dir", ".", "directory with <collection>"+exportExtension+" files")
+	only := collectionsFlag(flags)
+	drop := flags.Bool("drop", false, "remove all documents of the collection before the import")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	collections := splitCollections(*only)
+	if len(collections) == 0 {
+		files, err := filepath.Glob(filepath.Join(*dir, "*"+exportExtension))
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			collections = append(collections, strings.TrimSuffix(filepath.Base(file), exportExtension))
+		}
+		sort.Strings(collections)
+	}
+	if len(collections) == 0 {
+		return fmt.Errorf("no %v files found in %v", exportExtension, *dir)
+	}
+
+	db, err := client.Database(ctx)
+	if err != nil {
+		return err
+	}
+	for _, collection := range collections {
+		if *drop {
+			if _, err := db.Collection(collection).DeleteMany(ctx, bson.M{}); err != nil {
+				return fmt.Errorf("failed to clear %v: %w", collection, err)
+			}
+		}
+		count, err := importCollection(ctx, db.Collection(collection), filepath.Join(*dir, collection+exportExtension))
+		if err != nil {
+			return fmt.Errorf("failed to import %v: %w", collection, err)
+		}
+		fmt.Printf("%-24s imported %d documents\n", collection, count)
+	}
+	return nil
+}
+
+// importCollection replaces documents with the same _id, so the import can be repeated
+func importCollection(ctx context.Context, collection *mongo.Collection, path string) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	count := 0
+	batch := []mongo.WriteModel{}
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		_, err := collection.BulkWrite(ctx, batch)
+		if err != nil {
+			return err
+		}
+		count += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		var document bson.D
+		if err := bson.UnmarshalExtJSON(scanner.Bytes(), true, &document); err != nil {
+			return count, fmt.Errorf("line %d: %w", line, err)
+		}
+		var id interface{}
+		for _, element := range document {
+			if element.Key == "_id" {
+				id = element.Value
+			}
+		}
+		if id == nil {
+			batch = append(batch, mongo.NewInsertOneModel().SetDocument(document))
+		} else {
+			batch = append(batch, mongo.NewReplaceOneModel().SetFilter(bson.M{"_id": id}).SetReplacement(document).SetUpsert(true))
+		}
+		if len(batch) >= importBatchSize {
+			if err := flush(); err != nil {
+				return count, err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return count, err
+	}
+	return count, flush()
+}
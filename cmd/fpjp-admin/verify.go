@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/fpjp"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// problem found in a single document
+type problem struct {
+	collection string
+	id         string
+	message    string
+}
+
+// runVerify reports documents sharing the same id and documents referencing missing documents,
+// soft deleted documents are considered existing since they can still be restored
+func runVerify(ctx context.Context, client *db_service.MongoClient, args []string) error {
+	flags := flag.NewFlagSet("verify", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	services := newServices(client)
+	ctx = db_service.WithDeleted(ctx)
+	all := bson.M{}
+
+	departments, err := services.departments.FindDocuments(ctx, all)
+	if err != nil {
+		return err
+	}
+	rooms, err := services.rooms.FindDocuments(ctx, all)
+	if err != nil {
+		return err
+	}
+	equipment, err := services.equipment.FindDocuments(ctx, all)
+	if err != nil {
+		return err
+	}
+	requests, err := services.requests.FindDocuments(ctx, all)
+	if err != nil {
+		return err
+	}
+	movements, err := services.movements.FindDocuments(ctx, all)
+	if err != nil {
+		return err
+	}
+	reservations, err := services.reservations.FindDocuments(ctx, all)
+	if err != nil {
+		return err
+	}
+
+	departmentIds := map[string]bool{}
+	for _, department := range departments {
+		departmentIds[department.Id] = true
+	}
+	roomIds := map[string]bool{}
+	for _, room := range rooms {
+		roomIds[room.Id] = true
+	}
+	equipmentIds := map[string]bool{}
+	for _, item := range equipment {
+		equipmentIds[item.Id] = true
+	}
+
+	problems := []problem{}
+	problems = append(problems, duplicateIds("departments", departments, func(d fpjp.Department) string { return d.Id })...)
+	problems = append(problems, duplicateIds("rooms", rooms, func(r fpjp.Room) string { return r.Id })...)
+	problems = append(problems, duplicateIds("equipment", equipment, func(e fpjp.Equipment) string { return e.Id })...)
+	problems = append(problems, duplicateIds("requests", requests, func(r fpjp.Request) string { return r.Id })...)
+	problems = append(problems, duplicateIds("stock_movements", movements, func(m fpjp.StockMovement) string { return m.Id })...)
+	problems = append(problems, duplicateIds("reservations", reservations, func(r fpjp.Reservation) string { return r.Id })...)
+
+	for _, room := range rooms {
+		if !departmentIds[room.DepartmentId] {
+			problems = append(problems, problem{"rooms", room.Id, fmt.Sprintf("department %q does not exist", room.DepartmentId)})
+		}
+	}
+	for _, item := range equipment {
+		if !roomIds[item.Room] {
+			problems = append(problems, problem{"equipment", item.Id, fmt.Sprintf("room %q does not exist", item.Room)})
+		}
+		if item.Count < 0 {
+			problems = append(problems, problem{"equipment", item.Id, fmt.Sprintf("count %d is negative", item.Count)})
+		}
+	}
+	for _, request := range requests {
+		if !roomIds[request.Room] {
+			problems = append(problems, problem{"requests", request.Id, fmt.Sprintf("room %q does not exist", request.Room)})
+		}
+	}
+	for _, movement := range movements {
+		if !equipmentIds[movement.EquipmentId] {
+			problems = append(problems, problem{"stock_movements", movement.Id, fmt.Sprintf("equipment %q does not exist", movement.EquipmentId)})
+		}
+	}
+	for _, reservation := range reservations {
+		if !equipmentIds[reservation.EquipmentId] {
+			problems = append(problems, problem{"reservations", reservation.Id, fmt.Sprintf("equipment %q does not exist", reservation.EquipmentId)})
+		}
+		if !roomIds[reservation.Room] {
+			problems = append(problems, problem{"reservations", reservation.Id, fmt.Sprintf("room %q does not exist", reservation.Room)})
+		}
+	}
+
+	for _, problem := range problems {
+		fmt.Printf("%-16s %-38s %s\n", problem.collection, problem.id, problem.message)
+	}
+	fmt.Printf(
+		"checked %d departments, %d rooms, %d equipment, %d requests, %d stock movements, %d reservations\n",
+		len(departments), len(rooms), len(equipment), len(requests), len(movements), len(reservations),
+	)
+	if len(problems) > 0 {
+		return fmt.Errorf("found %d problems", len(problems))
+	}
+	return nil
+}
+
+// duplicateIds reports ids shared by multiple documents, they prevent the unique id index
+// of the migration 0002_unique_ids from being built
+func duplicateIds[T any](collection string, documents []*T, id func(T) string) []problem {
+	counts := map[string]int{}
+	ids := []string{}
+	for _, document := range documents {
+		documentId := id(*document)
+		if counts[documentId] == 0 {
+			ids = append(ids, documentId)
+		}
+		counts[documentId]++
+	}
+
+	problems := []problem{}
+	for _, documentId := range ids {
+		if counts[documentId] > 1 {
+			problems = append(problems, problem{collection, documentId, fmt.Sprintf("id is shared by %d documents", counts[documentId])})
+		}
+	}
+	return problems
+}
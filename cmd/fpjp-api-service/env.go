@@ -0,0 +1,47 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+)
+
+// configuration of the service is read from the AMBULANCE_API_* variables, invalid values
+// are reported and replaced by the defaults so that a typo does not stop the service
+
+func envInt(name string, defaultValue int) int {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return defaultValue
+	}
+	if value, err := strconv.Atoi(value); err == nil {
+		return value
+	}
+	slog.Warn("Invalid integer value, using default", "variable", name, "value", value)
+	return defaultValue
+}
+
+func envBool(name string, defaultValue bool) bool {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return defaultValue
+	}
+	if value, err := strconv.ParseBool(value); err == nil {
+		return value
+	}
+	slog.Warn("Invalid boolean value, using default", "variable", name, "value", value)
+	return defaultValue
+}
+
+func envTime(name string, defaultValue time.Time) time.Time {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return defaultValue
+	}
+	if value, err := time.Parse(time.RFC3339, value); err == nil {
+		return value
+	}
+	slog.Warn("Invalid RFC 3339 time value, using default", "variable", name, "value", value)
+	return defaultValue
+}
@@ -2,80 +2,337 @@ package main
 
 import (
 	"context"
-	"log"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
 	"strings"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/ns-super-team/fpjp-ambulance-webapi/api"
 	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/events"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/fhir"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/fixtures"
 	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/fpjp"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/fpjpv2"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/graph"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/grpcapi"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/health"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/idempotency"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/logging"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/metrics"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/problem"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/tracing"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/validation"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/versioning"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.mongodb.org/mongo-driver/bson"
 )
 
 func main() {
-	log.Printf("Server started")
+	logging.Setup()
+	slog.Info("Server started")
 	port := os.Getenv("AMBULANCE_API_PORT")
 	if port == "" {
 		port = "8080"
 	}
+	grpcPort := os.Getenv("AMBULANCE_API_GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "50051"
+	}
 	environment := os.Getenv("AMBULANCE_API_ENVIRONMENT")
 	if !strings.EqualFold(environment, "production") { // case insensitive comparison
 		gin.SetMode(gin.DebugMode)
 	}
+	if strings.TrimSpace(os.Getenv("AMBULANCE_API_TRUSTED_PROXIES")) == "" {
+		slog.Warn("Identity headers are trusted from any client, the service must be reachable only through the authenticating proxy")
+	}
+	shutdownTimeout := time.Duration(envInt("AMBULANCE_API_SHUTDOWN_TIMEOUT_SECONDS", 20)) * time.Second
+	shutdownDelay := time.Duration(envInt("AMBULANCE_API_SHUTDOWN_DELAY_SECONDS", 0)) * time.Second
+
+	// cancelled on SIGINT or SIGTERM, starts graceful shutdown
+	signalCtx, stopSignals := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stopSignals()
+
+	shutdownTracing, err := tracing.Setup(context.Background(), "fpjp-ambulance-webapi")
+	if err != nil {
+		slog.Error("Failed to setup tracing", "error", err)
+		os.Exit(1)
+	}
+
 	engine := gin.New()
-	engine.Use(gin.Recovery())
+	// services receive gin context, let it expose values of the request context like active span
+	engine.ContextWithFallback = true
+	engine.Use(logging.Middleware())
+	engine.Use(problem.Recovery())
+	engine.Use(tracing.Middleware())
+	engine.Use(metrics.Middleware())
 	corsMiddleware := cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
 		AllowMethods:     []string{"GET", "PUT", "POST", "DELETE", "PATCH"},
-		AllowHeaders:     []string{"Origin", "Authorization", "Content-Type"},
-		ExposeHeaders:    []string{""},
+		AllowHeaders:     []string{"Origin", "Authorization", "Content-Type", logging.RequestIDHeader, idempotency.KeyHeader},
+		ExposeHeaders:    []string{logging.RequestIDHeader, "Deprecation", "Sunset", "Link", idempotency.ReplayedHeader},
 		AllowCredentials: false,
 		MaxAge:           12 * time.Hour,
 	})
 	engine.Use(corsMiddleware)
 
+	// reject requests which do not match the OpenAPI spec of their version,
+	// responses are checked by default only in development environment
+	validateResponses := envBool("AMBULANCE_API_VALIDATE_RESPONSES", strings.EqualFold(environment, "development"))
+	validationMiddleware := map[*api.Version]gin.HandlerFunc{}
+	for _, version := range api.Versions {
+		validationMiddleware[version], err = validation.Middleware(version.Specification(), validation.Options{
+			ValidateResponses: validateResponses,
+		})
+		if err != nil {
+			slog.Error("Failed to setup request validation", "version", version.Name, "error", err)
+			os.Exit(1)
+		}
+	}
+
+	// all services share single connection pool
+	mongoClient := db_service.NewMongoClient(db_service.MongoClientConfig{})
+
+	// `fpjp-api-service migrate` only migrates the database and exits
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		err := runMigrations(mongoClient)
+		mongoClient.Disconnect(context.Background())
+		if err != nil {
+			slog.Error("Database migration failed", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if envBool("AMBULANCE_API_MIGRATE_ON_STARTUP", true) {
+		if err := runMigrations(mongoClient); err != nil {
+			slog.Error("Database migration failed", "error", err)
+			os.Exit(1)
+		}
+	}
+
 	// setup contexts
 	departmentService := db_service.NewMongoService[fpjp.Department](db_service.MongoServiceConfig{
+		Client:     mongoClient,
 		Collection: "departments",
 	})
-	defer departmentService.Disconnect(context.Background())
 
-	equipmentService := db_service.NewMongoService[fpjp.Equipment](db_service.MongoServiceConfig{
+	// changes of the equipment and requests are streamed to the GraphQL and gRPC subscribers
+	equipmentChanges := events.NewBroker[fpjp.Equipment]("equipment")
+	equipmentService := events.Publishing(db_service.NewMongoService[fpjp.Equipment](db_service.MongoServiceConfig{
+		Client:     mongoClient,
 		Collection: "equipment",
-	})
-	defer equipmentService.Disconnect(context.Background())
+		Versioned:  true,
+	}), equipmentChanges)
 
-	requestService := db_service.NewMongoService[fpjp.Request](db_service.MongoServiceConfig{
+	requestChanges := events.NewBroker[fpjp.Request]("requests")
+	requestService := events.Publishing(db_service.NewMongoService[fpjp.Request](db_service.MongoServiceConfig{
+		Client:     mongoClient,
 		Collection: "requests",
-	})
-	defer requestService.Disconnect(context.Background())
+		Versioned:  true,
+	}), requestChanges)
 
 	roomService := db_service.NewMongoService[fpjp.Room](db_service.MongoServiceConfig{
+		Client:     mongoClient,
 		Collection: "rooms",
 	})
-	defer roomService.Disconnect(context.Background())
+
+	movementService := db_service.NewMongoService[fpjp.StockMovement](db_service.MongoServiceConfig{
+		Client:     mongoClient,
+		Collection: "stock_movements",
+	})
+
+	reservationService := db_service.NewMongoService[fpjp.Reservation](db_service.MongoServiceConfig{
+		Client:     mongoClient,
+		Collection: "reservations",
+	})
+
+	lockService := db_service.NewMongoService[fpjp.Lock](db_service.MongoServiceConfig{
+		Client:     mongoClient,
+		Collection: "locks",
+	})
+
+	idempotencyService := db_service.NewMongoService[idempotency.Record](db_service.MongoServiceConfig{
+		Client:     mongoClient,
+		Collection: "idempotency_keys",
+	})
+
+	// business rules shared by the REST, GraphQL and gRPC APIs
+	inventory := fpjp.NewInventory(fpjp.InventoryConfig{
+		Departments:      departmentService,
+		Rooms:            roomService,
+		Equipment:        equipmentService,
+		Requests:         requestService,
+		Movements:        movementService,
+		Reservations:     reservationService,
+		Locks:            lockService,
+		EquipmentChanges: equipmentChanges,
+		RequestChanges:   requestChanges,
+		Transactions:     mongoClient.WithTransaction,
+	})
 
 	// db initialization
-	if environment == "development" {
+	if strings.EqualFold(environment, "development") {
 		insertInitialData(departmentService, roomService)
 	}
 
+	// hard delete documents after retention period
+	purgeCtx, stopPurge := context.WithCancel(context.Background())
+	purgeDone := startPurgeJob(purgeCtx, map[string]purger{
+		"equipment":    equipmentService,
+		"requests":     requestService,
+		"reservations": reservationService,
+	})
+
+	// domain gauges recomputed at most once per refresh interval
+	prometheus.MustRegister(fpjp.NewDomainCollector(roomService, requestService, equipmentService,
+		time.Duration(envInt("AMBULANCE_API_METRICS_REFRESH_SECONDS", 60))*time.Second))
+
+	// readiness depends on database connectivity
+	healthChecker := health.NewChecker(time.Duration(envInt("AMBULANCE_API_HEALTH_TIMEOUT_SECONDS", 2)) * time.Second)
+	healthChecker.Register("mongodb", mongoClient.Ping)
+
 	// update middleware
 	engine.Use(func(ctx *gin.Context) {
 		ctx.Set("department_service", departmentService)
 		ctx.Set("equipment_service", equipmentService)
 		ctx.Set("request_service", requestService)
 		ctx.Set("room_service", roomService)
+		ctx.Set("movement_service", movementService)
+		ctx.Set("reservation_service", reservationService)
+		ctx.Set("inventory", inventory)
 		ctx.Next()
 	})
 
-	// request routings
-	fpjp.AddRoutes(engine)
-	engine.GET("/openapi", api.HandleOpenApi)
-	engine.Run(":" + port)
+	// retried POST requests with the same Idempotency-Key get the stored response
+	idempotencyMiddleware := idempotency.Middleware(idempotency.Config{
+		Store:             idempotencyService,
+		Retention:         time.Duration(envInt("AMBULANCE_API_IDEMPOTENCY_RETENTION_HOURS", 24)) * time.Hour,
+		ProcessingTimeout: time.Duration(envInt("AMBULANCE_API_IDEMPOTENCY_PROCESSING_TIMEOUT_SECONDS", 60)) * time.Second,
+		Caller:            func(ctx *gin.Context) string { return fpjp.RequestCaller(ctx).User },
+	})
+
+	// request routings, /api is the deprecated alias of /api/v1
+	fpjp.AddRoutesAt(engine.Group("/api/v1", validationMiddleware[api.V1], idempotencyMiddleware))
+	fpjpv2.AddRoutesAt(engine.Group("/api/v2", validationMiddleware[api.V2], idempotencyMiddleware))
+	legacy := versioning.Deprecated(versioning.Deprecation{
+		DeprecatedAt:  envTime("AMBULANCE_API_LEGACY_DEPRECATED_AT", time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)),
+		Sunset:        envTime("AMBULANCE_API_LEGACY_SUNSET", time.Date(2027, time.April, 30, 0, 0, 0, 0, time.UTC)),
+		BasePath:      "/api",
+		SuccessorPath: "/api/v1",
+	})
+	fpjp.AddRoutesAt(engine.Group("/api", legacy, validationMiddleware[api.V1], idempotencyMiddleware))
+	for _, version := range api.Versions {
+		engine.GET("/api/"+version.Name+"/openapi", version.HandleOpenApi)
+		engine.GET("/api/"+version.Name+"/openapi.json", version.HandleOpenApiJson)
+	}
+	engine.GET("/openapi", api.V1.HandleOpenApi)
+	engine.GET("/openapi.json", api.V1.HandleOpenApiJson)
+	engine.GET("/docs", api.HandleDocs)
+	engine.GET("/docs/*filepath", api.HandleDocsAssets)
+	graphHandler, err := graph.NewHandler(graph.Services{
+		Departments: departmentService,
+		Rooms:       roomService,
+		Equipment:   equipmentService,
+		Requests:    requestService,
+		Inventory:   inventory,
+	})
+	if err != nil {
+		slog.Error("Failed to setup GraphQL endpoint", "error", err)
+		os.Exit(1)
+	}
+	engine.POST("/graphql", graphHandler.Handle)
+	// FHIR R4 facade for the hospital information system, its POSTs are retried the same way
+	fhir.NewHandler(fhir.Services{
+		Departments: departmentService,
+		Rooms:       roomService,
+		Equipment:   equipmentService,
+		Requests:    requestService,
+		Inventory:   inventory,
+	}).AddRoutes(engine.Group("/fhir", idempotencyMiddleware))
+	engine.GET("/metrics", metrics.Handler())
+	engine.GET("/healthz", healthChecker.HandleLiveness)
+	engine.GET("/readyz", healthChecker.HandleReadiness)
+	engine.NoRoute(func(ctx *gin.Context) {
+		problem.Respond(ctx, problem.NotFound("route", "No resource is available at the requested path."))
+	})
+
+	// gRPC API on its own port, the health service follows readiness of the dependencies
+	grpcServer := grpcapi.NewServer(grpcapi.Config{
+		Inventory:      inventory,
+		Health:         healthChecker,
+		HealthInterval: time.Duration(envInt("AMBULANCE_API_GRPC_HEALTH_INTERVAL_SECONDS", 10)) * time.Second,
+	})
+	grpcListener, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		slog.Error("Failed to listen for gRPC calls", "port", grpcPort, "error", err)
+		os.Exit(1)
+	}
+
+	server := &http.Server{
+		Addr:    ":" + port,
+		Handler: engine,
+	}
+	// subscriptions would otherwise keep their connections open until the shutdown timeout
+	server.RegisterOnShutdown(graphHandler.Close)
+	// set also by the goroutines of the servers
+	var exitCode atomic.Int32
+	go func() {
+		err := server.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("Server failed", "error", err)
+			exitCode.Store(1)
+			stopSignals()
+		}
+	}()
+	go func() {
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			slog.Error("gRPC server failed", "error", err)
+			exitCode.Store(1)
+			stopSignals()
+		}
+	}()
+
+	<-signalCtx.Done()
+	stopSignals() // second signal terminates immediately
+	slog.Info("Shutting down", "timeout", shutdownTimeout.String())
+
+	// let load balancers notice the pod is not ready before closing the listener
+	healthChecker.SetShuttingDown()
+	time.Sleep(shutdownDelay)
+
+	// stop accepting new connections and wait for in-flight requests
+	drainCtx, cancelDrain := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancelDrain()
+	if err := server.Shutdown(drainCtx); err != nil {
+		slog.Error("Failed to drain in-flight requests", "error", err)
+		exitCode.Store(1)
+	}
+	grpcServer.Shutdown(drainCtx)
+
+	// stop background workers before closing their database connections
+	stopPurge()
+	<-purgeDone
+
+	cleanupCtx, cancelCleanup := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancelCleanup()
+	if err := mongoClient.Disconnect(cleanupCtx); err != nil {
+		slog.Error("Failed to disconnect from database", "error", err)
+		exitCode.Store(1)
+	}
+
+	if err := shutdownTracing(cleanupCtx); err != nil {
+		slog.Error("Failed to flush traces", "error", err)
+	}
+	slog.Info("Server stopped")
+	os.Exit(int(exitCode.Load()))
 }
 
 // populates Departments and Rooms with initial data, if these collections are empty
@@ -84,7 +341,8 @@ func insertInitialData(departmentService db_service.DbService[fpjp.Department],
 
 	departments, err := departmentService.FindDocuments(ctx, bson.M{})
 	if err != nil {
-		log.Fatalf("Error checking departments collection: %v", err)
+		slog.Error("Error checking departments collection", "error", err)
+		os.Exit(1)
 	}
 	if len(departments) > 0 {
 		return
@@ -92,43 +350,21 @@ func insertInitialData(departmentService db_service.DbService[fpjp.Department],
 
 	rooms, err := roomService.FindDocuments(ctx, bson.M{})
 	if err != nil {
-		log.Fatalf("Error checking rooms collection: %v", err)
+		slog.Error("Error checking rooms collection", "error", err)
+		os.Exit(1)
 	}
 	if len(rooms) > 0 {
 		return
 	}
 
-	initialDepartments := []fpjp.Department{
-		{Id: "1", Name: "Pediatrické oddelenie"},
-		{Id: "2", Name: "Chirurgia"},
-		{Id: "3", Name: "Alergológia"},
-		{Id: "4", Name: "Ortopédia"},
-		{Id: "5", Name: "Neurológia"},
-	}
-
-	initialRooms := []fpjp.Room{
-		{Id: "1", DepartmentId: "1", Name: "Miestnosť 1.1"},
-		{Id: "2", DepartmentId: "1", Name: "Miestnosť 1.2"},
-		{Id: "3", DepartmentId: "2", Name: "Miestnosť 2.1"},
-		{Id: "4", DepartmentId: "2", Name: "Miestnosť 2.2"},
-		{Id: "5", DepartmentId: "2", Name: "Miestnosť 2.3"},
-		{Id: "6", DepartmentId: "3", Name: "Miestnosť 3.1"},
-		{Id: "7", DepartmentId: "4", Name: "Miestnosť 4.1"},
-		{Id: "8", DepartmentId: "5", Name: "Miestnosť 5.1"},
-		{Id: "9", DepartmentId: "5", Name: "Miestnosť 5.2"},
-	}
-
-	for _, department := range initialDepartments {
-		err := departmentService.CreateDocument(ctx, department.Id, &department)
-		if err != nil {
-			log.Fatalf("Failed to insert department %s: %v", department.Id, err)
-		}
+	fixture, err := fixtures.InitialData()
+	if err != nil {
+		slog.Error("Failed to load initial data", "error", err)
+		os.Exit(1)
 	}
-
-	for _, room := range initialRooms {
-		err := roomService.CreateDocument(ctx, room.Id, &room)
-		if err != nil {
-			log.Fatalf("Failed to insert room %s: %v", room.Id, err)
-		}
+	_, err = fixtures.Seed(ctx, fixtures.Services{Departments: departmentService, Rooms: roomService}, fixture)
+	if err != nil {
+		slog.Error("Failed to insert initial data", "error", err)
+		os.Exit(1)
 	}
 }
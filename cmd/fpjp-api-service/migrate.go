@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/migrations"
+)
+
+// runMigrations brings indexes and data of the database up to date
+func runMigrations(client *db_service.MongoClient) error {
+	timeout := time.Duration(envInt("AMBULANCE_API_MIGRATION_TIMEOUT_SECONDS", 300)) * time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	db, err := client.Database(ctx)
+	if err != nil {
+		return err
+	}
+	applied, err := migrations.Run(ctx, db, migrations.All)
+	if err != nil {
+		return err
+	}
+	slog.Info("Database migrations finished", "applied", applied)
+	return nil
+}
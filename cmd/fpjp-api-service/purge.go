@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type purger interface {
+	PurgeDocuments(ctx context.Context, deletedBefore time.Time) (int64, error)
+}
+
+// startPurgeJob periodically hard deletes documents which were soft deleted
+// longer than the configured retention period ago, the returned channel is closed
+// once the job stops after ctx is cancelled
+func startPurgeJob(ctx context.Context, services map[string]purger) <-chan struct{} {
+	done := make(chan struct{})
+	retentionDays := envInt("AMBULANCE_API_PURGE_RETENTION_DAYS", 30)
+	intervalMinutes := envInt("AMBULANCE_API_PURGE_INTERVAL_MINUTES", 60)
+	if retentionDays <= 0 || intervalMinutes <= 0 {
+		slog.Info("Purge of deleted documents is disabled")
+		close(done)
+		return done
+	}
+
+	retention := time.Duration(retentionDays) * 24 * time.Hour
+	interval := time.Duration(intervalMinutes) * time.Minute
+	slog.Info("Purging deleted documents", "retention", retention.String(), "interval", interval.String())
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			purgeDeleted(ctx, services, time.Now().Add(-retention))
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return done
+}
+
+func purgeDeleted(ctx context.Context, services map[string]purger, deletedBefore time.Time) {
+	for collection, service := range services {
+		if ctx.Err() != nil {
+			return
+		}
+		count, err := service.PurgeDocuments(ctx, deletedBefore)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to purge deleted documents", "collection", collection, "error", err)
+			continue
+		}
+		if count > 0 {
+			slog.InfoContext(ctx, "Purged deleted documents", "collection", collection, "count", count)
+		}
+	}
+}
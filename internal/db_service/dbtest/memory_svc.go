@@ -0,0 +1,612 @@
+// Package dbtest provides in-memory implementation of the database services for the tests
+// of the packages using them, it is never linked into the service
+package dbtest
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryStore groups in-memory services so that they can take part in the same transaction,
+// the services keep the semantics of the MongoDB ones and are used by the tests of the APIs
+type MemoryStore struct {
+	// simulates standalone server, WithTransaction then returns db_service.ErrTransactionsNotSupported
+	TransactionsNotSupported bool
+
+	mutex       sync.Mutex
+	transaction sync.Mutex
+	collections []memoryCollection
+}
+
+type memoryCollection interface {
+	// snapshot returns the function restoring the current content of the collection
+	snapshot() func()
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+// WithTransaction runs the function with the services of the store, their changes are rolled back when
+// the function fails, transactions are serialized the same way the write conflicts serialize them in MongoDB
+func (this *MemoryStore) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if this.TransactionsNotSupported {
+		return db_service.ErrTransactionsNotSupported
+	}
+	this.transaction.Lock()
+	defer this.transaction.Unlock()
+
+	this.mutex.Lock()
+	restores := make([]func(), 0, len(this.collections))
+	for _, collection := range this.collections {
+		restores = append(restores, collection.snapshot())
+	}
+	this.mutex.Unlock()
+
+	err := fn(ctx)
+	if err != nil {
+		for _, restore := range restores {
+			restore()
+		}
+	}
+	return err
+}
+
+type MemoryServiceConfig struct {
+	// store of the transactions, if nil the service has its own one
+	Store *MemoryStore
+	// keep snapshot of each change of the document
+	Versioned bool
+}
+
+type memorySvc[DocType interface{}] struct {
+	MemoryServiceConfig
+
+	mutex     sync.Mutex
+	ids       []string
+	documents map[string]bson.M
+	versions  map[string][]bson.M
+}
+
+// NewMemoryService creates service keeping the documents in memory
+func NewMemoryService[DocType interface{}](config MemoryServiceConfig) db_service.DbService[DocType] {
+	if config.Store == nil {
+		config.Store = NewMemoryStore()
+	}
+	service := &memorySvc[DocType]{
+		MemoryServiceConfig: config,
+		documents:           map[string]bson.M{},
+		versions:            map[string][]bson.M{},
+	}
+	config.Store.mutex.Lock()
+	config.Store.collections = append(config.Store.collections, service)
+	config.Store.mutex.Unlock()
+	return service
+}
+
+func (this *memorySvc[DocType]) snapshot() func() {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	ids := append([]string{}, this.ids...)
+	documents := make(map[string]bson.M, len(this.documents))
+	for id, document := range this.documents {
+		documents[id] = document
+	}
+	versions := make(map[string][]bson.M, len(this.versions))
+	for id, history := range this.versions {
+		versions[id] = append([]bson.M{}, history...)
+	}
+	return func() {
+		this.mutex.Lock()
+		defer this.mutex.Unlock()
+		this.ids, this.documents, this.versions = ids, documents, versions
+	}
+}
+
+func (this *memorySvc[DocType]) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (this *memorySvc[DocType]) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+func (this *memorySvc[DocType]) CreateDocument(ctx context.Context, id string, document *DocType) error {
+	stored, err := toDocument(document)
+	if err != nil {
+		return err
+	}
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	if _, ok := this.documents[id]; ok {
+		return db_service.ErrConflict
+	}
+	this.ids = append(this.ids, id)
+	this.documents[id] = stored
+	this.recordVersion(id, db_service.OperationCreate, stored)
+	return nil
+}
+
+func (this *memorySvc[DocType]) FindDocument(ctx context.Context, id string) (*DocType, error) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	document, ok := this.documents[id]
+	if !ok || !matches(document, db_service.NotDeleted(ctx, bson.M{})) {
+		return nil, db_service.ErrNotFound
+	}
+	return fromDocument[DocType](document)
+}
+
+func (this *memorySvc[DocType]) FindDocuments(ctx context.Context, filter bson.M) ([]*DocType, error) {
+	condition, err := toDocument(db_service.NotDeleted(ctx, filter))
+	if err != nil {
+		return nil, err
+	}
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
+	var documents []*DocType
+	for _, id := range this.ids {
+		if !matches(this.documents[id], condition) {
+			continue
+		}
+		document, err := fromDocument[DocType](this.documents[id])
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, document)
+	}
+	return documents, nil
+}
+
+func (this *memorySvc[DocType]) UpdateDocument(ctx context.Context, id string, document *DocType) error {
+	stored, err := toDocument(document)
+	if err != nil {
+		return err
+	}
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	current, ok := this.documents[id]
+	if !ok || !matches(current, db_service.NotDeleted(ctx, bson.M{})) {
+		return db_service.ErrNotFound
+	}
+	this.documents[id] = stored
+	this.recordVersion(id, db_service.OperationUpdate, stored)
+	return nil
+}
+
+func (this *memorySvc[DocType]) UpdateDocumentIf(ctx context.Context, id string, condition bson.M, document *DocType) error {
+	stored, err := toDocument(document)
+	if err != nil {
+		return err
+	}
+	condition, err = toDocument(db_service.NotDeleted(ctx, condition))
+	if err != nil {
+		return err
+	}
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	current, ok := this.documents[id]
+	if !ok || !matches(current, condition) {
+		return db_service.ErrNotFound
+	}
+	this.documents[id] = stored
+	this.recordVersion(id, db_service.OperationUpdate, stored)
+	return nil
+}
+
+func (this *memorySvc[DocType]) PatchDocument(ctx context.Context, id string, changes bson.M) (*DocType, error) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	current, ok := this.documents[id]
+	if !ok || !matches(current, db_service.NotDeleted(ctx, bson.M{})) {
+		return nil, db_service.ErrNotFound
+	}
+	if len(changes) == 0 {
+		return fromDocument[DocType](current)
+	}
+
+	patched := copyDocument(current)
+	for field, value := range changes {
+		if value == nil {
+			delete(patched, field)
+		} else {
+			patched[field] = value
+		}
+	}
+	// normalizes the values the same way the database stores them
+	patched, err := toDocument(patched)
+	if err != nil {
+		return nil, err
+	}
+	this.documents[id] = patched
+	this.recordVersion(id, db_service.OperationUpdate, patched)
+	return fromDocument[DocType](patched)
+}
+
+func (this *memorySvc[DocType]) IncrementField(ctx context.Context, id string, field string, delta int32) (*DocType, error) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	current, ok := this.documents[id]
+	if !ok || !matches(current, db_service.NotDeleted(ctx, bson.M{})) {
+		return nil, db_service.ErrNotFound
+	}
+	value, _ := number(current[field])
+	if value+float64(delta) < 0 {
+		return nil, db_service.ErrNegativeValue
+	}
+
+	incremented := copyDocument(current)
+	switch value := current[field].(type) {
+	case int64:
+		incremented[field] = value + int64(delta)
+	case float64:
+		incremented[field] = value + float64(delta)
+	case int32:
+		incremented[field] = value + delta
+	default:
+		// missing field is created by $inc
+		incremented[field] = delta
+	}
+	this.documents[id] = incremented
+	this.recordVersion(id, db_service.OperationUpdate, incremented)
+	return fromDocument[DocType](incremented)
+}
+
+func (this *memorySvc[DocType]) DeleteDocument(ctx context.Context, id string) error {
+	return this.DeleteDocumentIf(ctx, id, bson.M{})
+}
+
+func (this *memorySvc[DocType]) DeleteDocumentIf(ctx context.Context, id string, condition bson.M) error {
+	condition, err := toDocument(condition)
+	if err != nil {
+		return err
+	}
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	document, ok := this.documents[id]
+	if !ok || !matches(document, condition) {
+		return db_service.ErrNotFound
+	}
+	this.remove(id)
+	this.recordVersion(id, db_service.OperationDelete, document)
+	return nil
+}
+
+func (this *memorySvc[DocType]) SoftDeleteDocument(ctx context.Context, id string, deletedBy string) error {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	current, ok := this.documents[id]
+	if !ok || current[db_service.DeletedAtField] != nil {
+		return db_service.ErrNotFound
+	}
+	deleted := copyDocument(current)
+	deleted[db_service.DeletedAtField] = primitive.NewDateTimeFromTime(time.Now().UTC())
+	deleted[db_service.DeletedByField] = deletedBy
+	this.documents[id] = deleted
+	this.recordVersion(id, db_service.OperationSoftDelete, deleted)
+	return nil
+}
+
+func (this *memorySvc[DocType]) RestoreDocument(ctx context.Context, id string) (*DocType, error) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	current, ok := this.documents[id]
+	if !ok || current[db_service.DeletedAtField] == nil {
+		return nil, db_service.ErrNotFound
+	}
+	restored := copyDocument(current)
+	delete(restored, db_service.DeletedAtField)
+	delete(restored, db_service.DeletedByField)
+	this.documents[id] = restored
+	this.recordVersion(id, db_service.OperationRestore, restored)
+	return fromDocument[DocType](restored)
+}
+
+func (this *memorySvc[DocType]) PurgeDocuments(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	condition, err := toDocument(bson.M{db_service.DeletedAtField: bson.M{"$lt": deletedBefore}})
+	if err != nil {
+		return 0, err
+	}
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	purged := int64(0)
+	for _, id := range append([]string{}, this.ids...) {
+		if matches(this.documents[id], condition) {
+			this.remove(id)
+			delete(this.versions, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+func (this *memorySvc[DocType]) FindVersions(ctx context.Context, id string) ([]*db_service.DocumentVersion[DocType], error) {
+	if !this.Versioned {
+		return nil, db_service.ErrNotVersioned
+	}
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	if len(this.versions[id]) == 0 {
+		return nil, db_service.ErrNotFound
+	}
+	versions := []*db_service.DocumentVersion[DocType]{}
+	for _, version := range this.versions[id] {
+		decoded, err := fromDocument[db_service.DocumentVersion[DocType]](version)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, decoded)
+	}
+	return versions, nil
+}
+
+func (this *memorySvc[DocType]) FindDocumentsAsOf(ctx context.Context, filter bson.M, asOf time.Time) ([]*DocType, error) {
+	if !this.Versioned {
+		return nil, db_service.ErrNotVersioned
+	}
+	condition, err := toDocument(db_service.NotDeleted(ctx, filter))
+	if err != nil {
+		return nil, err
+	}
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
+	documents := []*DocType{}
+	for _, history := range this.versions {
+		var latest bson.M
+		for _, version := range history {
+			if version["recordedAt"].(primitive.DateTime).Time().After(asOf) {
+				break
+			}
+			latest = version
+		}
+		if latest == nil || latest["operation"] == db_service.OperationDelete {
+			continue
+		}
+		snapshot := latest["document"].(bson.M)
+		if !matches(snapshot, condition) {
+			continue
+		}
+		document, err := fromDocument[DocType](snapshot)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, document)
+	}
+	return documents, nil
+}
+
+func (this *memorySvc[DocType]) recordVersion(id string, operation string, document bson.M) {
+	if !this.Versioned {
+		return
+	}
+	this.versions[id] = append(this.versions[id], bson.M{
+		"id":         id,
+		"version":    int64(len(this.versions[id]) + 1),
+		"operation":  operation,
+		"recordedAt": primitive.NewDateTimeFromTime(time.Now().UTC()),
+		"document":   document,
+	})
+}
+
+func (this *memorySvc[DocType]) remove(id string) {
+	delete(this.documents, id)
+	for i := range this.ids {
+		if this.ids[i] == id {
+			this.ids = append(this.ids[:i:i], this.ids[i+1:]...)
+			return
+		}
+	}
+}
+
+// toDocument converts the value to the form it has in the database, the stored documents are never
+// modified in place, so they can be shared by the snapshots
+func toDocument(value interface{}) (bson.M, error) {
+	data, err := bson.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	document := bson.M{}
+	err = bson.Unmarshal(data, &document)
+	return document, err
+}
+
+func fromDocument[DocType interface{}](document bson.M) (*DocType, error) {
+	data, err := bson.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	var decoded DocType
+	if err := bson.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+	return &decoded, nil
+}
+
+func copyDocument(document bson.M) bson.M {
+	copied := make(bson.M, len(document))
+	for field, value := range document {
+		copied[field] = value
+	}
+	return copied
+}
+
+// matches evaluates the subset of the query language used by the services on the document
+func matches(document bson.M, filter bson.M) bool {
+	for field, condition := range filter {
+		switch field {
+		case "$or", "$and":
+			alternatives, _ := condition.(bson.A)
+			matched := field == "$and"
+			for _, alternative := range alternatives {
+				if matches(document, alternative.(bson.M)) != matched {
+					matched = !matched
+					break
+				}
+			}
+			if !matched {
+				return false
+			}
+			continue
+		}
+
+		value, present := lookupField(document, field)
+		operators, ok := condition.(bson.M)
+		if !ok || !isOperators(operators) {
+			if !matchesValue(value, condition) {
+				return false
+			}
+			continue
+		}
+		for operator, operand := range operators {
+			if !matchesOperator(value, present, operator, operand, operators) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func matchesOperator(value interface{}, present bool, operator string, operand interface{}, operators bson.M) bool {
+	switch operator {
+	case "$eq":
+		return matchesValue(value, operand)
+	case "$ne":
+		return !matchesValue(value, operand)
+	case "$in", "$nin":
+		found := false
+		for _, candidate := range operand.(bson.A) {
+			if matchesValue(value, candidate) {
+				found = true
+				break
+			}
+		}
+		return found == (operator == "$in")
+	case "$lt", "$lte", "$gt", "$gte":
+		order, ok := compare(value, operand)
+		if !ok {
+			return false
+		}
+		switch operator {
+		case "$lt":
+			return order < 0
+		case "$lte":
+			return order <= 0
+		case "$gt":
+			return order > 0
+		default:
+			return order >= 0
+		}
+	case "$exists":
+		exists, _ := operand.(bool)
+		return present == exists
+	case "$regex":
+		text, ok := value.(string)
+		if !ok {
+			return false
+		}
+		pattern := operand.(string)
+		if options, _ := operators["$options"].(string); strings.Contains(options, "i") {
+			pattern = "(?i)" + pattern
+		}
+		return regexp.MustCompile(pattern).MatchString(text)
+	case "$options":
+		return true
+	}
+	panic(fmt.Sprintf("operator %v is not supported by the memory service", operator))
+}
+
+// matchesValue compares the value like the equality condition, nil matches also missing field
+// and array field matches when any of its elements matches
+func matchesValue(value interface{}, expected interface{}) bool {
+	if value == nil || expected == nil {
+		return value == nil && expected == nil
+	}
+	if elements, ok := value.(bson.A); ok {
+		if _, ok := expected.(bson.A); !ok {
+			for _, element := range elements {
+				if matchesValue(element, expected) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	if order, ok := compare(value, expected); ok {
+		return order == 0
+	}
+	return reflect.DeepEqual(value, expected)
+}
+
+func compare(value interface{}, operand interface{}) (int, bool) {
+	if left, ok := number(value); ok {
+		right, ok := number(operand)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case left < right:
+			return -1, true
+		case left > right:
+			return 1, true
+		}
+		return 0, true
+	}
+	if left, ok := value.(string); ok {
+		right, ok := operand.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(left, right), true
+	}
+	return 0, false
+}
+
+// number converts numeric values and dates, which are ordered by their time
+func number(value interface{}) (float64, bool) {
+	switch value := value.(type) {
+	case int32:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	case float64:
+		return value, true
+	case primitive.DateTime:
+		return float64(value), true
+	}
+	return 0, false
+}
+
+func lookupField(document bson.M, path string) (interface{}, bool) {
+	var current interface{} = document
+	for _, name := range strings.Split(path, ".") {
+		nested, ok := current.(bson.M)
+		if !ok {
+			return nil, false
+		}
+		current, ok = nested[name]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func isOperators(condition bson.M) bool {
+	for key := range condition {
+		if !strings.HasPrefix(key, "$") {
+			return false
+		}
+	}
+	return len(condition) > 0
+}
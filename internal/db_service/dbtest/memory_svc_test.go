@@ -0,0 +1,149 @@
+package dbtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type testDocument struct {
+	Id        string     `bson:"id"`
+	Room      string     `bson:"room"`
+	Count     int32      `bson:"count"`
+	Start     time.Time  `bson:"start"`
+	DeletedAt *time.Time `bson:"deletedAt,omitempty"`
+}
+
+func TestMemoryServiceFilters(t *testing.T) {
+	ctx := context.Background()
+	service := NewMemoryService[testDocument](MemoryServiceConfig{})
+	start := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+	for i, room := range []string{"room-1", "room-2", "room-3"} {
+		document := testDocument{Id: room, Room: room, Count: int32(i), Start: start.Add(time.Duration(i) * time.Hour)}
+		if err := service.CreateDocument(ctx, document.Id, &document); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := service.SoftDeleteDocument(ctx, "room-3", "admin"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		filter   bson.M
+		expected int
+	}{
+		"all":      {bson.M{}, 2},
+		"equality": {bson.M{"room": "room-1"}, 1},
+		"in":       {bson.M{"room": bson.M{"$in": []string{"room-2", "room-3"}}}, 1},
+		"range":    {bson.M{"start": bson.M{"$gte": start.Add(time.Hour), "$lt": start.Add(3 * time.Hour)}}, 1},
+		"number":   {bson.M{"count": bson.M{"$gt": 0}}, 1},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			documents, err := service.FindDocuments(ctx, test.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(documents) != test.expected {
+				t.Errorf("expected %v documents, got %v", test.expected, len(documents))
+			}
+		})
+	}
+
+	deleted, err := service.FindDocuments(db_service.WithDeleted(ctx), bson.M{"room": "room-3"})
+	if err != nil || len(deleted) != 1 {
+		t.Errorf("deleted document should be found with db_service.WithDeleted, got %v, %v", deleted, err)
+	}
+}
+
+func TestMemoryServiceGuardsIncrement(t *testing.T) {
+	ctx := context.Background()
+	service := NewMemoryService[testDocument](MemoryServiceConfig{})
+	if err := service.CreateDocument(ctx, "eq-1", &testDocument{Id: "eq-1", Count: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := service.IncrementField(ctx, "eq-1", "count", -3); !errors.Is(err, db_service.ErrNegativeValue) {
+		t.Errorf("expected db_service.ErrNegativeValue, got %v", err)
+	}
+	document, err := service.IncrementField(ctx, "eq-1", "count", -2)
+	if err != nil || document.Count != 0 {
+		t.Errorf("expected count 0, got %v, %v", document, err)
+	}
+}
+
+func TestMemoryStoreRollsBackFailedTransaction(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	service := NewMemoryService[testDocument](MemoryServiceConfig{Store: store, Versioned: true})
+	failure := errors.New("failure")
+
+	err := store.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := service.CreateDocument(ctx, "eq-1", &testDocument{Id: "eq-1"}); err != nil {
+			return err
+		}
+		return failure
+	})
+
+	if !errors.Is(err, failure) {
+		t.Errorf("expected failure of the function, got %v", err)
+	}
+	if _, err := service.FindDocument(ctx, "eq-1"); !errors.Is(err, db_service.ErrNotFound) {
+		t.Errorf("document should be rolled back, got %v", err)
+	}
+	if _, err := service.FindVersions(ctx, "eq-1"); !errors.Is(err, db_service.ErrNotFound) {
+		t.Errorf("versions should be rolled back, got %v", err)
+	}
+}
+
+func TestMemoryServiceConditionalUpdate(t *testing.T) {
+	ctx := context.Background()
+	service := NewMemoryService[testDocument](MemoryServiceConfig{})
+	start := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+	if err := service.CreateDocument(ctx, "doc-1", &testDocument{Id: "doc-1", Room: "room-1", Count: 1, Start: start}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the first update changes the state both callers have read, so the second one does not match
+	condition := bson.M{"start": start, "count": 1}
+	if err := service.UpdateDocumentIf(ctx, "doc-1", condition, &testDocument{Id: "doc-1", Room: "room-2", Count: 2, Start: start}); err != nil {
+		t.Fatal(err)
+	}
+	err := service.UpdateDocumentIf(ctx, "doc-1", condition, &testDocument{Id: "doc-1", Room: "room-3", Count: 2, Start: start})
+	if !errors.Is(err, db_service.ErrNotFound) {
+		t.Errorf("expected db_service.ErrNotFound, got %v", err)
+	}
+
+	document, err := service.FindDocument(ctx, "doc-1")
+	if err != nil || document.Room != "room-2" {
+		t.Errorf("expected document updated by the first caller, got %+v, %v", document, err)
+	}
+}
+
+func TestMemoryServicePurgesHistory(t *testing.T) {
+	ctx := context.Background()
+	service := NewMemoryService[testDocument](MemoryServiceConfig{Versioned: true})
+	for _, id := range []string{"eq-1", "eq-2"} {
+		if err := service.CreateDocument(ctx, id, &testDocument{Id: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := service.SoftDeleteDocument(ctx, "eq-1", "admin"); err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := service.PurgeDocuments(ctx, time.Now().Add(time.Minute))
+	if err != nil || count != 1 {
+		t.Fatalf("expected single purged document, got %v, %v", count, err)
+	}
+	if _, err := service.FindVersions(ctx, "eq-1"); !errors.Is(err, db_service.ErrNotFound) {
+		t.Errorf("history of purged document should be removed, got %v", err)
+	}
+	if versions, err := service.FindVersions(ctx, "eq-2"); err != nil || len(versions) != 1 {
+		t.Errorf("history of kept document should stay, got %v, %v", versions, err)
+	}
+}
@@ -0,0 +1,173 @@
+package db_service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service")
+
+// isFailure distinguishes database failures from expected outcomes like missing documents
+func isFailure(err error) bool {
+	return err != nil &&
+		!errors.Is(err, ErrNotFound) &&
+		!errors.Is(err, ErrConflict) &&
+		!errors.Is(err, ErrNegativeValue)
+}
+
+// instrumentedSvc measures and traces all operations of the wrapped service
+type instrumentedSvc[DocType interface{}] struct {
+	next       DbService[DocType]
+	collection string
+}
+
+func newInstrumentedService[DocType interface{}](next DbService[DocType], collection string) DbService[DocType] {
+	return &instrumentedSvc[DocType]{next: next, collection: collection}
+}
+
+// begin starts span of the operation, returned function records the outcome
+func (this *instrumentedSvc[DocType]) begin(ctx context.Context, operation string) (context.Context, func(error)) {
+	start := time.Now()
+	ctx, span := tracer.Start(
+		ctx,
+		"db "+this.collection+"."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemMongoDB,
+			semconv.DBCollectionName(this.collection),
+			semconv.DBOperationName(operation),
+		),
+	)
+	return ctx, func(err error) {
+		duration := time.Since(start)
+		operationDuration.WithLabelValues(this.collection, operation).Observe(duration.Seconds())
+		if isFailure(err) {
+			operationErrors.WithLabelValues(this.collection, operation).Inc()
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			slog.WarnContext(ctx, "Database operation failed",
+				"collection", this.collection, "operation", operation, "duration_ms", float64(duration.Microseconds())/1000, "error", err)
+		} else {
+			slog.DebugContext(ctx, "Database operation completed",
+				"collection", this.collection, "operation", operation, "duration_ms", float64(duration.Microseconds())/1000, "error", err)
+		}
+		span.End()
+	}
+}
+
+func (this *instrumentedSvc[DocType]) CreateDocument(ctx context.Context, id string, document *DocType) error {
+	ctx, done := this.begin(ctx, "create")
+	err := this.next.CreateDocument(ctx, id, document)
+	done(err)
+	return err
+}
+
+func (this *instrumentedSvc[DocType]) FindDocument(ctx context.Context, id string) (*DocType, error) {
+	ctx, done := this.begin(ctx, "find")
+	document, err := this.next.FindDocument(ctx, id)
+	done(err)
+	return document, err
+}
+
+func (this *instrumentedSvc[DocType]) FindDocuments(ctx context.Context, filter bson.M) ([]*DocType, error) {
+	ctx, done := this.begin(ctx, "find_many")
+	documents, err := this.next.FindDocuments(ctx, filter)
+	done(err)
+	return documents, err
+}
+
+func (this *instrumentedSvc[DocType]) UpdateDocument(ctx context.Context, id string, document *DocType) error {
+	ctx, done := this.begin(ctx, "update")
+	err := this.next.UpdateDocument(ctx, id, document)
+	done(err)
+	return err
+}
+
+func (this *instrumentedSvc[DocType]) UpdateDocumentIf(ctx context.Context, id string, condition bson.M, document *DocType) error {
+	ctx, done := this.begin(ctx, "update")
+	err := this.next.UpdateDocumentIf(ctx, id, condition, document)
+	done(err)
+	return err
+}
+
+func (this *instrumentedSvc[DocType]) PatchDocument(ctx context.Context, id string, changes bson.M) (*DocType, error) {
+	ctx, done := this.begin(ctx, "patch")
+	document, err := this.next.PatchDocument(ctx, id, changes)
+	done(err)
+	return document, err
+}
+
+func (this *instrumentedSvc[DocType]) IncrementField(ctx context.Context, id string, field string, delta int32) (*DocType, error) {
+	ctx, done := this.begin(ctx, "increment")
+	document, err := this.next.IncrementField(ctx, id, field, delta)
+	done(err)
+	return document, err
+}
+
+func (this *instrumentedSvc[DocType]) DeleteDocument(ctx context.Context, id string) error {
+	ctx, done := this.begin(ctx, "delete")
+	err := this.next.DeleteDocument(ctx, id)
+	done(err)
+	return err
+}
+
+func (this *instrumentedSvc[DocType]) DeleteDocumentIf(ctx context.Context, id string, condition bson.M) error {
+	ctx, done := this.begin(ctx, "delete")
+	err := this.next.DeleteDocumentIf(ctx, id, condition)
+	done(err)
+	return err
+}
+
+func (this *instrumentedSvc[DocType]) SoftDeleteDocument(ctx context.Context, id string, deletedBy string) error {
+	ctx, done := this.begin(ctx, "soft_delete")
+	err := this.next.SoftDeleteDocument(ctx, id, deletedBy)
+	done(err)
+	return err
+}
+
+func (this *instrumentedSvc[DocType]) RestoreDocument(ctx context.Context, id string) (*DocType, error) {
+	ctx, done := this.begin(ctx, "restore")
+	document, err := this.next.RestoreDocument(ctx, id)
+	done(err)
+	return document, err
+}
+
+func (this *instrumentedSvc[DocType]) PurgeDocuments(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	ctx, done := this.begin(ctx, "purge")
+	count, err := this.next.PurgeDocuments(ctx, deletedBefore)
+	done(err)
+	return count, err
+}
+
+func (this *instrumentedSvc[DocType]) FindVersions(ctx context.Context, id string) ([]*DocumentVersion[DocType], error) {
+	ctx, done := this.begin(ctx, "find_versions")
+	versions, err := this.next.FindVersions(ctx, id)
+	done(err)
+	return versions, err
+}
+
+func (this *instrumentedSvc[DocType]) FindDocumentsAsOf(ctx context.Context, filter bson.M, asOf time.Time) ([]*DocType, error) {
+	ctx, done := this.begin(ctx, "find_as_of")
+	documents, err := this.next.FindDocumentsAsOf(ctx, filter, asOf)
+	done(err)
+	return documents, err
+}
+
+func (this *instrumentedSvc[DocType]) Ping(ctx context.Context) error {
+	ctx, done := this.begin(ctx, "ping")
+	err := this.next.Ping(ctx)
+	done(err)
+	return err
+}
+
+func (this *instrumentedSvc[DocType]) Disconnect(ctx context.Context) error {
+	return this.next.Disconnect(ctx)
+}
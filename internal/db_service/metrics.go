@@ -0,0 +1,48 @@
+package db_service
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+var (
+	operationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "fpjp_db_operation_duration_seconds",
+		Help:    "Duration of DbService operations",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"collection", "operation"})
+
+	operationErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "fpjp_db_operation_errors_total",
+		Help: "Number of DbService operations failed due to database errors",
+	}, []string{"collection", "operation"})
+
+	poolConnections = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "fpjp_mongo_pool_connections",
+		Help: "Number of MongoDB connections in the pool by state",
+	}, []string{"state"})
+
+	poolCheckoutFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "fpjp_mongo_pool_checkout_failures_total",
+		Help: "Number of failed attempts to obtain MongoDB connection from the pool",
+	})
+)
+
+// poolMonitor keeps the connection pool gauges up to date
+var poolMonitor = &event.PoolMonitor{
+	Event: func(evt *event.PoolEvent) {
+		switch evt.Type {
+		case event.ConnectionCreated:
+			poolConnections.WithLabelValues("open").Inc()
+		case event.ConnectionClosed:
+			poolConnections.WithLabelValues("open").Dec()
+		case event.GetSucceeded:
+			poolConnections.WithLabelValues("in_use").Inc()
+		case event.ConnectionReturned:
+			poolConnections.WithLabelValues("in_use").Dec()
+		case event.GetFailed:
+			poolCheckoutFailures.Inc()
+		}
+	},
+}
@@ -0,0 +1,443 @@
+package db_service
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/logging"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+type MongoClientConfig struct {
+	// full connection string, if set the host, port, credentials and auth source below are ignored,
+	// the remaining settings take precedence over options of the connection string when they are set,
+	// zero or nil leaves the option of the connection string or the default of the driver
+	URI        string
+	ServerHost string
+	ServerPort int
+	UserName   string
+	Password   string
+	AuthSource string
+	// database used by services which do not specify their own
+	DbName string
+	// TLS is enabled also if any of the certificate files is configured
+	TLS                bool
+	TLSCAFile          string
+	TLSCertificateFile string
+	TLSKeyFile         string
+	// primary, primaryPreferred, secondary, secondaryPreferred or nearest
+	ReadPreference string
+	// majority or number of acknowledging members
+	WriteConcern string
+	// limits of the connection pool shared by all services using the client
+	MaxPoolSize uint64
+	MinPoolSize uint64
+	// how long to wait for a suitable server before an operation fails
+	ServerSelectionTimeout time.Duration
+	ConnectTimeout         time.Duration
+	// limit of the transaction including its retries on transient errors
+	TransactionTimeout time.Duration
+	RetryWrites        *bool
+	RetryReads         *bool
+}
+
+// MongoClient is a connection manager shared by the typed services, so that
+// all of them use single connection pool
+type MongoClient struct {
+	MongoClientConfig
+	client     atomic.Pointer[mongo.Client]
+	clientLock sync.Mutex
+}
+
+// enviro reads the variable, or the file referenced by the variable with _FILE suffix,
+// so that secrets can be mounted as files
+func enviro(name string, defaultValue string) string {
+	if path, ok := os.LookupEnv(name + "_FILE"); ok && path != "" {
+		content, err := os.ReadFile(path)
+		if err == nil {
+			return strings.TrimRight(string(content), "\r\n")
+		}
+		slog.Warn("Failed to read variable from file", "variable", name, "file", path, "error", err)
+	}
+	if value, ok := os.LookupEnv(name); ok {
+		return value
+	}
+	return defaultValue
+}
+
+func enviroInt(name string, defaultValue int) int {
+	value := enviro(name, strconv.Itoa(defaultValue))
+	if value, err := strconv.Atoi(value); err == nil {
+		return value
+	}
+	slog.Warn("Invalid integer value, using default", "variable", name, "value", value)
+	return defaultValue
+}
+
+func enviroBool(name string, defaultValue bool) bool {
+	value := enviro(name, strconv.FormatBool(defaultValue))
+	if value, err := strconv.ParseBool(value); err == nil {
+		return value
+	}
+	slog.Warn("Invalid boolean value, using default", "variable", name, "value", value)
+	return defaultValue
+}
+
+// enviroOptionalBool reads the boolean variable, nil if it is not set
+func enviroOptionalBool(name string) *bool {
+	value := enviro(name, "")
+	if value == "" {
+		return nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		slog.Warn("Invalid boolean value, ignoring", "variable", name, "value", value)
+		return nil
+	}
+	return &parsed
+}
+
+func NewMongoClient(config MongoClientConfig) *MongoClient {
+	client := &MongoClient{}
+	client.MongoClientConfig = config
+
+	if client.URI == "" {
+		client.URI = enviro("AMBULANCE_API_MONGODB_URI", "")
+	}
+
+	if client.ServerHost == "" {
+		client.ServerHost = enviro("AMBULANCE_API_MONGODB_HOST", "localhost")
+	}
+
+	if client.ServerPort == 0 {
+		client.ServerPort = enviroInt("AMBULANCE_API_MONGODB_PORT", 27017)
+	}
+
+	if client.UserName == "" {
+		client.UserName = enviro("AMBULANCE_API_MONGODB_USERNAME", "")
+	}
+
+	if client.Password == "" {
+		client.Password = enviro("AMBULANCE_API_MONGODB_PASSWORD", "")
+	}
+
+	if client.AuthSource == "" {
+		client.AuthSource = enviro("AMBULANCE_API_MONGODB_AUTH_SOURCE", "")
+	}
+
+	if client.DbName == "" {
+		client.DbName = enviro("AMBULANCE_API_MONGODB_DATABASE", "fpjp-ambulance")
+	}
+
+	if !client.TLS {
+		client.TLS = enviroBool("AMBULANCE_API_MONGODB_TLS", false)
+	}
+
+	if client.TLSCAFile == "" {
+		client.TLSCAFile = enviro("AMBULANCE_API_MONGODB_TLS_CA_FILE", "")
+	}
+
+	if client.TLSCertificateFile == "" {
+		client.TLSCertificateFile = enviro("AMBULANCE_API_MONGODB_TLS_CERT_FILE", "")
+	}
+
+	if client.TLSKeyFile == "" {
+		client.TLSKeyFile = enviro("AMBULANCE_API_MONGODB_TLS_KEY_FILE", "")
+	}
+
+	if client.ReadPreference == "" {
+		client.ReadPreference = enviro("AMBULANCE_API_MONGODB_READ_PREFERENCE", "")
+	}
+
+	if client.WriteConcern == "" {
+		client.WriteConcern = enviro("AMBULANCE_API_MONGODB_WRITE_CONCERN", "")
+	}
+
+	// the options below are unset unless configured, so that the options of the connection string apply
+	if client.MaxPoolSize == 0 {
+		client.MaxPoolSize = uint64(max(enviroInt("AMBULANCE_API_MONGODB_MAX_POOL_SIZE", 0), 0))
+	}
+
+	if client.MinPoolSize == 0 {
+		client.MinPoolSize = uint64(max(enviroInt("AMBULANCE_API_MONGODB_MIN_POOL_SIZE", 0), 0))
+	}
+
+	if client.ServerSelectionTimeout == 0 {
+		seconds := enviroInt("AMBULANCE_API_MONGODB_SERVER_SELECTION_TIMEOUT_SECONDS", 0)
+		client.ServerSelectionTimeout = time.Duration(seconds) * time.Second
+	}
+
+	if client.ConnectTimeout == 0 {
+		seconds := enviroInt("AMBULANCE_API_MONGODB_CONNECT_TIMEOUT_SECONDS", 0)
+		client.ConnectTimeout = time.Duration(seconds) * time.Second
+	}
+
+	if client.TransactionTimeout == 0 {
+		seconds := enviroInt("AMBULANCE_API_MONGODB_TRANSACTION_TIMEOUT_SECONDS", 30)
+		client.TransactionTimeout = time.Duration(seconds) * time.Second
+	}
+
+	if client.RetryWrites == nil {
+		client.RetryWrites = enviroOptionalBool("AMBULANCE_API_MONGODB_RETRY_WRITES")
+	}
+
+	if client.RetryReads == nil {
+		client.RetryReads = enviroOptionalBool("AMBULANCE_API_MONGODB_RETRY_READS")
+	}
+
+	slog.Info(
+		"MongoDB client configured",
+		"uri", logging.RedactURI(client.connectionString()),
+		"database", client.DbName,
+		"tls", client.TLS || client.TLSCAFile != "" || client.TLSCertificateFile != "",
+		"read_preference", client.ReadPreference,
+		"write_concern", client.WriteConcern,
+		"max_pool_size", client.MaxPoolSize,
+		"min_pool_size", client.MinPoolSize,
+		"server_selection_timeout", client.ServerSelectionTimeout.String(),
+		"retry_writes", optional(client.RetryWrites),
+		"retry_reads", optional(client.RetryReads),
+	)
+	return client
+}
+
+// optional describes the setting for the log, unset setting is left to the connection string or the driver
+func optional(value *bool) string {
+	if value == nil {
+		return "default"
+	}
+	return strconv.FormatBool(*value)
+}
+
+// Connect returns connected client, the connection is opened on first use
+func (this *MongoClient) Connect(ctx context.Context) (*mongo.Client, error) {
+	// optimistic check
+	client := this.client.Load()
+	if client != nil {
+		return client, nil
+	}
+
+	this.clientLock.Lock()
+	defer this.clientLock.Unlock()
+	// pesimistic check
+	client = this.client.Load()
+	if client != nil {
+		return client, nil
+	}
+
+	uri := this.connectionString()
+	slog.InfoContext(ctx, "Connecting to MongoDB", "uri", logging.RedactURI(uri))
+
+	clientOptions, err := this.clientOptions(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	if client, err := mongo.Connect(ctx, clientOptions); err != nil {
+		return nil, err
+	} else {
+		this.client.Store(client)
+		return client, nil
+	}
+}
+
+// connectionString returns configured URI, or composes it from the host, port and
+// credentials, the credentials are escaped so they may contain any characters
+func (this *MongoClient) connectionString() string {
+	if this.URI != "" {
+		return this.URI
+	}
+
+	uri := url.URL{
+		Scheme: "mongodb",
+		Host:   net.JoinHostPort(this.ServerHost, strconv.Itoa(this.ServerPort)),
+		Path:   "/",
+	}
+	if len(this.UserName) != 0 {
+		uri.User = url.UserPassword(this.UserName, this.Password)
+	}
+	if this.AuthSource != "" {
+		uri.RawQuery = url.Values{"authSource": {this.AuthSource}}.Encode()
+	}
+	return uri.String()
+}
+
+func (this *MongoClient) clientOptions(uri string) (*options.ClientOptions, error) {
+	clientOptions := options.Client().
+		ApplyURI(uri).
+		SetPoolMonitor(poolMonitor)
+
+	// only the configured settings override the options of the connection string
+	if this.ConnectTimeout > 0 {
+		clientOptions.SetConnectTimeout(this.ConnectTimeout)
+	}
+	if this.ServerSelectionTimeout > 0 {
+		clientOptions.SetServerSelectionTimeout(this.ServerSelectionTimeout)
+	}
+	if this.MaxPoolSize > 0 {
+		clientOptions.SetMaxPoolSize(this.MaxPoolSize)
+	}
+	if this.MinPoolSize > 0 {
+		clientOptions.SetMinPoolSize(this.MinPoolSize)
+	}
+	if this.RetryWrites != nil {
+		clientOptions.SetRetryWrites(*this.RetryWrites)
+	}
+	if this.RetryReads != nil {
+		clientOptions.SetRetryReads(*this.RetryReads)
+	}
+
+	if this.TLS || this.TLSCAFile != "" || this.TLSCertificateFile != "" {
+		tlsConfig, err := this.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+		clientOptions.SetTLSConfig(tlsConfig)
+	}
+
+	if this.ReadPreference != "" {
+		mode, err := readpref.ModeFromString(this.ReadPreference)
+		if err != nil {
+			return nil, err
+		}
+		preference, err := readpref.New(mode)
+		if err != nil {
+			return nil, err
+		}
+		clientOptions.SetReadPreference(preference)
+	}
+
+	if this.WriteConcern != "" {
+		if this.WriteConcern == "majority" {
+			clientOptions.SetWriteConcern(writeconcern.Majority())
+		} else if members, err := strconv.Atoi(this.WriteConcern); err == nil && members >= 0 {
+			clientOptions.SetWriteConcern(&writeconcern.WriteConcern{W: members})
+		} else {
+			return nil, fmt.Errorf("invalid write concern %q, expected majority or number of members", this.WriteConcern)
+		}
+	}
+
+	return clientOptions, clientOptions.Validate()
+}
+
+// tlsConfig trusts the configured CA bundle in addition to the system ones
+// and presents client certificate if configured
+func (this *MongoClient) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if this.TLSCAFile != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		bundle, err := os.ReadFile(this.TLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		if !pool.AppendCertsFromPEM(bundle) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %v", this.TLSCAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if this.TLSCertificateFile != "" {
+		// the key may be stored in the same PEM file as the certificate
+		keyFile := this.TLSKeyFile
+		if keyFile == "" {
+			keyFile = this.TLSCertificateFile
+		}
+		certificate, err := tls.LoadX509KeyPair(this.TLSCertificateFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
+
+// Database returns handle of the default database
+func (this *MongoClient) Database(ctx context.Context) (*mongo.Database, error) {
+	client, err := this.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.Database(this.DbName), nil
+}
+
+// ErrTransactionsNotSupported is returned when the deployment is standalone server,
+// transactions require replica set or sharded cluster
+var ErrTransactionsNotSupported = fmt.Errorf("transactions are not supported by the database deployment")
+
+// WithTransaction runs the function in a transaction, operations of the services sharing
+// this client join the transaction when they are called with the provided context,
+// the function is retried on transient errors so it must not have other side effects
+func (this *MongoClient) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	ctx, contextCancel := context.WithTimeout(ctx, this.TransactionTimeout)
+	defer contextCancel()
+
+	client, err := this.Connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	session, err := client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessionCtx mongo.SessionContext) (interface{}, error) {
+		// the driver keeps retrying transient errors for two minutes regardless of the context
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fn(sessionCtx)
+	})
+
+	// IllegalOperation - "Transaction numbers are only allowed on a replica set member or mongos"
+	var commandError mongo.CommandError
+	if errors.As(err, &commandError) && commandError.Code == 20 {
+		return ErrTransactionsNotSupported
+	}
+	return err
+}
+
+// Ping checks that the database server is reachable
+func (this *MongoClient) Ping(ctx context.Context) error {
+	client, err := this.Connect(ctx)
+	if err != nil {
+		return err
+	}
+	// nil uses the configured read preference
+	return client.Ping(ctx, nil)
+}
+
+// Disconnect closes all pooled connections, services using the client
+// must not be used afterwards
+func (this *MongoClient) Disconnect(ctx context.Context) error {
+	this.clientLock.Lock()
+	defer this.clientLock.Unlock()
+
+	client := this.client.Load()
+	if client == nil {
+		return nil
+	}
+	this.client.Store(nil)
+	return client.Disconnect(ctx)
+}
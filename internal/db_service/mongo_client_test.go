@@ -0,0 +1,47 @@
+package db_service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConnectionStringOptionsSurvive(t *testing.T) {
+	for _, name := range []string{"MAX_POOL_SIZE", "MIN_POOL_SIZE", "SERVER_SELECTION_TIMEOUT_SECONDS", "CONNECT_TIMEOUT_SECONDS", "RETRY_WRITES", "RETRY_READS"} {
+		t.Setenv("AMBULANCE_API_MONGODB_"+name, "")
+	}
+	uri := "mongodb://db.example:27017/?maxPoolSize=5&minPoolSize=2&retryWrites=false&retryReads=false&serverSelectionTimeoutMS=1500&connectTimeoutMS=700"
+
+	clientOptions, err := NewMongoClient(MongoClientConfig{URI: uri}).clientOptions(uri)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *clientOptions.MaxPoolSize != 5 || *clientOptions.MinPoolSize != 2 {
+		t.Errorf("unexpected pool size %v-%v", *clientOptions.MinPoolSize, *clientOptions.MaxPoolSize)
+	}
+	if *clientOptions.RetryWrites || *clientOptions.RetryReads {
+		t.Errorf("retries should stay disabled")
+	}
+	if *clientOptions.ServerSelectionTimeout != 1500*time.Millisecond || *clientOptions.ConnectTimeout != 700*time.Millisecond {
+		t.Errorf("unexpected timeouts %v and %v", *clientOptions.ServerSelectionTimeout, *clientOptions.ConnectTimeout)
+	}
+}
+
+func TestConfiguredOptionsOverrideConnectionString(t *testing.T) {
+	t.Setenv("AMBULANCE_API_MONGODB_MAX_POOL_SIZE", "20")
+	t.Setenv("AMBULANCE_API_MONGODB_RETRY_WRITES", "true")
+	t.Setenv("AMBULANCE_API_MONGODB_SERVER_SELECTION_TIMEOUT_SECONDS", "")
+	uri := "mongodb://db.example:27017/?maxPoolSize=5&retryWrites=false&serverSelectionTimeoutMS=1500"
+
+	clientOptions, err := NewMongoClient(MongoClientConfig{URI: uri, ConnectTimeout: 3 * time.Second}).clientOptions(uri)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *clientOptions.MaxPoolSize != 20 || !*clientOptions.RetryWrites || *clientOptions.ConnectTimeout != 3*time.Second {
+		t.Errorf("configured options should win, got %v, %v, %v", *clientOptions.MaxPoolSize, *clientOptions.RetryWrites, *clientOptions.ConnectTimeout)
+	}
+	if *clientOptions.ServerSelectionTimeout != 1500*time.Millisecond {
+		t.Errorf("unset option should keep the connection string value, got %v", *clientOptions.ServerSelectionTimeout)
+	}
+}
@@ -3,11 +3,7 @@ package db_service
 import (
 	"context"
 	"fmt"
-	"log"
-	"os"
-	"strconv"
-	"sync"
-	"sync/atomic"
+	"log/slog"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -20,64 +16,84 @@ type DbService[DocType interface{}] interface {
 	FindDocument(ctx context.Context, id string) (*DocType, error)
 	FindDocuments(ctx context.Context, filter bson.M) ([]*DocType, error)
 	UpdateDocument(ctx context.Context, id string, document *DocType) error
+	UpdateDocumentIf(ctx context.Context, id string, condition bson.M, document *DocType) error
+	PatchDocument(ctx context.Context, id string, changes bson.M) (*DocType, error)
+	IncrementField(ctx context.Context, id string, field string, delta int32) (*DocType, error)
 	DeleteDocument(ctx context.Context, id string) error
+	DeleteDocumentIf(ctx context.Context, id string, condition bson.M) error
+	SoftDeleteDocument(ctx context.Context, id string, deletedBy string) error
+	RestoreDocument(ctx context.Context, id string) (*DocType, error)
+	PurgeDocuments(ctx context.Context, deletedBefore time.Time) (int64, error)
+	FindVersions(ctx context.Context, id string) ([]*DocumentVersion[DocType], error)
+	FindDocumentsAsOf(ctx context.Context, filter bson.M, asOf time.Time) ([]*DocType, error)
+	Ping(ctx context.Context) error
 	Disconnect(ctx context.Context) error
 }
 
 var ErrNotFound = fmt.Errorf("document not found")
 var ErrConflict = fmt.Errorf("conflict: document already exists")
+var ErrNotVersioned = fmt.Errorf("collection does not keep document versions")
+var ErrNegativeValue = fmt.Errorf("value would become negative")
+
+// names of the soft delete markers stored alongside the documents
+const (
+	DeletedAtField = "deletedAt"
+	DeletedByField = "deletedBy"
+)
+
+type includeDeletedKey struct{}
+
+// WithDeleted returns context under which FindDocument and FindDocuments
+// also return soft deleted documents
+func WithDeleted(ctx context.Context) context.Context {
+	return context.WithValue(ctx, includeDeletedKey{}, true)
+}
+
+func includeDeleted(ctx context.Context) bool {
+	value, _ := ctx.Value(includeDeletedKey{}).(bool)
+	return value
+}
+
+// NotDeleted extends the filter so that soft deleted documents are excluded,
+// unless the context requests them explicitly
+func NotDeleted(ctx context.Context, filter bson.M) bson.M {
+	if includeDeleted(ctx) {
+		return filter
+	}
+	extended := bson.M{DeletedAtField: nil}
+	for key, value := range filter {
+		extended[key] = value
+	}
+	return extended
+}
 
 type MongoServiceConfig struct {
-	ServerHost string
-	ServerPort int
-	UserName   string
-	Password   string
+	// shared connection manager, if nil the service opens its own connection
+	Client     *MongoClient
 	DbName     string
 	Collection string
 	Timeout    time.Duration
+	// keep snapshot of the document in the versions collection on every change
+	Versioned bool
 }
 
 type mongoSvc[DocType interface{}] struct {
 	MongoServiceConfig
-	client     atomic.Pointer[mongo.Client]
-	clientLock sync.Mutex
+	// the service disconnects the client only if it created it
+	ownsClient bool
 }
 
 func NewMongoService[DocType interface{}](config MongoServiceConfig) DbService[DocType] {
-	enviro := func(name string, defaultValue string) string {
-		if value, ok := os.LookupEnv(name); ok {
-			return value
-		}
-		return defaultValue
-	}
-
 	svc := &mongoSvc[DocType]{}
 	svc.MongoServiceConfig = config
 
-	if svc.ServerHost == "" {
-		svc.ServerHost = enviro("AMBULANCE_API_MONGODB_HOST", "localhost")
-	}
-
-	if svc.ServerPort == 0 {
-		port := enviro("AMBULANCE_API_MONGODB_PORT", "27017")
-		if port, err := strconv.Atoi(port); err == nil {
-			svc.ServerPort = port
-		} else {
-			log.Printf("Invalid port value: %v", port)
-			svc.ServerPort = 27017
-		}
-	}
-
-	if svc.UserName == "" {
-		svc.UserName = enviro("AMBULANCE_API_MONGODB_USERNAME", "")
-	}
-
-	if svc.Password == "" {
-		svc.Password = enviro("AMBULANCE_API_MONGODB_PASSWORD", "")
+	if svc.Client == nil {
+		svc.Client = NewMongoClient(MongoClientConfig{})
+		svc.ownsClient = true
 	}
 
 	if svc.DbName == "" {
-		svc.DbName = enviro("AMBULANCE_API_MONGODB_DATABASE", "fpjp-ambulance")
+		svc.DbName = svc.Client.DbName
 	}
 
 	if svc.Collection == "" {
@@ -85,75 +101,35 @@ func NewMongoService[DocType interface{}](config MongoServiceConfig) DbService[D
 	}
 
 	if svc.Timeout == 0 {
-		seconds := enviro("AMBULANCE_API_MONGODB_TIMEOUT_SECONDS", "10")
-		if seconds, err := strconv.Atoi(seconds); err == nil {
-			svc.Timeout = time.Duration(seconds) * time.Second
-		} else {
-			log.Printf("Invalid timeout value: %v", seconds)
-			svc.Timeout = 10 * time.Second
-		}
+		svc.Timeout = time.Duration(enviroInt("AMBULANCE_API_MONGODB_TIMEOUT_SECONDS", 10)) * time.Second
 	}
 
-	log.Printf(
-		"MongoDB config: //%v@%v:%v/%v/%v",
-		svc.UserName,
-		svc.ServerHost,
-		svc.ServerPort,
-		svc.DbName,
-		svc.Collection,
+	slog.Info(
+		"MongoDB service configured",
+		"database", svc.DbName,
+		"collection", svc.Collection,
 	)
-	return svc
+	return newInstrumentedService[DocType](svc, svc.Collection)
 }
 
 func (this *mongoSvc[DocType]) connect(ctx context.Context) (*mongo.Client, error) {
-	// optimistic check
-	client := this.client.Load()
-	if client != nil {
-		return client, nil
-	}
-
-	this.clientLock.Lock()
-	defer this.clientLock.Unlock()
-	// pesimistic check
-	client = this.client.Load()
-	if client != nil {
-		return client, nil
-	}
+	return this.Client.Connect(ctx)
+}
 
+// Ping checks that the database server is reachable
+func (this *mongoSvc[DocType]) Ping(ctx context.Context) error {
 	ctx, contextCancel := context.WithTimeout(ctx, this.Timeout)
 	defer contextCancel()
-
-	var uri = fmt.Sprintf("mongodb://%v:%v", this.ServerHost, this.ServerPort)
-	log.Printf("Using URI: " + uri)
-
-	if len(this.UserName) != 0 {
-		uri = fmt.Sprintf("mongodb://%v:%v@%v:%v", this.UserName, this.Password, this.ServerHost, this.ServerPort)
-	}
-
-	if client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri).SetConnectTimeout(10*time.Second)); err != nil {
-		return nil, err
-	} else {
-		this.client.Store(client)
-		return client, nil
-	}
+	return this.Client.Ping(ctx)
 }
 
+// Disconnect closes the connection only if it is not shared with other services,
+// shared client is disconnected by its owner
 func (this *mongoSvc[DocType]) Disconnect(ctx context.Context) error {
-	client := this.client.Load()
-
-	if client != nil {
-		this.clientLock.Lock()
-		defer this.clientLock.Unlock()
-
-		client = this.client.Load()
-		defer this.client.Store(nil)
-		if client != nil {
-			if err := client.Disconnect(ctx); err != nil {
-				return err
-			}
-		}
+	if !this.ownsClient {
+		return nil
 	}
-	return nil
+	return this.Client.Disconnect(ctx)
 }
 
 func (this *mongoSvc[DocType]) CreateDocument(ctx context.Context, id string, document *DocType) error {
@@ -165,18 +141,16 @@ func (this *mongoSvc[DocType]) CreateDocument(ctx context.Context, id string, do
 	}
 	db := client.Database(this.DbName)
 	collection := db.Collection(this.Collection)
-	result := collection.FindOne(ctx, bson.D{{Key: "id", Value: id}})
-	switch result.Err() {
-	case nil: // no error means there is conflicting document
+	// uniqueness of the id is enforced by the unique index created by migrations
+	_, err = collection.InsertOne(ctx, document)
+	if mongo.IsDuplicateKeyError(err) {
 		return ErrConflict
-	case mongo.ErrNoDocuments:
-		// do nothing, this is expected
-	default: // other errors - return them
-		return result.Err()
 	}
-	_, err = collection.InsertOne(ctx, document)
+	if err != nil {
+		return err
+	}
 
-	return err
+	return this.recordVersion(ctx, db, id, OperationCreate, document)
 }
 
 func (this *mongoSvc[DocType]) FindDocument(ctx context.Context, id string) (*DocType, error) {
@@ -188,7 +162,7 @@ func (this *mongoSvc[DocType]) FindDocument(ctx context.Context, id string) (*Do
 	}
 	db := client.Database(this.DbName)
 	collection := db.Collection(this.Collection)
-	result := collection.FindOne(ctx, bson.D{{Key: "id", Value: id}})
+	result := collection.FindOne(ctx, NotDeleted(ctx, bson.M{"id": id}))
 
 	switch result.Err() {
 	case nil:
@@ -216,7 +190,7 @@ func (this *mongoSvc[DocType]) FindDocuments(ctx context.Context, filter bson.M)
 	}
 	db := client.Database(this.DbName)
 	collection := db.Collection(this.Collection)
-	result, err := collection.Find(ctx, filter)
+	result, err := collection.Find(ctx, NotDeleted(ctx, filter))
 
 	// handling errors
 	switch err {
@@ -224,8 +198,9 @@ func (this *mongoSvc[DocType]) FindDocuments(ctx context.Context, filter bson.M)
 	case mongo.ErrNoDocuments:
 		return nil, ErrNotFound
 	default:
-		return nil, result.Err()
+		return nil, err
 	}
+	defer result.Close(ctx)
 
 	// constructing list of documents
 	var documents []*DocType
@@ -236,6 +211,9 @@ func (this *mongoSvc[DocType]) FindDocuments(ctx context.Context, filter bson.M)
 		}
 		documents = append(documents, &document)
 	}
+	if err := result.Err(); err != nil {
+		return nil, err
+	}
 
 	return documents, nil
 }
@@ -249,7 +227,190 @@ func (this *mongoSvc[DocType]) UpdateDocument(ctx context.Context, id string, do
 	}
 	db := client.Database(this.DbName)
 	collection := db.Collection(this.Collection)
-	result := collection.FindOne(ctx, bson.D{{Key: "id", Value: id}})
+	result, err := collection.ReplaceOne(ctx, NotDeleted(ctx, bson.M{"id": id}), document)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrConflict
+	}
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	return this.recordVersion(ctx, db, id, OperationUpdate, document)
+}
+
+// UpdateDocumentIf replaces the document only while it still matches the condition, so that only one
+// of the concurrent callers replaces the state they have read, the others get ErrNotFound
+func (this *mongoSvc[DocType]) UpdateDocumentIf(ctx context.Context, id string, condition bson.M, document *DocType) error {
+	ctx, contextCancel := context.WithTimeout(ctx, this.Timeout)
+	defer contextCancel()
+	client, err := this.connect(ctx)
+	if err != nil {
+		return err
+	}
+	db := client.Database(this.DbName)
+	collection := db.Collection(this.Collection)
+
+	filter := NotDeleted(ctx, bson.M{"id": id})
+	for field, value := range condition {
+		filter[field] = value
+	}
+	result, err := collection.ReplaceOne(ctx, filter, document)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrConflict
+	}
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	return this.recordVersion(ctx, db, id, OperationUpdate, document)
+}
+
+// PatchDocument sets the changed fields of the document, fields with nil value are removed
+func (this *mongoSvc[DocType]) PatchDocument(ctx context.Context, id string, changes bson.M) (*DocType, error) {
+	ctx, contextCancel := context.WithTimeout(ctx, this.Timeout)
+	defer contextCancel()
+	client, err := this.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	db := client.Database(this.DbName)
+	collection := db.Collection(this.Collection)
+
+	set := bson.M{}
+	unset := bson.M{}
+	for field, value := range changes {
+		if value == nil {
+			unset[field] = ""
+		} else {
+			set[field] = value
+		}
+	}
+	update := bson.M{}
+	if len(set) > 0 {
+		update["$set"] = set
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	var result *mongo.SingleResult
+	if len(update) == 0 {
+		// nothing to change, just return the current state
+		result = collection.FindOne(ctx, NotDeleted(ctx, bson.M{"id": id}))
+	} else {
+		result = collection.FindOneAndUpdate(
+			ctx,
+			NotDeleted(ctx, bson.M{"id": id}),
+			update,
+			options.FindOneAndUpdate().SetReturnDocument(options.After),
+		)
+	}
+
+	switch result.Err() {
+	case nil:
+	case mongo.ErrNoDocuments:
+		return nil, ErrNotFound
+	default: // other errors - return them
+		return nil, result.Err()
+	}
+
+	var document *DocType
+	if err := result.Decode(&document); err != nil {
+		return nil, err
+	}
+	if len(update) > 0 {
+		if err := this.recordVersion(ctx, db, id, OperationUpdate, document); err != nil {
+			return nil, err
+		}
+	}
+	return document, nil
+}
+
+// IncrementField atomically adds delta to the numeric field, the field is never decreased below zero
+func (this *mongoSvc[DocType]) IncrementField(ctx context.Context, id string, field string, delta int32) (*DocType, error) {
+	ctx, contextCancel := context.WithTimeout(ctx, this.Timeout)
+	defer contextCancel()
+	client, err := this.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	db := client.Database(this.DbName)
+	collection := db.Collection(this.Collection)
+
+	filter := NotDeleted(ctx, bson.M{"id": id})
+	if delta < 0 {
+		filter[field] = bson.M{"$gte": -delta}
+	}
+	result := collection.FindOneAndUpdate(
+		ctx,
+		filter,
+		bson.M{"$inc": bson.M{field: delta}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	)
+
+	switch result.Err() {
+	case nil:
+	case mongo.ErrNoDocuments:
+		// distinguish missing document from the violated guard
+		count, err := collection.CountDocuments(ctx, NotDeleted(ctx, bson.M{"id": id}))
+		if err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			return nil, ErrNotFound
+		}
+		return nil, ErrNegativeValue
+	default: // other errors - return them
+		return nil, result.Err()
+	}
+
+	var document *DocType
+	if err := result.Decode(&document); err != nil {
+		return nil, err
+	}
+	if err := this.recordVersion(ctx, db, id, OperationUpdate, document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+func (this *mongoSvc[DocType]) DeleteDocument(ctx context.Context, id string) error {
+	return this.DeleteDocumentIf(ctx, id, bson.M{})
+}
+
+// DeleteDocumentIf deletes the document only if it matches the condition, ErrNotFound is returned
+// when the document does not exist or does not match
+func (this *mongoSvc[DocType]) DeleteDocumentIf(ctx context.Context, id string, condition bson.M) error {
+	ctx, contextCancel := context.WithTimeout(ctx, this.Timeout)
+	defer contextCancel()
+	client, err := this.connect(ctx)
+	if err != nil {
+		return err
+	}
+	db := client.Database(this.DbName)
+	collection := db.Collection(this.Collection)
+
+	filter := bson.M{"id": id}
+	for field, value := range condition {
+		filter[field] = value
+	}
+	if !this.Versioned {
+		result, err := collection.DeleteOne(ctx, filter)
+		if err != nil {
+			return err
+		}
+		if result.DeletedCount == 0 {
+			return ErrNotFound
+		}
+		return nil
+	}
+
+	// the last snapshot of versioned document is taken from the deleted one
+	result := collection.FindOneAndDelete(ctx, filter)
 	switch result.Err() {
 	case nil:
 	case mongo.ErrNoDocuments:
@@ -257,11 +418,14 @@ func (this *mongoSvc[DocType]) UpdateDocument(ctx context.Context, id string, do
 	default: // other errors - return them
 		return result.Err()
 	}
-	_, err = collection.ReplaceOne(ctx, bson.D{{Key: "id", Value: id}}, document)
-	return err
+	var document *DocType
+	if err := result.Decode(&document); err != nil {
+		return err
+	}
+	return this.recordVersion(ctx, db, id, OperationDelete, document)
 }
 
-func (this *mongoSvc[DocType]) DeleteDocument(ctx context.Context, id string) error {
+func (this *mongoSvc[DocType]) SoftDeleteDocument(ctx context.Context, id string, deletedBy string) error {
 	ctx, contextCancel := context.WithTimeout(ctx, this.Timeout)
 	defer contextCancel()
 	client, err := this.connect(ctx)
@@ -270,7 +434,13 @@ func (this *mongoSvc[DocType]) DeleteDocument(ctx context.Context, id string) er
 	}
 	db := client.Database(this.DbName)
 	collection := db.Collection(this.Collection)
-	result := collection.FindOne(ctx, bson.D{{Key: "id", Value: id}})
+	result := collection.FindOneAndUpdate(
+		ctx,
+		bson.M{"id": id, DeletedAtField: nil},
+		bson.M{"$set": bson.M{DeletedAtField: time.Now().UTC(), DeletedByField: deletedBy}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	)
+
 	switch result.Err() {
 	case nil:
 	case mongo.ErrNoDocuments:
@@ -278,6 +448,80 @@ func (this *mongoSvc[DocType]) DeleteDocument(ctx context.Context, id string) er
 	default: // other errors - return them
 		return result.Err()
 	}
-	_, err = collection.DeleteOne(ctx, bson.D{{Key: "id", Value: id}})
-	return err
+
+	var document *DocType
+	if err := result.Decode(&document); err != nil {
+		return err
+	}
+	return this.recordVersion(ctx, db, id, OperationSoftDelete, document)
+}
+
+func (this *mongoSvc[DocType]) RestoreDocument(ctx context.Context, id string) (*DocType, error) {
+	ctx, contextCancel := context.WithTimeout(ctx, this.Timeout)
+	defer contextCancel()
+	client, err := this.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	db := client.Database(this.DbName)
+	collection := db.Collection(this.Collection)
+	result := collection.FindOneAndUpdate(
+		ctx,
+		bson.M{"id": id, DeletedAtField: bson.M{"$ne": nil}},
+		bson.M{"$unset": bson.M{DeletedAtField: "", DeletedByField: ""}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	)
+
+	switch result.Err() {
+	case nil:
+	case mongo.ErrNoDocuments:
+		return nil, ErrNotFound
+	default: // other errors - return them
+		return nil, result.Err()
+	}
+
+	var document *DocType
+	if err := result.Decode(&document); err != nil {
+		return nil, err
+	}
+	if err := this.recordVersion(ctx, db, id, OperationRestore, document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// PurgeDocuments hard deletes documents soft deleted before the given time together with their
+// snapshots and version counters, so that nothing of the purged documents is retained
+func (this *mongoSvc[DocType]) PurgeDocuments(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	ctx, contextCancel := context.WithTimeout(ctx, this.Timeout)
+	defer contextCancel()
+	client, err := this.connect(ctx)
+	if err != nil {
+		return 0, err
+	}
+	db := client.Database(this.DbName)
+	collection := db.Collection(this.Collection)
+	purged := bson.M{DeletedAtField: bson.M{"$lt": deletedBefore}}
+
+	// the history goes first, if it fails the documents are still found by the next purge
+	if this.Versioned {
+		ids, err := collection.Distinct(ctx, "id", purged)
+		if err != nil {
+			return 0, err
+		}
+		if len(ids) > 0 {
+			if _, err := this.versionsCollection(db).DeleteMany(ctx, bson.M{"id": bson.M{"$in": ids}}); err != nil {
+				return 0, err
+			}
+			if _, err := this.versionCountersCollection(db).DeleteMany(ctx, bson.M{"id": bson.M{"$in": ids}}); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	result, err := collection.DeleteMany(ctx, purged)
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
 }
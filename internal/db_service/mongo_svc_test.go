@@ -0,0 +1,190 @@
+package db_service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+type testDocument struct {
+	Id    string `bson:"id"`
+	Room  string `bson:"room"`
+	Count int32  `bson:"count"`
+}
+
+// mockedService runs the service against mocked server replying with the prepared responses
+func mockedService(mt *mtest.T) DbService[testDocument] {
+	client := &MongoClient{}
+	client.client.Store(mt.Client)
+	return &mongoSvc[testDocument]{MongoServiceConfig: MongoServiceConfig{
+		Client:     client,
+		DbName:     "test",
+		Collection: "documents",
+		Timeout:    time.Second,
+	}}
+}
+
+func TestDuplicateKeyIsConflict(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	duplicate := mtest.WriteError{Index: 0, Code: 11000, Message: "E11000 duplicate key error collection: test.documents index: id_1"}
+
+	mt.Run("create", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateWriteErrorsResponse(duplicate))
+		err := mockedService(mt).CreateDocument(context.Background(), "eq-1", &testDocument{Id: "eq-1"})
+		if !errors.Is(err, ErrConflict) {
+			t.Errorf("expected ErrConflict, got %v", err)
+		}
+	})
+
+	mt.Run("update", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateWriteErrorsResponse(duplicate))
+		err := mockedService(mt).UpdateDocument(context.Background(), "eq-1", &testDocument{Id: "eq-2"})
+		if !errors.Is(err, ErrConflict) {
+			t.Errorf("expected ErrConflict, got %v", err)
+		}
+	})
+
+	mt.Run("other write error", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateWriteErrorsResponse(mtest.WriteError{Index: 0, Code: 121, Message: "Document failed validation"}))
+		err := mockedService(mt).CreateDocument(context.Background(), "eq-1", &testDocument{Id: "eq-1"})
+		if err == nil || errors.Is(err, ErrConflict) {
+			t.Errorf("expected other error than ErrConflict, got %v", err)
+		}
+	})
+}
+
+func TestConditionalUpdateFiltersByCondition(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("condition not matched", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 0}, bson.E{Key: "nModified", Value: 0}))
+		err := mockedService(mt).UpdateDocumentIf(context.Background(), "eq-1", bson.M{"count": 1}, &testDocument{Id: "eq-1", Count: 2})
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+
+		command := mt.GetStartedEvent().Command
+		filter := command.Lookup("updates").Array().Index(0).Value().Document().Lookup("q").Document()
+		if filter.Lookup("id").StringValue() != "eq-1" || filter.Lookup("count").Int32() != 1 {
+			t.Errorf("unexpected filter %v", filter)
+		}
+	})
+}
+
+func TestVersionIsAllocatedByCounter(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("update", func(mt *mtest.T) {
+		service := mockedService(mt).(*mongoSvc[testDocument])
+		service.Versioned = true
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}),
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: bson.D{{Key: "id", Value: "eq-1"}, {Key: "version", Value: int64(3)}}}),
+			mtest.CreateSuccessResponse(),
+		)
+
+		if err := service.UpdateDocument(context.Background(), "eq-1", &testDocument{Id: "eq-1"}); err != nil {
+			t.Fatal(err)
+		}
+
+		mt.GetStartedEvent()
+		counter := mt.GetStartedEvent().Command
+		if counter.Lookup("findAndModify").StringValue() != "documents_version_counters" || !counter.Lookup("upsert").Boolean() {
+			t.Errorf("unexpected counter command %v", counter)
+		}
+		insert := mt.GetStartedEvent().Command
+		snapshot := insert.Lookup("documents").Array().Index(0).Value().Document()
+		if insert.Lookup("insert").StringValue() != "documents_versions" || snapshot.Lookup("version").Int64() != 3 {
+			t.Errorf("unexpected snapshot %v", insert)
+		}
+	})
+}
+
+func TestPurgeRemovesHistory(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("versioned", func(mt *mtest.T) {
+		service := mockedService(mt).(*mongoSvc[testDocument])
+		service.Versioned = true
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "values", Value: bson.A{"eq-1", "eq-2"}}),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 5}),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 2}),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 2}),
+		)
+
+		count, err := service.PurgeDocuments(context.Background(), time.Now())
+		if err != nil || count != 2 {
+			t.Fatalf("expected 2 purged documents, got %v, %v", count, err)
+		}
+
+		mt.GetStartedEvent()
+		for _, collection := range []string{"documents_versions", "documents_version_counters", "documents"} {
+			command := mt.GetStartedEvent().Command
+			if command.Lookup("delete").StringValue() != collection {
+				t.Errorf("expected delete from %v, got %v", collection, command)
+			}
+		}
+	})
+
+	mt.Run("nothing to purge", func(mt *mtest.T) {
+		service := mockedService(mt).(*mongoSvc[testDocument])
+		service.Versioned = true
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "values", Value: bson.A{}}),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 0}),
+		)
+
+		if _, err := service.PurgeDocuments(context.Background(), time.Now()); err != nil {
+			t.Fatal(err)
+		}
+		if started := len(mt.GetAllStartedEvents()); started != 2 {
+			t.Errorf("expected distinct and delete of documents, got %v commands", started)
+		}
+	})
+}
+
+func TestAsOfListingMatchesCandidatesFirst(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("aggregate", func(mt *mtest.T) {
+		service := mockedService(mt).(*mongoSvc[testDocument])
+		service.Versioned = true
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.documents_versions", mtest.FirstBatch,
+			bson.D{{Key: "id", Value: "eq-1"}, {Key: "room", Value: "room-1"}}))
+
+		documents, err := service.FindDocumentsAsOf(context.Background(), bson.M{"room": bson.M{"$in": bson.A{"room-1"}}}, time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(documents) != 1 || documents[0].Room != "room-1" {
+			t.Errorf("unexpected documents %v", documents)
+		}
+
+		pipeline := mt.GetStartedEvent().Command.Lookup("pipeline").Array()
+		first := pipeline.Index(0).Value().Document().Lookup("$match").Document()
+		if _, err := first.LookupErr("document.room"); err != nil {
+			t.Errorf("candidates should be matched by the filter of the snapshots, got %v", first)
+		}
+		values, _ := pipeline.Values()
+		last := values[len(values)-1].Document().Lookup("$match").Document()
+		if _, err := last.LookupErr("room"); err != nil {
+			t.Errorf("latest snapshots should be matched by the filter again, got %v", last)
+		}
+	})
+}
+
+func TestPrefixFields(t *testing.T) {
+	filter := bson.M{"room": "room-1", "$or": bson.A{bson.M{"name": "Monitor"}, bson.M{"type": "monitor"}}}
+
+	prefixed := prefixFields(filter, "document.")
+
+	alternatives := prefixed["$or"].(bson.A)
+	if prefixed["document.room"] != "room-1" || alternatives[0].(bson.M)["document.name"] != "Monitor" || alternatives[1].(bson.M)["document.type"] != "monitor" {
+		t.Errorf("unexpected filter %v", prefixed)
+	}
+}
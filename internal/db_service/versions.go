@@ -0,0 +1,177 @@
+package db_service
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// operations recorded in the document history
+const (
+	OperationCreate     = "create"
+	OperationUpdate     = "update"
+	OperationSoftDelete = "soft-delete"
+	OperationRestore    = "restore"
+	OperationDelete     = "delete"
+)
+
+// DocumentVersion is a full snapshot of the document taken after each change
+type DocumentVersion[DocType interface{}] struct {
+	Id         string    `json:"id" bson:"id"`
+	Version    int64     `json:"version" bson:"version"`
+	Operation  string    `json:"operation" bson:"operation"`
+	RecordedAt time.Time `json:"recordedAt" bson:"recordedAt"`
+	Document   *DocType  `json:"document" bson:"document"`
+}
+
+func (this *mongoSvc[DocType]) versionsCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection(this.Collection + "_versions")
+}
+
+// versionCountersCollection keeps the latest version number of each document
+func (this *mongoSvc[DocType]) versionCountersCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection(this.Collection + "_version_counters")
+}
+
+// recordVersion stores snapshot of the document, if versioning is enabled for the collection,
+// the version number is allocated by atomic increment of the counter of the document, so that
+// concurrent writers never get the same number, also when they run in transactions where the
+// write conflict on the counter makes the driver retry the whole transaction
+func (this *mongoSvc[DocType]) recordVersion(ctx context.Context, db *mongo.Database, id string, operation string, document *DocType) error {
+	if !this.Versioned {
+		return nil
+	}
+
+	counter := struct {
+		Version int64 `bson:"version"`
+	}{}
+	err := this.versionCountersCollection(db).FindOneAndUpdate(
+		ctx,
+		bson.M{"id": id},
+		bson.M{"$inc": bson.M{"version": 1}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&counter)
+	if err != nil {
+		return err
+	}
+
+	_, err = this.versionsCollection(db).InsertOne(ctx, DocumentVersion[DocType]{
+		Id:         id,
+		Version:    counter.Version,
+		Operation:  operation,
+		RecordedAt: time.Now().UTC(),
+		Document:   document,
+	})
+	return err
+}
+
+func (this *mongoSvc[DocType]) FindVersions(ctx context.Context, id string) ([]*DocumentVersion[DocType], error) {
+	if !this.Versioned {
+		return nil, ErrNotVersioned
+	}
+	ctx, contextCancel := context.WithTimeout(ctx, this.Timeout)
+	defer contextCancel()
+	client, err := this.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	db := client.Database(this.DbName)
+	collection := this.versionsCollection(db)
+	cursor, err := collection.Find(
+		ctx,
+		bson.M{"id": id},
+		options.Find().SetSort(bson.D{{Key: "version", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	versions := []*DocumentVersion[DocType]{}
+	if err := cursor.All(ctx, &versions); err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, ErrNotFound
+	}
+	return versions, nil
+}
+
+// FindDocumentsAsOf reconstructs documents matching the filter as they existed at the given time,
+// documents soft deleted at that time are included only under the context from WithDeleted
+func (this *mongoSvc[DocType]) FindDocumentsAsOf(ctx context.Context, filter bson.M, asOf time.Time) ([]*DocType, error) {
+	if !this.Versioned {
+		return nil, ErrNotVersioned
+	}
+	// the deleted documents are excluded by the filter of the snapshots
+	filter = NotDeleted(ctx, filter)
+	ctx, contextCancel := context.WithTimeout(ctx, this.Timeout)
+	defer contextCancel()
+	client, err := this.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	db := client.Database(this.DbName)
+	collection := this.versionsCollection(db)
+
+	// the documents which ever matched the filter before the point in time are found first, then the latest
+	// snapshot of each of them is taken and filtered again, so that moved documents are listed only where they were
+	candidates := prefixFields(filter, "document.")
+	candidates["recordedAt"] = bson.M{"$lte": asOf}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: candidates}},
+		{{Key: "$group", Value: bson.M{"_id": "$id"}}},
+		// served by the unique (id, version) index
+		{{Key: "$lookup", Value: bson.M{
+			"from": collection.Name(),
+			"let":  bson.M{"id": "$_id"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{"$expr": bson.M{"$and": bson.A{
+					bson.M{"$eq": bson.A{"$id", "$$id"}},
+					bson.M{"$lte": bson.A{"$recordedAt", asOf}},
+				}}}},
+				bson.M{"$sort": bson.D{{Key: "id", Value: 1}, {Key: "version", Value: -1}}},
+				bson.M{"$limit": 1},
+			},
+			"as": "latest",
+		}}},
+		{{Key: "$unwind", Value: "$latest"}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$latest"}}},
+		{{Key: "$match", Value: bson.M{"operation": bson.M{"$ne": OperationDelete}}}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$document"}}},
+		{{Key: "$match", Value: filter}},
+	}
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	documents := []*DocType{}
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
+
+// prefixFields rewrites the filter of the documents to the filter of their snapshots,
+// the fields of the logical operators are rewritten too
+func prefixFields(filter bson.M, prefix string) bson.M {
+	prefixed := bson.M{}
+	for field, condition := range filter {
+		switch field {
+		case "$or", "$and", "$nor":
+			alternatives := bson.A{}
+			for _, alternative := range condition.(bson.A) {
+				alternatives = append(alternatives, prefixFields(alternative.(bson.M), prefix))
+			}
+			prefixed[field] = alternatives
+		default:
+			prefixed[prefix+field] = condition
+		}
+	}
+	return prefixed
+}
@@ -0,0 +1,122 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+type Operation string
+
+const (
+	Created  Operation = "created"
+	Updated  Operation = "updated"
+	Deleted  Operation = "deleted"
+	Restored Operation = "restored"
+)
+
+// Change describes modification of single document, the document is its state after
+// the change, it is nil when the document was removed permanently or could not be read
+type Change[DocType interface{}] struct {
+	Operation Operation
+	Id        string
+	Document  *DocType
+	Time      time.Time
+}
+
+// number of changes kept for subscriber which does not keep up with the publisher
+const subscriberBuffer = 64
+
+// Broker delivers changes to the subscribers within this instance of the service,
+// the subscribers are not notified about changes made by other replicas
+type Broker[DocType interface{}] struct {
+	name        string
+	mutex       sync.RWMutex
+	subscribers map[chan Change[DocType]]struct{}
+}
+
+func NewBroker[DocType interface{}](name string) *Broker[DocType] {
+	return &Broker[DocType]{
+		name:        name,
+		subscribers: map[chan Change[DocType]]struct{}{},
+	}
+}
+
+// Publish notifies all subscribers, it never blocks the publisher -
+// changes are dropped for subscribers with full buffer, changes published
+// with deferring context are delivered only when the Deferred is published
+func (this *Broker[DocType]) Publish(ctx context.Context, change Change[DocType]) {
+	if deferred, ok := ctx.Value(deferredKey{}).(*Deferred); ok {
+		deferred.add(func() { this.deliver(ctx, change) })
+		return
+	}
+	this.deliver(ctx, change)
+}
+
+func (this *Broker[DocType]) deliver(ctx context.Context, change Change[DocType]) {
+	this.mutex.RLock()
+	defer this.mutex.RUnlock()
+
+	for subscriber := range this.subscribers {
+		select {
+		case subscriber <- change:
+		default:
+			slog.WarnContext(ctx, "Change dropped for slow subscriber",
+				"broker", this.name, "operation", change.Operation, "id", change.Id)
+		}
+	}
+}
+
+// Subscribe returns channel of the changes published from now on,
+// the channel is closed when the context is done
+func (this *Broker[DocType]) Subscribe(ctx context.Context) <-chan Change[DocType] {
+	subscriber := make(chan Change[DocType], subscriberBuffer)
+
+	this.mutex.Lock()
+	this.subscribers[subscriber] = struct{}{}
+	this.mutex.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		this.mutex.Lock()
+		delete(this.subscribers, subscriber)
+		this.mutex.Unlock()
+		close(subscriber)
+	}()
+	return subscriber
+}
+
+type deferredKey struct{}
+
+// Deferred holds back the changes made within a transaction,
+// so that subscribers never see changes which were rolled back
+type Deferred struct {
+	mutex   sync.Mutex
+	pending []func()
+}
+
+// Defer returns context whose changes are delivered by Publish of the returned Deferred,
+// changes of an aborted transaction are dropped by not publishing it
+func Defer(ctx context.Context) (context.Context, *Deferred) {
+	deferred := &Deferred{}
+	return context.WithValue(ctx, deferredKey{}, deferred), deferred
+}
+
+func (this *Deferred) add(deliver func()) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	this.pending = append(this.pending, deliver)
+}
+
+// Publish delivers the held back changes in the order they were made
+func (this *Deferred) Publish() {
+	this.mutex.Lock()
+	pending := this.pending
+	this.pending = nil
+	this.mutex.Unlock()
+
+	for _, deliver := range pending {
+		deliver()
+	}
+}
@@ -0,0 +1,105 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// publishingSvc publishes successful modifications made through the wrapped service,
+// the other operations are passed to the wrapped service unchanged
+type publishingSvc[DocType interface{}] struct {
+	db_service.DbService[DocType]
+	broker *Broker[DocType]
+}
+
+// Publishing wraps the service so that its modifications are published by the broker
+func Publishing[DocType interface{}](next db_service.DbService[DocType], broker *Broker[DocType]) db_service.DbService[DocType] {
+	return &publishingSvc[DocType]{DbService: next, broker: broker}
+}
+
+func (this *publishingSvc[DocType]) publish(ctx context.Context, operation Operation, id string, document *DocType) {
+	this.broker.Publish(ctx, Change[DocType]{
+		Operation: operation,
+		Id:        id,
+		Document:  document,
+		Time:      time.Now().UTC(),
+	})
+}
+
+func (this *publishingSvc[DocType]) CreateDocument(ctx context.Context, id string, document *DocType) error {
+	err := this.DbService.CreateDocument(ctx, id, document)
+	if err == nil {
+		this.publish(ctx, Created, id, document)
+	}
+	return err
+}
+
+func (this *publishingSvc[DocType]) UpdateDocument(ctx context.Context, id string, document *DocType) error {
+	err := this.DbService.UpdateDocument(ctx, id, document)
+	if err == nil {
+		this.publish(ctx, Updated, id, document)
+	}
+	return err
+}
+
+func (this *publishingSvc[DocType]) UpdateDocumentIf(ctx context.Context, id string, condition bson.M, document *DocType) error {
+	err := this.DbService.UpdateDocumentIf(ctx, id, condition, document)
+	if err == nil {
+		this.publish(ctx, Updated, id, document)
+	}
+	return err
+}
+
+func (this *publishingSvc[DocType]) PatchDocument(ctx context.Context, id string, changes bson.M) (*DocType, error) {
+	document, err := this.DbService.PatchDocument(ctx, id, changes)
+	if err == nil {
+		this.publish(ctx, Updated, id, document)
+	}
+	return document, err
+}
+
+func (this *publishingSvc[DocType]) IncrementField(ctx context.Context, id string, field string, delta int32) (*DocType, error) {
+	document, err := this.DbService.IncrementField(ctx, id, field, delta)
+	if err == nil {
+		this.publish(ctx, Updated, id, document)
+	}
+	return document, err
+}
+
+func (this *publishingSvc[DocType]) DeleteDocument(ctx context.Context, id string) error {
+	err := this.DbService.DeleteDocument(ctx, id)
+	if err == nil {
+		this.publish(ctx, Deleted, id, nil)
+	}
+	return err
+}
+
+func (this *publishingSvc[DocType]) DeleteDocumentIf(ctx context.Context, id string, condition bson.M) error {
+	err := this.DbService.DeleteDocumentIf(ctx, id, condition)
+	if err == nil {
+		this.publish(ctx, Deleted, id, nil)
+	}
+	return err
+}
+
+func (this *publishingSvc[DocType]) SoftDeleteDocument(ctx context.Context, id string, deletedBy string) error {
+	err := this.DbService.SoftDeleteDocument(ctx, id, deletedBy)
+	if err != nil {
+		return err
+	}
+	// subscribers get also the deletion markers, the change is published even if the lookup fails
+	document, _ := this.DbService.FindDocument(db_service.WithDeleted(ctx), id)
+	this.publish(ctx, Deleted, id, document)
+	return nil
+}
+
+func (this *publishingSvc[DocType]) RestoreDocument(ctx context.Context, id string) (*DocType, error) {
+	document, err := this.DbService.RestoreDocument(ctx, id)
+	if err == nil {
+		this.publish(ctx, Restored, id, document)
+	}
+	return document, err
+}
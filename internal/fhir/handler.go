@@ -0,0 +1,548 @@
+package fhir
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/fpjp"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/problem"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Services are the database services searched by the facade and the inventory
+// which applies the business rules to the read and submitted resources
+type Services struct {
+	Departments db_service.DbService[fpjp.Department]
+	Rooms       db_service.DbService[fpjp.Room]
+	Equipment   db_service.DbService[fpjp.Equipment]
+	Requests    db_service.DbService[fpjp.Request]
+	Inventory   *fpjp.Inventory
+}
+
+// Handler serves the FHIR R4 facade of the inventory, equipment is exposed as Device,
+// requests for missing equipment as DeviceRequest, requests for repair as ServiceRequest
+// and departments with their rooms as Location
+type Handler struct {
+	services Services
+	// path of the route group, e.g. /fhir
+	basePath string
+}
+
+func NewHandler(services Services) *Handler {
+	return &Handler{services: services}
+}
+
+// AddRoutes registers the interactions supported by the facade, see CapabilityStatement at metadata
+func (this *Handler) AddRoutes(group *gin.RouterGroup) {
+	this.basePath = group.BasePath()
+	group.GET("/metadata", this.Capabilities)
+	group.GET("/Device", this.SearchDevices)
+	group.GET("/Device/:id", this.ReadDevice)
+	group.GET("/Location", this.SearchLocations)
+	group.GET("/Location/:id", this.ReadLocation)
+	group.GET("/DeviceRequest", this.SearchDeviceRequests)
+	group.GET("/DeviceRequest/:id", this.ReadDeviceRequest)
+	group.POST("/DeviceRequest", this.CreateDeviceRequest)
+	group.GET("/ServiceRequest", this.SearchServiceRequests)
+	group.GET("/ServiceRequest/:id", this.ReadServiceRequest)
+	group.POST("/ServiceRequest", this.CreateServiceRequest)
+}
+
+// Capabilities describes the supported resources, interactions and search parameters
+func (this *Handler) Capabilities(ctx *gin.Context) {
+	resources := []gin.H{}
+	for _, resource := range capabilities {
+		interactions := []gin.H{{"code": "read"}, {"code": "search-type"}}
+		if resource.create {
+			interactions = append(interactions, gin.H{"code": "create"})
+		}
+		searchParams := []gin.H{}
+		for _, parameter := range resource.searchParams {
+			searchParams = append(searchParams, gin.H{"name": parameter.name, "type": parameter.kind})
+		}
+		resources = append(resources, gin.H{
+			"type":        resource.resourceType,
+			"interaction": interactions,
+			"searchParam": searchParams,
+		})
+	}
+
+	ctx.Header("Content-Type", ContentType)
+	ctx.JSON(http.StatusOK, gin.H{
+		"resourceType": "CapabilityStatement",
+		"status":       "active",
+		"kind":         "instance",
+		"fhirVersion":  "4.0.1",
+		"format":       []string{"json"},
+		"rest": []gin.H{{
+			"mode":     "server",
+			"resource": resources,
+		}},
+	})
+}
+
+type searchParam struct {
+	name string
+	kind string
+}
+
+var capabilities = []struct {
+	resourceType string
+	create       bool
+	searchParams []searchParam
+}{
+	{"Device", false, []searchParam{{"_id", "token"}, {"location", "reference"}, {"type", "token"}, {"device-name", "string"}}},
+	{"Location", false, []searchParam{{"_id", "token"}, {"name", "string"}, {"partof", "reference"}, {"type", "token"}}},
+	{"DeviceRequest", true, []searchParam{{"_id", "token"}, {"subject", "reference"}, {"code", "token"}, {"status", "token"}}},
+	{"ServiceRequest", true, []searchParam{{"_id", "token"}, {"subject", "reference"}, {"code", "token"}, {"status", "token"}}},
+}
+
+// SearchDevices - Provides the equipment matching the search parameters
+func (this *Handler) SearchDevices(ctx *gin.Context) {
+	slog.DebugContext(ctx, "Handling request", "operation", "SearchDevices", "api", "fhir")
+
+	filter := bson.M{}
+	if ids := tokens(ctx.Query("_id")); ids != nil {
+		filter["id"] = bson.M{"$in": ids}
+	}
+	if types := tokens(ctx.Query("type")); types != nil {
+		filter["type"] = bson.M{"$in": types}
+	}
+	if name, contains := stringParam(ctx, "device-name"); name != "" {
+		filter["name"] = stringMatch(name, contains)
+	}
+	if reference := ctx.Query("location"); reference != "" {
+		rooms, err := this.locationRooms(ctx, reference)
+		if err != nil {
+			respond(ctx, err)
+			return
+		}
+		filter["room"] = bson.M{"$in": rooms}
+	}
+
+	equipment, err := this.services.Equipment.FindDocuments(ctx, filter)
+	if err != nil {
+		respond(ctx, problem.Database(err))
+		return
+	}
+	resources := make([]resource, len(equipment))
+	for i, item := range equipment {
+		resources[i] = resource{"Device", item.Id, toDevice(item)}
+	}
+	this.searchset(ctx, resources)
+}
+
+// ReadDevice - Provides specific equipment
+func (this *Handler) ReadDevice(ctx *gin.Context) {
+	slog.DebugContext(ctx, "Handling request", "operation", "ReadDevice", "api", "fhir")
+
+	equipment, err := this.services.Inventory.Equipment(ctx, fpjp.RequestCaller(ctx), ctx.Param("id"), false)
+	if err != nil {
+		respond(ctx, err)
+		return
+	}
+	read(ctx, toDevice(equipment))
+}
+
+// SearchLocations - Provides the departments and rooms matching the search parameters
+func (this *Handler) SearchLocations(ctx *gin.Context) {
+	slog.DebugContext(ctx, "Handling request", "operation", "SearchLocations", "api", "fhir")
+
+	departmentFilter := bson.M{}
+	roomFilter := bson.M{}
+	if ids := tokens(ctx.Query("_id")); ids != nil {
+		departmentFilter["id"] = bson.M{"$in": prefixed(ids, departmentPrefix)}
+		roomFilter["id"] = bson.M{"$in": prefixed(ids, roomPrefix)}
+	}
+	if name, contains := stringParam(ctx, "name"); name != "" {
+		departmentFilter["name"] = stringMatch(name, contains)
+		roomFilter["name"] = stringMatch(name, contains)
+	}
+	// only rooms are part of other location
+	includeDepartments := true
+	if reference := ctx.Query("partof"); reference != "" {
+		includeDepartments = false
+		departmentId, _ := strings.CutPrefix(reference, "Location/")
+		departmentId, _ = strings.CutPrefix(departmentId, departmentPrefix)
+		roomFilter["department_id"] = departmentId
+	}
+	includeRooms := true
+	if types := tokens(ctx.Query("type")); types != nil {
+		includeDepartments = includeDepartments && contains(types, "wa")
+		includeRooms = contains(types, "ro")
+	}
+
+	resources := []resource{}
+	if includeDepartments {
+		departments, err := this.services.Departments.FindDocuments(ctx, departmentFilter)
+		if err != nil {
+			respond(ctx, problem.Database(err))
+			return
+		}
+		for _, department := range departments {
+			location := departmentLocation(department)
+			resources = append(resources, resource{"Location", location.Id, location})
+		}
+	}
+	if includeRooms {
+		rooms, err := this.services.Rooms.FindDocuments(ctx, roomFilter)
+		if err != nil {
+			respond(ctx, problem.Database(err))
+			return
+		}
+		for _, room := range rooms {
+			location := roomLocation(room)
+			resources = append(resources, resource{"Location", location.Id, location})
+		}
+	}
+	this.searchset(ctx, resources)
+}
+
+// ReadLocation - Provides specific department or room
+func (this *Handler) ReadLocation(ctx *gin.Context) {
+	slog.DebugContext(ctx, "Handling request", "operation", "ReadLocation", "api", "fhir")
+
+	id := ctx.Param("id")
+	if departmentId, found := strings.CutPrefix(id, departmentPrefix); found {
+		department, err := this.services.Inventory.Department(ctx, departmentId)
+		if err != nil {
+			respond(ctx, err)
+			return
+		}
+		read(ctx, departmentLocation(department))
+		return
+	}
+	if roomId, found := strings.CutPrefix(id, roomPrefix); found {
+		room, err := this.services.Inventory.Room(ctx, roomId)
+		if err != nil {
+			respond(ctx, err)
+			return
+		}
+		read(ctx, roomLocation(room))
+		return
+	}
+	respond(ctx, problem.NotFound("location", "Location with provided ID was not found."))
+}
+
+// SearchDeviceRequests - Provides the requests for missing equipment matching the search parameters
+func (this *Handler) SearchDeviceRequests(ctx *gin.Context) {
+	slog.DebugContext(ctx, "Handling request", "operation", "SearchDeviceRequests", "api", "fhir")
+
+	requests, err := this.searchRequests(ctx, missingEquipment)
+	if err != nil {
+		respond(ctx, err)
+		return
+	}
+	resources := make([]resource, len(requests))
+	for i, request := range requests {
+		resources[i] = resource{"DeviceRequest", request.Id, toDeviceRequest(request)}
+	}
+	this.searchset(ctx, resources)
+}
+
+// ReadDeviceRequest - Provides specific request for missing equipment
+func (this *Handler) ReadDeviceRequest(ctx *gin.Context) {
+	slog.DebugContext(ctx, "Handling request", "operation", "ReadDeviceRequest", "api", "fhir")
+
+	request, err := this.readRequest(ctx, missingEquipment)
+	if err != nil {
+		respond(ctx, err)
+		return
+	}
+	read(ctx, toDeviceRequest(request))
+}
+
+// CreateDeviceRequest - Submits new request for missing equipment
+func (this *Handler) CreateDeviceRequest(ctx *gin.Context) {
+	slog.DebugContext(ctx, "Handling request", "operation", "CreateDeviceRequest", "api", "fhir")
+
+	resource := DeviceRequest{}
+	if err := ctx.ShouldBindJSON(&resource); err != nil {
+		respond(ctx, problem.InvalidBody(err))
+		return
+	}
+	request, err := fromDeviceRequest(&resource)
+	if err != nil {
+		respond(ctx, err)
+		return
+	}
+	this.createRequest(ctx, request, "DeviceRequest", func() interface{} { return toDeviceRequest(request) })
+}
+
+// SearchServiceRequests - Provides the requests for repair matching the search parameters
+func (this *Handler) SearchServiceRequests(ctx *gin.Context) {
+	slog.DebugContext(ctx, "Handling request", "operation", "SearchServiceRequests", "api", "fhir")
+
+	requests, err := this.searchRequests(ctx, repair)
+	if err != nil {
+		respond(ctx, err)
+		return
+	}
+	resources := make([]resource, len(requests))
+	for i, request := range requests {
+		resources[i] = resource{"ServiceRequest", request.Id, toServiceRequest(request)}
+	}
+	this.searchset(ctx, resources)
+}
+
+// ReadServiceRequest - Provides specific request for repair
+func (this *Handler) ReadServiceRequest(ctx *gin.Context) {
+	slog.DebugContext(ctx, "Handling request", "operation", "ReadServiceRequest", "api", "fhir")
+
+	request, err := this.readRequest(ctx, repair)
+	if err != nil {
+		respond(ctx, err)
+		return
+	}
+	read(ctx, toServiceRequest(request))
+}
+
+// CreateServiceRequest - Submits new request for repair
+func (this *Handler) CreateServiceRequest(ctx *gin.Context) {
+	slog.DebugContext(ctx, "Handling request", "operation", "CreateServiceRequest", "api", "fhir")
+
+	resource := ServiceRequest{}
+	if err := ctx.ShouldBindJSON(&resource); err != nil {
+		respond(ctx, problem.InvalidBody(err))
+		return
+	}
+	request, err := fromServiceRequest(&resource)
+	if err != nil {
+		respond(ctx, err)
+		return
+	}
+	this.createRequest(ctx, request, "ServiceRequest", func() interface{} { return toServiceRequest(request) })
+}
+
+// searchRequests finds the requests of the type matching the search parameters shared by DeviceRequest and ServiceRequest
+func (this *Handler) searchRequests(ctx *gin.Context, requestType string) ([]*fpjp.Request, error) {
+	// the service keeps only active requests, deleted ones are not exposed by the facade
+	if statuses := tokens(ctx.Query("status")); statuses != nil && !contains(statuses, "active") {
+		return []*fpjp.Request{}, nil
+	}
+
+	filter := bson.M{"type": requestType}
+	if ids := tokens(ctx.Query("_id")); ids != nil {
+		filter["id"] = bson.M{"$in": ids}
+	}
+	if codes := tokens(ctx.Query("code")); codes != nil {
+		// the name of the equipment is the text of the code
+		names := make([]interface{}, len(codes))
+		for i, code := range codes {
+			names[i] = primitive.Regex{Pattern: "^" + regexp.QuoteMeta(code) + "$", Options: "i"}
+		}
+		filter["name"] = bson.M{"$in": names}
+	}
+	if reference := ctx.Query("subject"); reference != "" {
+		rooms, err := this.locationRooms(ctx, reference)
+		if err != nil {
+			return nil, err
+		}
+		filter["room"] = bson.M{"$in": rooms}
+	}
+
+	requests, err := this.services.Requests.FindDocuments(ctx, filter)
+	if err != nil {
+		return nil, problem.Database(err)
+	}
+	return requests, nil
+}
+
+// readRequest provides the request of the type, requests of the other type are not found
+func (this *Handler) readRequest(ctx *gin.Context, requestType string) (*fpjp.Request, error) {
+	request, err := this.services.Inventory.Request(ctx, fpjp.RequestCaller(ctx), ctx.Param("id"), false)
+	if err != nil {
+		return nil, err
+	}
+	if request.Type != requestType {
+		return nil, problem.NotFound("request", "Request with provided ID was not found.")
+	}
+	return request, nil
+}
+
+// createRequest stores the request under new id, the id of the submitted resource is ignored as in the FHIR create interaction
+func (this *Handler) createRequest(ctx *gin.Context, request *fpjp.Request, resourceType string, created func() interface{}) {
+	// the referenced room Location must exist
+	_, err := this.services.Rooms.FindDocument(ctx, request.Room)
+	switch {
+	case err == nil:
+	case errors.Is(err, db_service.ErrNotFound):
+		respond(ctx, invalidResource(fieldError(resourceType+".subject", "not-found", "must reference existing room Location")))
+		return
+	default:
+		respond(ctx, problem.Database(err))
+		return
+	}
+
+	request.Id = ""
+	if err := this.services.Inventory.CreateRequest(ctx, request); err != nil {
+		respond(ctx, err)
+		return
+	}
+
+	ctx.Header("Location", this.baseUrl(ctx)+"/"+resourceType+"/"+request.Id)
+	ctx.Header("Content-Type", ContentType)
+	ctx.JSON(http.StatusCreated, created())
+}
+
+// locationRooms resolves the Location reference to the ids of the rooms, department includes all its rooms
+func (this *Handler) locationRooms(ctx context.Context, reference string) ([]string, error) {
+	id, _ := strings.CutPrefix(reference, "Location/")
+	if roomId, found := strings.CutPrefix(id, roomPrefix); found {
+		return []string{roomId}, nil
+	}
+	if departmentId, found := strings.CutPrefix(id, departmentPrefix); found {
+		rooms, err := this.services.Rooms.FindDocuments(ctx, bson.M{"department_id": departmentId})
+		if err != nil {
+			return nil, problem.Database(err)
+		}
+		ids := make([]string, len(rooms))
+		for i, room := range rooms {
+			ids[i] = room.Id
+		}
+		return ids, nil
+	}
+	// unknown location matches nothing
+	return []string{}, nil
+}
+
+// resource is the search match together with the parts of its full URL
+type resource struct {
+	resourceType string
+	id           string
+	body         interface{}
+}
+
+// searchset responds with the page of the matches, _count limits the number of the entries and _offset skips
+// the preceding ones, the links of the Bundle navigate to the neighbouring pages
+func (this *Handler) searchset(ctx *gin.Context, resources []resource) {
+	// the pages are stable only when the matches are always in the same order
+	sort.Slice(resources, func(i, j int) bool { return resources[i].id < resources[j].id })
+
+	offset, err := strconv.Atoi(ctx.Query("_offset"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	count, err := strconv.Atoi(ctx.Query("_count"))
+	if err != nil || count < 0 || count > len(resources) {
+		count = len(resources)
+	}
+
+	bundle := Bundle{
+		ResourceType: "Bundle",
+		Type:         "searchset",
+		Total:        len(resources),
+		Link:         []BundleLink{{Relation: "self", Url: this.origin(ctx) + ctx.Request.URL.RequestURI()}},
+		Entry:        []BundleEntry{},
+	}
+	if count > 0 {
+		if offset > 0 {
+			bundle.Link = append(bundle.Link,
+				BundleLink{Relation: "first", Url: this.pageUrl(ctx, 0, count)},
+				BundleLink{Relation: "previous", Url: this.pageUrl(ctx, max(offset-count, 0), count)})
+		}
+		if offset+count < len(resources) {
+			bundle.Link = append(bundle.Link, BundleLink{Relation: "next", Url: this.pageUrl(ctx, offset+count, count)})
+		}
+	}
+
+	for _, resource := range resources[min(offset, len(resources)):min(offset+count, len(resources))] {
+		bundle.Entry = append(bundle.Entry, BundleEntry{
+			FullUrl:  this.baseUrl(ctx) + "/" + resource.resourceType + "/" + resource.id,
+			Resource: resource.body,
+			Search:   &EntrySearch{Mode: "match"},
+		})
+	}
+
+	ctx.Header("Content-Type", ContentType)
+	ctx.JSON(http.StatusOK, bundle)
+}
+
+// pageUrl is the URL of the search with the same parameters for the page starting at the offset
+func (this *Handler) pageUrl(ctx *gin.Context, offset int, count int) string {
+	query := ctx.Request.URL.Query()
+	query.Set("_offset", strconv.Itoa(offset))
+	query.Set("_count", strconv.Itoa(count))
+	return this.origin(ctx) + ctx.Request.URL.Path + "?" + query.Encode()
+}
+
+func read(ctx *gin.Context, resource interface{}) {
+	ctx.Header("Content-Type", ContentType)
+	ctx.JSON(http.StatusOK, resource)
+}
+
+// baseUrl is the absolute URL of the facade, e.g. https://hospital.example/fhir
+func (this *Handler) baseUrl(ctx *gin.Context) string {
+	return this.origin(ctx) + this.basePath
+}
+
+// origin is the scheme and host the client used, the proxy in front of the service reports the scheme
+func (this *Handler) origin(ctx *gin.Context) string {
+	scheme := "http"
+	if ctx.Request.TLS != nil {
+		scheme = "https"
+	}
+	if forwarded := ctx.GetHeader("X-Forwarded-Proto"); forwarded != "" {
+		scheme = forwarded
+	}
+	return scheme + "://" + ctx.Request.Host
+}
+
+// tokens splits the comma separated values of the token parameter, the system part of system|code is ignored
+func tokens(value string) []string {
+	if value == "" {
+		return nil
+	}
+	values := strings.Split(value, ",")
+	for i, token := range values {
+		if _, code, found := strings.Cut(token, "|"); found {
+			values[i] = code
+		}
+	}
+	return values
+}
+
+// stringParam returns value of the string parameter, :contains modifier matches anywhere instead of the start
+func stringParam(ctx *gin.Context, name string) (string, bool) {
+	if value := ctx.Query(name + ":contains"); value != "" {
+		return value, true
+	}
+	return ctx.Query(name), false
+}
+
+// stringMatch matches the string case insensitively as the FHIR string search does
+func stringMatch(value string, contains bool) bson.M {
+	pattern := regexp.QuoteMeta(value)
+	if !contains {
+		pattern = "^" + pattern
+	}
+	return bson.M{"$regex": pattern, "$options": "i"}
+}
+
+func prefixed(ids []string, prefix string) []string {
+	matching := []string{}
+	for _, id := range ids {
+		if trimmed, found := strings.CutPrefix(id, prefix); found {
+			matching = append(matching, trimmed)
+		}
+	}
+	return matching
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,212 @@
+package fhir
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service/dbtest"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/fpjp"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/idempotency"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// newTestEngine serves the facade at /fhir with the services kept in memory,
+// room r1 exists and contains the equipment eq-0 to eq-4
+func newTestEngine(t *testing.T, middlewares ...gin.HandlerFunc) (*gin.Engine, db_service.DbService[fpjp.Request]) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	config := dbtest.MemoryServiceConfig{Store: dbtest.NewMemoryStore()}
+	services := Services{
+		Departments: dbtest.NewMemoryService[fpjp.Department](config),
+		Rooms:       dbtest.NewMemoryService[fpjp.Room](config),
+		Equipment:   dbtest.NewMemoryService[fpjp.Equipment](config),
+		Requests:    dbtest.NewMemoryService[fpjp.Request](config),
+	}
+	services.Inventory = fpjp.NewInventory(fpjp.InventoryConfig{
+		Departments: services.Departments,
+		Rooms:       services.Rooms,
+		Equipment:   services.Equipment,
+		Requests:    services.Requests,
+	})
+
+	ctx := context.Background()
+	if err := services.Rooms.CreateDocument(ctx, "r1", &fpjp.Room{Id: "r1", DepartmentId: "d1", Name: "Operating room"}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		id := fmt.Sprintf("eq-%v", i)
+		equipment := &fpjp.Equipment{Id: id, Room: "r1", Type: "monitor", Name: "Monitor", Count: 1}
+		if err := services.Equipment.CreateDocument(ctx, id, equipment); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	engine := gin.New()
+	NewHandler(services).AddRoutes(engine.Group("/fhir", middlewares...))
+	return engine, services.Requests
+}
+
+func serve(engine *gin.Engine, method string, target string, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestSearchsetPages(t *testing.T) {
+	engine, _ := newTestEngine(t)
+
+	tests := map[string]struct {
+		query   string
+		entries []string
+		links   map[string]string
+	}{
+		"first page":  {"_count=2", []string{"eq-0", "eq-1"}, map[string]string{"next": "2"}},
+		"middle page": {"_count=2&_offset=2", []string{"eq-2", "eq-3"}, map[string]string{"first": "0", "previous": "0", "next": "4"}},
+		"last page":   {"_count=2&_offset=4", []string{"eq-4"}, map[string]string{"first": "0", "previous": "2"}},
+		"all entries": {"", []string{"eq-0", "eq-1", "eq-2", "eq-3", "eq-4"}, map[string]string{}},
+		"past end":    {"_count=2&_offset=9", []string{}, map[string]string{"first": "0", "previous": "7"}},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			response := serve(engine, http.MethodGet, "/fhir/Device?type=monitor&"+test.query, "")
+
+			if response.Code != http.StatusOK {
+				t.Fatalf("unexpected status %v: %v", response.Code, response.Body.String())
+			}
+			bundle := struct {
+				Total int          `json:"total"`
+				Link  []BundleLink `json:"link"`
+				Entry []struct {
+					Resource Device `json:"resource"`
+				} `json:"entry"`
+			}{}
+			if err := json.Unmarshal(response.Body.Bytes(), &bundle); err != nil {
+				t.Fatal(err)
+			}
+
+			if bundle.Total != 5 {
+				t.Errorf("total should count all matches, got %v", bundle.Total)
+			}
+			entries := []string{}
+			for _, entry := range bundle.Entry {
+				entries = append(entries, entry.Resource.Id)
+			}
+			if fmt.Sprint(entries) != fmt.Sprint(test.entries) {
+				t.Errorf("expected entries %v, got %v", test.entries, entries)
+			}
+
+			offsets := map[string]string{}
+			for _, link := range bundle.Link {
+				if link.Relation == "self" {
+					continue
+				}
+				linked, err := url.Parse(link.Url)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if linked.Query().Get("type") != "monitor" || linked.Query().Get("_count") != "2" {
+					t.Errorf("link %v should keep the search parameters, got %v", link.Relation, link.Url)
+				}
+				offsets[link.Relation] = linked.Query().Get("_offset")
+			}
+			if fmt.Sprint(offsets) != fmt.Sprint(test.links) {
+				t.Errorf("expected links %v, got %v", test.links, offsets)
+			}
+		})
+	}
+}
+
+func TestCreateRequestOfMissingRoom(t *testing.T) {
+	engine, requests := newTestEngine(t)
+
+	tests := map[string]struct {
+		path string
+		body string
+	}{
+		"device request": {"/fhir/DeviceRequest",
+			`{"resourceType": "DeviceRequest", "codeCodeableConcept": {"text": "Monitor"}, "subject": {"reference": "Location/room-r9"}}`},
+		"service request": {"/fhir/ServiceRequest",
+			`{"resourceType": "ServiceRequest", "code": {"text": "Monitor"}, "subject": {"reference": "Location/room-r9"}}`},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			response := serve(engine, http.MethodPost, test.path, test.body)
+
+			if response.Code != http.StatusBadRequest {
+				t.Fatalf("unexpected status %v: %v", response.Code, response.Body.String())
+			}
+			outcome := OperationOutcome{}
+			if err := json.Unmarshal(response.Body.Bytes(), &outcome); err != nil {
+				t.Fatal(err)
+			}
+			if outcome.ResourceType != "OperationOutcome" || len(outcome.Issue) != 2 || outcome.Issue[1].Code != "invalid" {
+				t.Errorf("unexpected outcome %+v", outcome)
+			}
+		})
+	}
+
+	stored, err := requests.FindDocuments(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored) != 0 {
+		t.Errorf("no request should be stored, got %v", len(stored))
+	}
+}
+
+func TestCreateRequestOfExistingRoom(t *testing.T) {
+	engine, _ := newTestEngine(t)
+
+	response := serve(engine, http.MethodPost, "/fhir/DeviceRequest",
+		`{"resourceType": "DeviceRequest", "codeCodeableConcept": {"text": "Monitor"}, "subject": {"reference": "Location/room-r1"}}`)
+
+	if response.Code != http.StatusCreated || !strings.Contains(response.Header().Get("Location"), "/fhir/DeviceRequest/") {
+		t.Errorf("unexpected response %v %v: %v", response.Code, response.Header(), response.Body.String())
+	}
+}
+
+func TestRetriedCreateIsReplayed(t *testing.T) {
+	engine, requests := newTestEngine(t, idempotency.Middleware(idempotency.Config{
+		Store:             dbtest.NewMemoryService[idempotency.Record](dbtest.MemoryServiceConfig{}),
+		Retention:         time.Hour,
+		ProcessingTimeout: time.Minute,
+		Caller:            func(ctx *gin.Context) string { return fpjp.RequestCaller(ctx).User },
+	}))
+	create := func() *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "/fhir/ServiceRequest", strings.NewReader(
+			`{"resourceType": "ServiceRequest", "code": {"text": "Repair"}, "subject": {"reference": "Location/room-r1"}}`))
+		request.Header.Set("Content-Type", "application/fhir+json")
+		request.Header.Set(idempotency.KeyHeader, "key-1")
+		recorder := httptest.NewRecorder()
+		engine.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	first := create()
+	retry := create()
+
+	if first.Code != http.StatusCreated || retry.Code != http.StatusCreated || retry.Header().Get(idempotency.ReplayedHeader) != "true" {
+		t.Fatalf("expected replay of the created request, got %v and %v: %v", first.Code, retry.Code, retry.Body.String())
+	}
+	if retry.Header().Get("Location") != first.Header().Get("Location") {
+		t.Errorf("replay should point to the created request, got %v", retry.Header().Get("Location"))
+	}
+	stored, err := requests.FindDocuments(context.Background(), bson.M{})
+	if err != nil || len(stored) != 1 {
+		t.Errorf("single request should be stored, got %v, %v", len(stored), err)
+	}
+}
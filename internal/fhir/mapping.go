@@ -0,0 +1,219 @@
+package fhir
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/fpjp"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/problem"
+)
+
+// types of the requests stored by the service
+const (
+	missingEquipment = "missing-equipment"
+	repair           = "repair"
+)
+
+// departments and rooms share the Location resource, their ids are distinguished by the prefix
+const (
+	departmentPrefix = "department-"
+	roomPrefix       = "room-"
+)
+
+const physicalTypeSystem = "http://terminology.hl7.org/CodeSystem/location-physical-type"
+
+// code of the DeviceRequest parameter carrying the number of requested items
+const quantityParameter = "quantity"
+
+func departmentLocation(department *fpjp.Department) *Location {
+	return &Location{
+		ResourceType: "Location",
+		Id:           departmentPrefix + department.Id,
+		Status:       "active",
+		Name:         department.Name,
+		Mode:         "instance",
+		PhysicalType: &CodeableConcept{Coding: []Coding{{System: physicalTypeSystem, Code: "wa", Display: "Ward"}}},
+	}
+}
+
+func roomLocation(room *fpjp.Room) *Location {
+	return &Location{
+		ResourceType: "Location",
+		Id:           roomPrefix + room.Id,
+		Status:       "active",
+		Name:         room.Name,
+		Mode:         "instance",
+		PhysicalType: &CodeableConcept{Coding: []Coding{{System: physicalTypeSystem, Code: "ro", Display: "Room"}}},
+		PartOf:       &Reference{Reference: "Location/" + departmentPrefix + room.DepartmentId},
+	}
+}
+
+func roomReference(roomId string) *Reference {
+	return &Reference{Reference: "Location/" + roomPrefix + roomId}
+}
+
+func toDevice(equipment *fpjp.Equipment) *Device {
+	return &Device{
+		ResourceType: "Device",
+		Id:           equipment.Id,
+		Status:       "active",
+		DeviceName:   []DeviceName{{Name: equipment.Name, Type: "user-friendly-name"}},
+		Type:         &CodeableConcept{Text: equipment.Type},
+		Property: []DeviceProperty{{
+			Type:          CodeableConcept{Text: "count"},
+			ValueQuantity: []Quantity{{Value: equipment.Count}},
+		}},
+		Location: roomReference(equipment.Room),
+	}
+}
+
+func toDeviceRequest(request *fpjp.Request) *DeviceRequest {
+	resource := &DeviceRequest{
+		ResourceType:        "DeviceRequest",
+		Id:                  request.Id,
+		Status:              "active",
+		Intent:              "order",
+		CodeCodeableConcept: &CodeableConcept{Text: request.Name},
+		Subject:             roomReference(request.Room),
+	}
+	if request.Count != nil {
+		resource.Parameter = []DeviceRequestParameter{{
+			Code:          &CodeableConcept{Text: quantityParameter},
+			ValueQuantity: &Quantity{Value: *request.Count},
+		}}
+	}
+	if request.Description != "" {
+		resource.Note = []Annotation{{Text: request.Description}}
+	}
+	return resource
+}
+
+func toServiceRequest(request *fpjp.Request) *ServiceRequest {
+	resource := &ServiceRequest{
+		ResourceType:      "ServiceRequest",
+		Id:                request.Id,
+		Status:            "active",
+		Intent:            "order",
+		Code:              &CodeableConcept{Text: request.Name},
+		Subject:           roomReference(request.Room),
+		LocationReference: []Reference{*roomReference(request.Room)},
+	}
+	if request.Description != "" {
+		resource.Note = []Annotation{{Text: request.Description}}
+	}
+	return resource
+}
+
+// fromDeviceRequest converts the DeviceRequest to the request for missing equipment,
+// the subject must reference the room which needs the equipment
+func fromDeviceRequest(resource *DeviceRequest) (*fpjp.Request, error) {
+	invalid := []problem.FieldError{}
+	if resource.ResourceType != "DeviceRequest" {
+		invalid = append(invalid, fieldError("DeviceRequest.resourceType", "invalid", "must be DeviceRequest"))
+	}
+	name := conceptText(resource.CodeCodeableConcept)
+	if name == "" {
+		invalid = append(invalid, fieldError("DeviceRequest.codeCodeableConcept", "required", "is required"))
+	}
+	roomId, ok := roomId(resource.Subject)
+	if !ok {
+		invalid = append(invalid, fieldError("DeviceRequest.subject", "invalid", "must reference room Location"))
+	}
+	if len(invalid) > 0 {
+		return nil, invalidResource(invalid...)
+	}
+
+	request := &fpjp.Request{
+		Id:          resource.Id,
+		Room:        roomId,
+		Type:        missingEquipment,
+		Name:        name,
+		Description: notes(resource.Note),
+	}
+	for _, parameter := range resource.Parameter {
+		if conceptText(parameter.Code) == quantityParameter && parameter.ValueQuantity != nil {
+			count := parameter.ValueQuantity.Value
+			request.Count = &count
+		}
+	}
+	return request, nil
+}
+
+// fromServiceRequest converts the ServiceRequest to the request for repair,
+// the subject or the location must reference the room with the broken equipment
+func fromServiceRequest(resource *ServiceRequest) (*fpjp.Request, error) {
+	invalid := []problem.FieldError{}
+	if resource.ResourceType != "ServiceRequest" {
+		invalid = append(invalid, fieldError("ServiceRequest.resourceType", "invalid", "must be ServiceRequest"))
+	}
+	name := conceptText(resource.Code)
+	if name == "" {
+		invalid = append(invalid, fieldError("ServiceRequest.code", "required", "is required"))
+	}
+	location := resource.Subject
+	if location == nil && len(resource.LocationReference) > 0 {
+		location = &resource.LocationReference[0]
+	}
+	roomId, ok := roomId(location)
+	if !ok {
+		invalid = append(invalid, fieldError("ServiceRequest.subject", "invalid", "must reference room Location"))
+	}
+	if len(invalid) > 0 {
+		return nil, invalidResource(invalid...)
+	}
+
+	return &fpjp.Request{
+		Id:          resource.Id,
+		Room:        roomId,
+		Type:        repair,
+		Name:        name,
+		Description: notes(resource.Note),
+	}, nil
+}
+
+// roomId extracts id of the room from the Location reference, e.g. Location/room-r1
+func roomId(reference *Reference) (string, bool) {
+	if reference == nil {
+		return "", false
+	}
+	id, found := strings.CutPrefix(reference.Reference, "Location/"+roomPrefix)
+	return id, found && id != ""
+}
+
+// conceptText prefers the text of the concept, the display of the first coding is used otherwise
+func conceptText(concept *CodeableConcept) string {
+	if concept == nil {
+		return ""
+	}
+	if concept.Text != "" {
+		return concept.Text
+	}
+	for _, coding := range concept.Coding {
+		if coding.Display != "" {
+			return coding.Display
+		}
+		if coding.Code != "" {
+			return coding.Code
+		}
+	}
+	return ""
+}
+
+func notes(annotations []Annotation) string {
+	texts := make([]string, len(annotations))
+	for i, annotation := range annotations {
+		texts[i] = annotation.Text
+	}
+	return strings.Join(texts, "\n")
+}
+
+func fieldError(expression string, code string, message string) problem.FieldError {
+	return problem.FieldError{Field: expression, Code: code, Message: message}
+}
+
+// invalidResource reports the elements of the submitted resource which are not valid
+func invalidResource(errors ...problem.FieldError) *problem.Problem {
+	detail := fmt.Sprintf("Resource is not valid, %v element(s) failed validation.", len(errors))
+	return problem.New(http.StatusBadRequest, problem.CodeInvalidBody, detail).WithErrors(errors...)
+}
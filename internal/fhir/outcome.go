@@ -0,0 +1,69 @@
+package fhir
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/logging"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/problem"
+)
+
+// ContentType of the FHIR resources in JSON format
+const ContentType = "application/fhir+json"
+
+// respond writes the problem carried by the error as OperationOutcome and aborts the request,
+// server side failures are logged together with their cause
+func respond(ctx *gin.Context, err error) {
+	failure := problem.From(err)
+	if failure.Status >= http.StatusInternalServerError {
+		slog.ErrorContext(ctx, "Request failed", "code", failure.Code, "error", failure)
+	}
+
+	// the stable code of the problem is the same as the REST API reports
+	issue := Issue{
+		Severity: "error",
+		Code:     issueType(failure.Status),
+		Details:  &CodeableConcept{Coding: []Coding{{Code: failure.Code}}, Text: failure.Detail},
+	}
+	if requestId := logging.RequestID(ctx); requestId != "" {
+		issue.Diagnostics = "Request ID " + requestId
+	}
+	outcome := OperationOutcome{ResourceType: "OperationOutcome", Issue: []Issue{issue}}
+	for _, fieldError := range failure.Errors {
+		outcome.Issue = append(outcome.Issue, Issue{
+			Severity:   "error",
+			Code:       issueType(failure.Status),
+			Details:    &CodeableConcept{Text: fieldError.Message},
+			Expression: []string{fieldError.Field},
+		})
+	}
+
+	ctx.Header("Content-Type", ContentType)
+	ctx.AbortWithStatusJSON(failure.Status, outcome)
+}
+
+// issueType maps the HTTP status to the code of the issue, see https://hl7.org/fhir/R4/valueset-issue-type.html
+func issueType(status int) string {
+	switch status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return "invalid"
+	case http.StatusUnauthorized:
+		return "login"
+	case http.StatusForbidden:
+		return "forbidden"
+	case http.StatusNotFound:
+		return "not-found"
+	case http.StatusMethodNotAllowed:
+		return "not-supported"
+	case http.StatusConflict:
+		return "duplicate"
+	case http.StatusUnsupportedMediaType:
+		return "structure"
+	default:
+		if status >= http.StatusInternalServerError {
+			return "exception"
+		}
+		return "processing"
+	}
+}
@@ -0,0 +1,124 @@
+package fhir
+
+// the subset of FHIR R4 data types and resources the facade exchanges with the hospital information system,
+// see https://hl7.org/fhir/R4/resourcelist.html
+
+type Reference struct {
+	Reference string `json:"reference,omitempty"`
+	Display   string `json:"display,omitempty"`
+}
+
+type Coding struct {
+	System  string `json:"system,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Display string `json:"display,omitempty"`
+}
+
+type CodeableConcept struct {
+	Coding []Coding `json:"coding,omitempty"`
+	Text   string   `json:"text,omitempty"`
+}
+
+type Quantity struct {
+	Value int32 `json:"value"`
+}
+
+type Annotation struct {
+	Text string `json:"text"`
+}
+
+type Device struct {
+	ResourceType string           `json:"resourceType"`
+	Id           string           `json:"id,omitempty"`
+	Status       string           `json:"status,omitempty"`
+	DeviceName   []DeviceName     `json:"deviceName,omitempty"`
+	Type         *CodeableConcept `json:"type,omitempty"`
+	Property     []DeviceProperty `json:"property,omitempty"`
+	Location     *Reference       `json:"location,omitempty"`
+}
+
+type DeviceName struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type DeviceProperty struct {
+	Type          CodeableConcept `json:"type"`
+	ValueQuantity []Quantity      `json:"valueQuantity,omitempty"`
+}
+
+// DeviceRequest asks for the missing equipment
+type DeviceRequest struct {
+	ResourceType        string                   `json:"resourceType"`
+	Id                  string                   `json:"id,omitempty"`
+	Status              string                   `json:"status,omitempty"`
+	Intent              string                   `json:"intent,omitempty"`
+	CodeCodeableConcept *CodeableConcept         `json:"codeCodeableConcept,omitempty"`
+	Parameter           []DeviceRequestParameter `json:"parameter,omitempty"`
+	Subject             *Reference               `json:"subject,omitempty"`
+	Note                []Annotation             `json:"note,omitempty"`
+}
+
+type DeviceRequestParameter struct {
+	Code          *CodeableConcept `json:"code,omitempty"`
+	ValueQuantity *Quantity        `json:"valueQuantity,omitempty"`
+}
+
+// ServiceRequest asks for the repair of the equipment
+type ServiceRequest struct {
+	ResourceType      string           `json:"resourceType"`
+	Id                string           `json:"id,omitempty"`
+	Status            string           `json:"status,omitempty"`
+	Intent            string           `json:"intent,omitempty"`
+	Code              *CodeableConcept `json:"code,omitempty"`
+	Subject           *Reference       `json:"subject,omitempty"`
+	LocationReference []Reference      `json:"locationReference,omitempty"`
+	Note              []Annotation     `json:"note,omitempty"`
+}
+
+// Location is either the department (ward) or the room which is part of it
+type Location struct {
+	ResourceType string           `json:"resourceType"`
+	Id           string           `json:"id,omitempty"`
+	Status       string           `json:"status,omitempty"`
+	Name         string           `json:"name,omitempty"`
+	Mode         string           `json:"mode,omitempty"`
+	PhysicalType *CodeableConcept `json:"physicalType,omitempty"`
+	PartOf       *Reference       `json:"partOf,omitempty"`
+}
+
+type Bundle struct {
+	ResourceType string        `json:"resourceType"`
+	Type         string        `json:"type"`
+	Total        int           `json:"total"`
+	Link         []BundleLink  `json:"link,omitempty"`
+	Entry        []BundleEntry `json:"entry"`
+}
+
+type BundleLink struct {
+	Relation string `json:"relation"`
+	Url      string `json:"url"`
+}
+
+type BundleEntry struct {
+	FullUrl  string       `json:"fullUrl,omitempty"`
+	Resource interface{}  `json:"resource"`
+	Search   *EntrySearch `json:"search,omitempty"`
+}
+
+type EntrySearch struct {
+	Mode string `json:"mode"`
+}
+
+type OperationOutcome struct {
+	ResourceType string  `json:"resourceType"`
+	Issue        []Issue `json:"issue"`
+}
+
+type Issue struct {
+	Severity    string           `json:"severity"`
+	Code        string           `json:"code"`
+	Details     *CodeableConcept `json:"details,omitempty"`
+	Diagnostics string           `json:"diagnostics,omitempty"`
+	Expression  []string         `json:"expression,omitempty"`
+}
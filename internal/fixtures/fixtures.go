@@ -0,0 +1,143 @@
+package fixtures
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/fpjp"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed initial-data.yaml
+var initialData []byte
+
+// Fixture is a set of documents to be inserted into the database, the fields
+// of the documents are named the same way as in the API
+type Fixture struct {
+	Departments  []fpjp.Department  `json:"departments"`
+	Rooms        []fpjp.Room        `json:"rooms"`
+	Equipment    []fpjp.Equipment   `json:"equipment"`
+	Requests     []fpjp.Request     `json:"requests"`
+	Reservations []fpjp.Reservation `json:"reservations"`
+}
+
+// Services used to store the fixture documents
+type Services struct {
+	Departments  db_service.DbService[fpjp.Department]
+	Rooms        db_service.DbService[fpjp.Room]
+	Equipment    db_service.DbService[fpjp.Equipment]
+	Requests     db_service.DbService[fpjp.Request]
+	Reservations db_service.DbService[fpjp.Reservation]
+}
+
+// SeedResult counts documents per collection
+type SeedResult struct {
+	Inserted map[string]int
+	Skipped  map[string]int
+}
+
+// InitialData returns the sample departments and rooms used in development
+func InitialData() (*Fixture, error) {
+	return Parse(initialData, "yaml")
+}
+
+// Load reads fixture from YAML or JSON file, the format is chosen by the file extension
+func Load(path string) (*Fixture, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	if format == "yml" {
+		format = "yaml"
+	}
+	return Parse(content, format)
+}
+
+// Parse decodes fixture in yaml or json format
+func Parse(content []byte, format string) (*Fixture, error) {
+	switch format {
+	case "json":
+	case "yaml":
+		// documents are decoded by their json field names, so the same names are used in both formats
+		var document interface{}
+		if err := yaml.Unmarshal(content, &document); err != nil {
+			return nil, err
+		}
+		converted, err := json.Marshal(document)
+		if err != nil {
+			return nil, err
+		}
+		content = converted
+	default:
+		return nil, fmt.Errorf("unsupported fixture format %q, expected json or yaml", format)
+	}
+
+	fixture := &Fixture{}
+	decoder := json.NewDecoder(strings.NewReader(string(content)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(fixture); err != nil {
+		return nil, fmt.Errorf("invalid fixture: %w", err)
+	}
+	return fixture, nil
+}
+
+// Seed inserts the fixture documents, documents with already existing id are skipped
+func Seed(ctx context.Context, services Services, fixture *Fixture) (*SeedResult, error) {
+	result := &SeedResult{Inserted: map[string]int{}, Skipped: map[string]int{}}
+
+	if err := insertAll(ctx, result, "departments", services.Departments, fixture.Departments, func(d *fpjp.Department) string { return d.Id }); err != nil {
+		return result, err
+	}
+	if err := insertAll(ctx, result, "rooms", services.Rooms, fixture.Rooms, func(d *fpjp.Room) string { return d.Id }); err != nil {
+		return result, err
+	}
+	if err := insertAll(ctx, result, "equipment", services.Equipment, fixture.Equipment, func(d *fpjp.Equipment) string { return d.Id }); err != nil {
+		return result, err
+	}
+	if err := insertAll(ctx, result, "requests", services.Requests, fixture.Requests, func(d *fpjp.Request) string { return d.Id }); err != nil {
+		return result, err
+	}
+	if err := insertAll(ctx, result, "reservations", services.Reservations, fixture.Reservations, func(d *fpjp.Reservation) string { return d.Id }); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func insertAll[DocType interface{}](
+	ctx context.Context,
+	result *SeedResult,
+	collection string,
+	service db_service.DbService[DocType],
+	documents []DocType,
+	id func(*DocType) string,
+) error {
+	if len(documents) == 0 {
+		return nil
+	}
+	if service == nil {
+		return fmt.Errorf("no service configured for %v", collection)
+	}
+	for i := range documents {
+		document := &documents[i]
+		if id(document) == "" {
+			return fmt.Errorf("%v[%d] has no id", collection, i)
+		}
+		err := service.CreateDocument(ctx, id(document), document)
+		switch err {
+		case nil:
+			result.Inserted[collection]++
+		case db_service.ErrConflict:
+			result.Skipped[collection]++
+		default:
+			return fmt.Errorf("failed to insert %v %v: %w", collection, id(document), err)
+		}
+	}
+	return nil
+}
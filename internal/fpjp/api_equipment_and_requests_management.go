@@ -21,27 +21,66 @@ type EquipmentAndRequestsManagementAPI interface {
 	// internal registration of api routes
 	addRoutes(routerGroup *gin.RouterGroup)
 
+	// AddEquipmentAdjustment - Atomically changes count of specific equipment
+	AddEquipmentAdjustment(ctx *gin.Context)
+
 	// AddRoomEquipment - Adds new equipment to a room
 	AddRoomEquipment(ctx *gin.Context)
 
 	// AddRoomRequest - Adds new request to a room
 	AddRoomRequest(ctx *gin.Context)
 
+	// CancelReservation - Cancels specific reservation
+	CancelReservation(ctx *gin.Context)
+
+	// CreateEquipmentReservation - Reserves specific equipment for a time range
+	CreateEquipmentReservation(ctx *gin.Context)
+
 	// DeleteEquipment - Deletes specific equipment
 	DeleteEquipment(ctx *gin.Context)
 
 	// DeleteRequest - Deletes specific request
 	DeleteRequest(ctx *gin.Context)
 
+	// ExecuteBatch - Executes multiple operations on equipment and requests
+	ExecuteBatch(ctx *gin.Context)
+
 	// GetDepartmentEquipment - Provides list of all equipment in a department
 	GetDepartmentEquipment(ctx *gin.Context)
 
 	// GetDepartmentRequests - Provides list of all requests in a department
 	GetDepartmentRequests(ctx *gin.Context)
 
+	// GetDepartmentReservations - Provides availability calendar of equipment in a department
+	GetDepartmentReservations(ctx *gin.Context)
+
 	// GetDepartments - Provides list of all departments
 	GetDepartments(ctx *gin.Context)
 
+	// GetEquipmentAdjustments - Provides stock movement ledger of specific equipment
+	GetEquipmentAdjustments(ctx *gin.Context)
+
+	// GetEquipmentReservations - Provides reservations of specific equipment
+	GetEquipmentReservations(ctx *gin.Context)
+
+	// GetEquipmentVersions - Provides history of changes of specific equipment
+	GetEquipmentVersions(ctx *gin.Context)
+
+	// GetRequestVersions - Provides history of changes of specific request
+	GetRequestVersions(ctx *gin.Context)
+
+	// PatchEquipment - Partially updates specific equipment
+	PatchEquipment(ctx *gin.Context)
+
+	// PatchRequest - Partially updates specific request
+	PatchRequest(ctx *gin.Context)
+
+	// RestoreEquipment - Restores previously deleted equipment
+	RestoreEquipment(ctx *gin.Context)
+
+	// RestoreRequest - Restores previously deleted request
+	RestoreRequest(ctx *gin.Context)
+
 	// UpdateEquipment - Updates specific equipment
 	UpdateEquipment(ctx *gin.Context)
 
@@ -58,18 +97,36 @@ func newEquipmentAndRequestsManagementAPI() EquipmentAndRequestsManagementAPI {
 }
 
 func (this *implEquipmentAndRequestsManagementAPI) addRoutes(routerGroup *gin.RouterGroup) {
+	routerGroup.Handle(http.MethodPost, "/equipment/:equipmentId/adjustments", this.AddEquipmentAdjustment)
 	routerGroup.Handle(http.MethodPost, "/rooms/:roomId/equipment", this.AddRoomEquipment)
 	routerGroup.Handle(http.MethodPost, "/rooms/:roomId/requests", this.AddRoomRequest)
+	routerGroup.Handle(http.MethodDelete, "/reservations/:reservationId", this.CancelReservation)
+	routerGroup.Handle(http.MethodPost, "/equipment/:equipmentId/reservations", this.CreateEquipmentReservation)
 	routerGroup.Handle(http.MethodDelete, "/equipment/:equipmentId", this.DeleteEquipment)
 	routerGroup.Handle(http.MethodDelete, "/requests/:requestId", this.DeleteRequest)
+	routerGroup.Handle(http.MethodPost, "/batch", this.ExecuteBatch)
 	routerGroup.Handle(http.MethodGet, "/departments/:departmentId/equipment", this.GetDepartmentEquipment)
 	routerGroup.Handle(http.MethodGet, "/departments/:departmentId/requests", this.GetDepartmentRequests)
+	routerGroup.Handle(http.MethodGet, "/departments/:departmentId/reservations", this.GetDepartmentReservations)
 	routerGroup.Handle(http.MethodGet, "/departments/", this.GetDepartments)
+	routerGroup.Handle(http.MethodGet, "/equipment/:equipmentId/adjustments", this.GetEquipmentAdjustments)
+	routerGroup.Handle(http.MethodGet, "/equipment/:equipmentId/reservations", this.GetEquipmentReservations)
+	routerGroup.Handle(http.MethodGet, "/equipment/:equipmentId/versions", this.GetEquipmentVersions)
+	routerGroup.Handle(http.MethodGet, "/requests/:requestId/versions", this.GetRequestVersions)
+	routerGroup.Handle(http.MethodPatch, "/equipment/:equipmentId", this.PatchEquipment)
+	routerGroup.Handle(http.MethodPatch, "/requests/:requestId", this.PatchRequest)
+	routerGroup.Handle(http.MethodPost, "/equipment/:equipmentId/restore", this.RestoreEquipment)
+	routerGroup.Handle(http.MethodPost, "/requests/:requestId/restore", this.RestoreRequest)
 	routerGroup.Handle(http.MethodPut, "/equipment/:equipmentId", this.UpdateEquipment)
 	routerGroup.Handle(http.MethodPut, "/requests/:requestId", this.UpdateRequest)
 }
 
 // Copy following section to separate file, uncomment, and implement accordingly
+// // AddEquipmentAdjustment - Atomically changes count of specific equipment
+// func (this *implEquipmentAndRequestsManagementAPI) AddEquipmentAdjustment(ctx *gin.Context) {
+//  	ctx.AbortWithStatus(http.StatusNotImplemented)
+// }
+//
 // // AddRoomEquipment - Adds new equipment to a room
 // func (this *implEquipmentAndRequestsManagementAPI) AddRoomEquipment(ctx *gin.Context) {
 //  	ctx.AbortWithStatus(http.StatusNotImplemented)
@@ -80,6 +137,16 @@ func (this *implEquipmentAndRequestsManagementAPI) addRoutes(routerGroup *gin.Ro
 //  	ctx.AbortWithStatus(http.StatusNotImplemented)
 // }
 //
+// // CancelReservation - Cancels specific reservation
+// func (this *implEquipmentAndRequestsManagementAPI) CancelReservation(ctx *gin.Context) {
+//  	ctx.AbortWithStatus(http.StatusNotImplemented)
+// }
+//
+// // CreateEquipmentReservation - Reserves specific equipment for a time range
+// func (this *implEquipmentAndRequestsManagementAPI) CreateEquipmentReservation(ctx *gin.Context) {
+//  	ctx.AbortWithStatus(http.StatusNotImplemented)
+// }
+//
 // // DeleteEquipment - Deletes specific equipment
 // func (this *implEquipmentAndRequestsManagementAPI) DeleteEquipment(ctx *gin.Context) {
 //  	ctx.AbortWithStatus(http.StatusNotImplemented)
@@ -90,6 +157,11 @@ func (this *implEquipmentAndRequestsManagementAPI) addRoutes(routerGroup *gin.Ro
 //  	ctx.AbortWithStatus(http.StatusNotImplemented)
 // }
 //
+// // ExecuteBatch - Executes multiple operations on equipment and requests
+// func (this *implEquipmentAndRequestsManagementAPI) ExecuteBatch(ctx *gin.Context) {
+//  	ctx.AbortWithStatus(http.StatusNotImplemented)
+// }
+//
 // // GetDepartmentEquipment - Provides list of all equipment in a department
 // func (this *implEquipmentAndRequestsManagementAPI) GetDepartmentEquipment(ctx *gin.Context) {
 //  	ctx.AbortWithStatus(http.StatusNotImplemented)
@@ -100,11 +172,56 @@ func (this *implEquipmentAndRequestsManagementAPI) addRoutes(routerGroup *gin.Ro
 //  	ctx.AbortWithStatus(http.StatusNotImplemented)
 // }
 //
+// // GetDepartmentReservations - Provides availability calendar of equipment in a department
+// func (this *implEquipmentAndRequestsManagementAPI) GetDepartmentReservations(ctx *gin.Context) {
+//  	ctx.AbortWithStatus(http.StatusNotImplemented)
+// }
+//
 // // GetDepartments - Provides list of all departments
 // func (this *implEquipmentAndRequestsManagementAPI) GetDepartments(ctx *gin.Context) {
 //  	ctx.AbortWithStatus(http.StatusNotImplemented)
 // }
 //
+// // GetEquipmentAdjustments - Provides stock movement ledger of specific equipment
+// func (this *implEquipmentAndRequestsManagementAPI) GetEquipmentAdjustments(ctx *gin.Context) {
+//  	ctx.AbortWithStatus(http.StatusNotImplemented)
+// }
+//
+// // GetEquipmentReservations - Provides reservations of specific equipment
+// func (this *implEquipmentAndRequestsManagementAPI) GetEquipmentReservations(ctx *gin.Context) {
+//  	ctx.AbortWithStatus(http.StatusNotImplemented)
+// }
+//
+// // GetEquipmentVersions - Provides history of changes of specific equipment
+// func (this *implEquipmentAndRequestsManagementAPI) GetEquipmentVersions(ctx *gin.Context) {
+//  	ctx.AbortWithStatus(http.StatusNotImplemented)
+// }
+//
+// // GetRequestVersions - Provides history of changes of specific request
+// func (this *implEquipmentAndRequestsManagementAPI) GetRequestVersions(ctx *gin.Context) {
+//  	ctx.AbortWithStatus(http.StatusNotImplemented)
+// }
+//
+// // PatchEquipment - Partially updates specific equipment
+// func (this *implEquipmentAndRequestsManagementAPI) PatchEquipment(ctx *gin.Context) {
+//  	ctx.AbortWithStatus(http.StatusNotImplemented)
+// }
+//
+// // PatchRequest - Partially updates specific request
+// func (this *implEquipmentAndRequestsManagementAPI) PatchRequest(ctx *gin.Context) {
+//  	ctx.AbortWithStatus(http.StatusNotImplemented)
+// }
+//
+// // RestoreEquipment - Restores previously deleted equipment
+// func (this *implEquipmentAndRequestsManagementAPI) RestoreEquipment(ctx *gin.Context) {
+//  	ctx.AbortWithStatus(http.StatusNotImplemented)
+// }
+//
+// // RestoreRequest - Restores previously deleted request
+// func (this *implEquipmentAndRequestsManagementAPI) RestoreRequest(ctx *gin.Context) {
+//  	ctx.AbortWithStatus(http.StatusNotImplemented)
+// }
+//
 // // UpdateEquipment - Updates specific equipment
 // func (this *implEquipmentAndRequestsManagementAPI) UpdateEquipment(ctx *gin.Context) {
 //  	ctx.AbortWithStatus(http.StatusNotImplemented)
@@ -0,0 +1,76 @@
+package fpjp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// batchBody creates equipment eq-a, updates missing equipment eq-9 and creates request req-a
+const batchBody = `{"mode": %q, "operations": [
+	{"action": "create", "resource": "equipment", "equipment": {"id": "eq-a", "room": "room-1", "type": "monitor", "name": "Monitor", "count": 1}},
+	{"action": "update", "resource": "equipment", "id": "eq-9", "equipment": {"id": "eq-9", "room": "room-1", "type": "monitor", "name": "Monitor", "count": 1}},
+	{"action": "create", "resource": "request", "request": {"id": "req-a", "room": "room-1", "type": "repair", "name": "Monitor", "description": "Broken"}}
+]}`
+
+func TestBatchModes(t *testing.T) {
+	tests := map[string]struct {
+		statuses []int32
+		stored   bool
+	}{
+		batchTransactional: {[]int32{http.StatusFailedDependency, http.StatusNotFound, http.StatusFailedDependency}, false},
+		batchBestEffort:    {[]int32{http.StatusCreated, http.StatusNotFound, http.StatusCreated}, true},
+	}
+	for mode, test := range tests {
+		t.Run(mode, func(t *testing.T) {
+			server := newTestServer(t)
+
+			response := server.serve(http.MethodPost, "/api/batch", "application/json", fmt.Sprintf(batchBody, mode))
+
+			batch := decode[BatchResponse](t, response, http.StatusMultiStatus)
+			if batch.Mode != mode || len(batch.Results) != len(test.statuses) {
+				t.Fatalf("unexpected response %+v", batch)
+			}
+			for i, status := range test.statuses {
+				if batch.Results[i].Index != int32(i) || batch.Results[i].Status != status {
+					t.Errorf("expected operation %v to end with %v, got %+v", i, status, batch.Results[i])
+				}
+			}
+
+			_, equipmentErr := server.equipment.FindDocument(context.Background(), "eq-a")
+			_, requestErr := server.requests.FindDocument(context.Background(), "req-a")
+			if stored := equipmentErr == nil && requestErr == nil; stored != test.stored {
+				t.Errorf("expected stored %v, got errors %v and %v", test.stored, equipmentErr, requestErr)
+			}
+		})
+	}
+}
+
+func TestTransactionalBatchWithoutTransactions(t *testing.T) {
+	server := newTestServer(t)
+	server.inventory.config.Transactions = nil
+
+	response := server.serve(http.MethodPost, "/api/batch", "application/json", fmt.Sprintf(batchBody, batchTransactional))
+
+	if code := problemCode(t, response, http.StatusNotImplemented); code != codeTransactionsNotSupported {
+		t.Errorf("unexpected problem %v", code)
+	}
+	if _, err := server.equipment.FindDocument(context.Background(), "eq-a"); err == nil {
+		t.Error("no operation should be applied")
+	}
+}
+
+func TestSuccessfulTransactionalBatch(t *testing.T) {
+	server := newTestServer(t)
+
+	response := server.serve(http.MethodPost, "/api/batch", "application/json", `{"operations": [
+		{"action": "create", "resource": "equipment", "equipment": {"id": "eq-a", "room": "room-1", "type": "monitor", "name": "Monitor", "count": 1}},
+		{"action": "delete", "resource": "equipment", "id": "eq-a"}
+	]}`)
+
+	batch := decode[BatchResponse](t, response, http.StatusOK)
+	if batch.Mode != batchTransactional || batch.Results[0].Status != http.StatusCreated || batch.Results[1].Status != http.StatusNoContent {
+		t.Errorf("unexpected response %+v", batch)
+	}
+}
@@ -0,0 +1,77 @@
+package fpjp
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/problem"
+)
+
+// codes of the domain specific problems
+const (
+	codeInsufficientStock        = "insufficient-stock"
+	codeNotAvailable             = "equipment-not-available"
+	codeNotVersioned             = "not-versioned"
+	codeReasonMismatch           = "reason-mismatch"
+	codeTransactionsNotSupported = "transactions-not-supported"
+	codeNotApplied               = "not-applied"
+)
+
+// lookupService returns the database service which main registers in the context
+func lookupService[DocType interface{}](ctx *gin.Context, name string) (db_service.DbService[DocType], error) {
+	value, exists := ctx.Get(name)
+	if !exists {
+		return nil, problem.Internal(fmt.Errorf("%v not found", name))
+	}
+	db, ok := value.(db_service.DbService[DocType])
+	if !ok {
+		return nil, problem.Internal(fmt.Errorf("cannot cast %v context to db_service.DbService", name))
+	}
+	return db, nil
+}
+
+// LookupInventory returns the service layer which main registers in the context,
+// the handlers of all versions of the API share it
+func LookupInventory(ctx *gin.Context) (*Inventory, error) {
+	value, exists := ctx.Get("inventory")
+	if !exists {
+		return nil, problem.Internal(fmt.Errorf("inventory not found"))
+	}
+	inventory, ok := value.(*Inventory)
+	if !ok {
+		return nil, problem.Internal(fmt.Errorf("cannot cast inventory context to *Inventory"))
+	}
+	return inventory, nil
+}
+
+// dbProblem translates error of the database service related to the resource
+// (equipment, request, room, ...) into the problem reported to the client
+func dbProblem(err error, resource string) *problem.Problem {
+	noun := strings.ToUpper(resource[:1]) + resource[1:]
+	switch {
+	case errors.Is(err, db_service.ErrNotFound):
+		return problem.NotFound(resource, fmt.Sprintf("%v with provided ID was not found.", noun))
+	case errors.Is(err, db_service.ErrConflict):
+		return problem.New(http.StatusConflict, problem.CodeAlreadyExists, fmt.Sprintf("%v with provided ID already exists.", noun))
+	case errors.Is(err, db_service.ErrNotVersioned):
+		return problem.New(http.StatusNotFound, codeNotVersioned, fmt.Sprintf("History of %v changes is not recorded.", resource))
+	default:
+		return problem.Database(err)
+	}
+}
+
+// idMismatch reports identifier in the body different from the one in the path
+func idMismatch(field string, parameter string) *problem.Problem {
+	detail := fmt.Sprintf("Field %v of the request body is not equal to %v parameter of the URL.", field, parameter)
+	return problem.New(http.StatusBadRequest, problem.CodeIdMismatch, detail).
+		WithErrors(problem.FieldError{Field: field, Code: "mismatch", Message: "must be equal to " + parameter})
+}
+
+// adminOnly reports that the operation requires admin role
+func adminOnly(detail string) *problem.Problem {
+	return problem.New(http.StatusForbidden, problem.CodeForbidden, detail)
+}
@@ -0,0 +1,43 @@
+package fpjp
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/problem"
+)
+
+func TestDbProblem(t *testing.T) {
+	tests := map[string]struct {
+		err    error
+		status int
+		code   string
+	}{
+		"not found":     {db_service.ErrNotFound, http.StatusNotFound, "equipment-not-found"},
+		"conflict":      {fmt.Errorf("insert: %w", db_service.ErrConflict), http.StatusConflict, problem.CodeAlreadyExists},
+		"not versioned": {db_service.ErrNotVersioned, http.StatusNotFound, codeNotVersioned},
+		"other":         {fmt.Errorf("connection refused"), http.StatusBadGateway, problem.CodeDatabaseError},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			failure := dbProblem(test.err, "equipment")
+			if failure.Status != test.status || failure.Code != test.code {
+				t.Errorf("expected %v %v, got %v %v", test.status, test.code, failure.Status, failure.Code)
+			}
+		})
+	}
+}
+
+func TestCreateWithExistingIdIsConflict(t *testing.T) {
+	server := newTestServer(t)
+	server.addEquipment(t, Equipment{Id: "eq-1", Room: "room-1", Type: "monitor", Name: "Monitor", Count: 2})
+
+	response := server.serve(http.MethodPost, "/api/rooms/room-1/equipment", "application/json",
+		`{"id": "eq-1", "room": "room-1", "type": "monitor", "name": "Second monitor", "count": 1}`)
+
+	if code := problemCode(t, response, http.StatusConflict); code != problem.CodeAlreadyExists {
+		t.Errorf("unexpected problem %v", code)
+	}
+}
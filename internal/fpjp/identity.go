@@ -0,0 +1,91 @@
+package fpjp
+
+import (
+	"log/slog"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// identity of the caller is provided by the authenticating proxy in front of the service,
+// the headers are accepted only from the proxies listed in AMBULANCE_API_TRUSTED_PROXIES,
+// without the list the service must be reachable only through the proxy, otherwise any
+// client can claim to be an admin
+const (
+	userHeader   = "X-Forwarded-User"
+	groupsHeader = "X-Forwarded-Groups"
+)
+
+// Caller is the identity of the user performing the operation
+type Caller struct {
+	User string
+	// member of the admin group
+	Admin bool
+}
+
+// NewCaller identifies the user by the name and comma separated groups provided by the proxy
+func NewCaller(user string, groups string) Caller {
+	if user == "" {
+		user = "anonymous"
+	}
+
+	adminGroup := os.Getenv("AMBULANCE_API_ADMIN_GROUP")
+	if adminGroup == "" {
+		adminGroup = "admin"
+	}
+
+	caller := Caller{User: user}
+	for _, group := range strings.Split(groups, ",") {
+		if strings.TrimSpace(group) == adminGroup {
+			caller.Admin = true
+		}
+	}
+	return caller
+}
+
+// TrustedProxy reports whether the identity provided by the peer with the given address
+// can be trusted, the address is the direct peer and never the one forwarded by headers
+func TrustedProxy(address string) bool {
+	proxies := os.Getenv("AMBULANCE_API_TRUSTED_PROXIES")
+	if strings.TrimSpace(proxies) == "" {
+		return true
+	}
+
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		address = host
+	}
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+	for _, proxy := range strings.Split(proxies, ",") {
+		proxy = strings.TrimSpace(proxy)
+		if !strings.Contains(proxy, "/") {
+			if trusted := net.ParseIP(proxy); trusted != nil && trusted.Equal(ip) {
+				return true
+			}
+			continue
+		}
+		if _, network, err := net.ParseCIDR(proxy); err == nil && network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// RequestCaller identifies the user performing the HTTP request, requests which did not come
+// through a trusted proxy are anonymous
+func RequestCaller(ctx *gin.Context) Caller {
+	if !TrustedProxy(ctx.RemoteIP()) {
+		slog.DebugContext(ctx, "Ignoring identity of untrusted peer", "peer", ctx.RemoteIP())
+		return NewCaller("", "")
+	}
+	return NewCaller(ctx.GetHeader(userHeader), ctx.GetHeader(groupsHeader))
+}
+
+// requestUser returns identity of the user performing the request
+func requestUser(ctx *gin.Context) string {
+	return RequestCaller(ctx).User
+}
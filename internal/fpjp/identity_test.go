@@ -0,0 +1,53 @@
+package fpjp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestTrustedProxy(t *testing.T) {
+	tests := map[string]struct {
+		proxies string
+		address string
+		trusted bool
+	}{
+		"not configured":  {"", "203.0.113.7:41000", true},
+		"listed address":  {"10.0.0.5, 10.0.0.6", "10.0.0.6:41000", true},
+		"listed network":  {"10.1.0.0/16", "10.1.20.3:41000", true},
+		"address only":    {"10.1.0.0/16", "10.1.20.3", true},
+		"other address":   {"10.0.0.5", "10.0.0.7:41000", false},
+		"other network":   {"10.1.0.0/16", "10.2.0.1:41000", false},
+		"not ip address":  {"10.1.0.0/16", "bufconn", false},
+		"invalid network": {"10.1.0.0/33", "10.1.0.1:41000", false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("AMBULANCE_API_TRUSTED_PROXIES", test.proxies)
+			if trusted := TrustedProxy(test.address); trusted != test.trusted {
+				t.Errorf("expected trusted %v, got %v", test.trusted, trusted)
+			}
+		})
+	}
+}
+
+func TestRequestCallerIgnoresUntrustedPeer(t *testing.T) {
+	t.Setenv("AMBULANCE_API_TRUSTED_PROXIES", "10.0.0.5")
+	caller := func(remoteAddr string) Caller {
+		ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+		ctx.Request = httptest.NewRequest(http.MethodGet, "/api/departments", nil)
+		ctx.Request.RemoteAddr = remoteAddr
+		ctx.Request.Header.Set(userHeader, "nurse")
+		ctx.Request.Header.Set(groupsHeader, "staff, admin")
+		return RequestCaller(ctx)
+	}
+
+	if trusted := caller("10.0.0.5:41000"); trusted.User != "nurse" || !trusted.Admin {
+		t.Errorf("identity from trusted proxy should be accepted, got %+v", trusted)
+	}
+	if untrusted := caller("203.0.113.7:41000"); untrusted.User != "anonymous" || untrusted.Admin {
+		t.Errorf("identity from untrusted peer should be ignored, got %+v", untrusted)
+	}
+}
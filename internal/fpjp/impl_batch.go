@@ -0,0 +1,200 @@
+package fpjp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/logging"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/problem"
+)
+
+const (
+	batchTransactional = "transactional"
+	batchBestEffort    = "best-effort"
+)
+
+// ExecuteBatch - Executes multiple operations on equipment and requests
+func (this *implEquipmentAndRequestsManagementAPI) ExecuteBatch(ctx *gin.Context) {
+	slog.DebugContext(ctx, "Handling request", "operation", "ExecuteBatch")
+
+	inventory, err := LookupInventory(ctx)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+
+	batch := BatchRequest{}
+	err = ctx.ShouldBindJSON(&batch)
+	if err != nil {
+		problem.Respond(ctx, problem.InvalidBody(err))
+		return
+	}
+	if batch.Mode == "" {
+		batch.Mode = batchTransactional
+	}
+
+	caller := RequestCaller(ctx)
+	response := BatchResponse{Mode: batch.Mode}
+
+	if batch.Mode == batchBestEffort {
+		// each operation is applied independently of the others
+		for i := range batch.Operations {
+			response.Results = append(response.Results, executeOperation(ctx, ctx, inventory, caller, i, &batch.Operations[i]))
+		}
+	} else {
+		failed := -1
+		err = inventory.InTransaction(ctx, func(txCtx context.Context) error {
+			// the transaction may be retried, so the results of the previous attempt are discarded
+			response.Results = response.Results[:0]
+			failed = -1
+			for i := range batch.Operations {
+				result := executeOperation(ctx, txCtx, inventory, caller, i, &batch.Operations[i])
+				response.Results = append(response.Results, result)
+				if result.Status >= http.StatusBadRequest {
+					failed = i
+					return result.Body.(*problem.Problem)
+				}
+			}
+			return nil
+		})
+
+		if err != nil && failed < 0 {
+			// the operations succeeded but the transaction could not be committed
+			problem.Respond(ctx, err)
+			return
+		}
+		if failed >= 0 {
+			// nothing was stored, the failed operation keeps its problem
+			failure := response.Results[failed]
+			response.Results = make([]BatchResult, len(batch.Operations))
+			for i := range batch.Operations {
+				if i == failed {
+					response.Results[i] = failure
+					continue
+				}
+				response.Results[i] = notApplied(ctx, i, batch.Operations[i].Id, failed)
+			}
+		}
+	}
+
+	status := http.StatusOK
+	for _, result := range response.Results {
+		if result.Status >= http.StatusBadRequest {
+			status = http.StatusMultiStatus
+		}
+	}
+	ctx.JSON(status, response)
+}
+
+// executeOperation applies single operation of the batch, the ctx of the HTTP request
+// is used for logging and opCtx for the changes so they can join the transaction
+func executeOperation(ctx *gin.Context, opCtx context.Context, inventory *Inventory, caller Caller, index int, operation *BatchOperation) BatchResult {
+	result := BatchResult{Index: int32(index)}
+
+	var status int
+	var body interface{}
+	var err error
+	switch operation.Resource {
+	case "equipment":
+		status, body, err = executeEquipmentOperation(opCtx, inventory, caller, index, operation)
+		if equipment, ok := body.(*Equipment); ok {
+			result.Id = equipment.Id
+		}
+	case "request":
+		status, body, err = executeRequestOperation(opCtx, inventory, caller, index, operation)
+		if request, ok := body.(*Request); ok {
+			result.Id = request.Id
+		}
+	}
+	if result.Id == "" {
+		result.Id = operation.Id
+	}
+
+	if err != nil {
+		return operationFailure(ctx, result, err)
+	}
+	result.Status = int32(status)
+	result.Body = body
+	return result
+}
+
+func executeEquipmentOperation(ctx context.Context, inventory *Inventory, caller Caller, index int, operation *BatchOperation) (int, interface{}, error) {
+	if operation.Action == "delete" {
+		if operation.Id == "" {
+			return 0, nil, missingOperationField(index, "id")
+		}
+		return http.StatusNoContent, nil, inventory.DeleteEquipment(ctx, caller, operation.Id)
+	}
+
+	equipment := operation.Equipment
+	if equipment == nil {
+		return 0, nil, missingOperationField(index, "equipment")
+	}
+
+	if operation.Action == "create" {
+		// new UUID is assigned when the ID is empty
+		return http.StatusCreated, equipment, inventory.CreateEquipment(ctx, equipment)
+	}
+
+	// the update may identify the equipment by the body only
+	if operation.Id != "" && operation.Id != equipment.Id {
+		return 0, nil, idMismatch(fmt.Sprintf("operations[%v].equipment.id", index), fmt.Sprintf("operations[%v].id", index))
+	}
+	return http.StatusOK, equipment, inventory.UpdateEquipment(ctx, equipment)
+}
+
+func executeRequestOperation(ctx context.Context, inventory *Inventory, caller Caller, index int, operation *BatchOperation) (int, interface{}, error) {
+	if operation.Action == "delete" {
+		if operation.Id == "" {
+			return 0, nil, missingOperationField(index, "id")
+		}
+		return http.StatusNoContent, nil, inventory.DeleteRequest(ctx, caller, operation.Id)
+	}
+
+	request := operation.Request
+	if request == nil {
+		return 0, nil, missingOperationField(index, "request")
+	}
+
+	if operation.Action == "create" {
+		// new UUID is assigned when the ID is empty
+		return http.StatusCreated, request, inventory.CreateRequest(ctx, request)
+	}
+
+	// the update may identify the request by the body only
+	if operation.Id != "" && operation.Id != request.Id {
+		return 0, nil, idMismatch(fmt.Sprintf("operations[%v].request.id", index), fmt.Sprintf("operations[%v].id", index))
+	}
+	return http.StatusOK, request, inventory.UpdateRequest(ctx, request)
+}
+
+// operationFailure reports the problem of the operation in its result,
+// server side failures are logged the same way as failed requests
+func operationFailure(ctx *gin.Context, result BatchResult, err error) BatchResult {
+	failure := *problem.From(err)
+	if failure.Status >= http.StatusInternalServerError {
+		slog.ErrorContext(ctx, "Batch operation failed", "index", result.Index, "code", failure.Code, "error", err)
+	}
+	failure.Instance = ctx.Request.URL.Path
+	failure.RequestId = logging.RequestID(ctx)
+
+	result.Status = int32(failure.Status)
+	result.Body = &failure
+	return result
+}
+
+// notApplied reports operation rolled back or skipped due to failure of other operation in transactional batch
+func notApplied(ctx *gin.Context, index int, id string, failed int) BatchResult {
+	detail := fmt.Sprintf("Operation was not applied because operation %v of the transactional batch failed.", failed)
+	return operationFailure(ctx, BatchResult{Index: int32(index), Id: id},
+		problem.New(http.StatusFailedDependency, codeNotApplied, detail).With("failedOperation", failed))
+}
+
+func missingOperationField(index int, field string) *problem.Problem {
+	path := fmt.Sprintf("operations[%v].%v", index, field)
+	return problem.New(http.StatusBadRequest, problem.CodeInvalidBody, fmt.Sprintf("Field %v is required by the operation.", path)).
+		WithErrors(problem.FieldError{Field: path, Code: "required", Message: "is required"})
+}
@@ -1,54 +1,35 @@
 package fpjp
 
 import (
+	"log/slog"
 	"net/http"
-
-	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
-	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service"
-	"go.mongodb.org/mongo-driver/bson"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/problem"
 )
 
+// NewEquipmentAndRequestsManagementAPI provides the handlers of version 1 of the API,
+// later versions reuse them for the operations they did not redesign
+func NewEquipmentAndRequestsManagementAPI() EquipmentAndRequestsManagementAPI {
+	return newEquipmentAndRequestsManagementAPI()
+}
+
 // AddRoomEquipment - Adds new equipment to a room
 func (this *implEquipmentAndRequestsManagementAPI) AddRoomEquipment(ctx *gin.Context) {
-	fmt.Println("req -> AddRoomEquipment")
-
-	value, exists := ctx.Get("equipment_service")
-	if !exists {
-		ctx.JSON(
-			http.StatusInternalServerError,
-			gin.H{
-				"status":  "Internal Server Error",
-				"message": "equipment_service not found",
-				"error":   "equipment_service not found",
-			})
-		return
-	}
+	slog.DebugContext(ctx, "Handling request", "operation", "AddRoomEquipment")
 
-	db, ok := value.(db_service.DbService[Equipment])
-	if !ok {
-		ctx.JSON(
-			http.StatusInternalServerError,
-			gin.H{
-				"status":  "Internal Server Error",
-				"message": "equipment_service context is not of required type",
-				"error":   "cannot cast equipment_service context to db_service.DbService",
-			})
+	inventory, err := LookupInventory(ctx)
+	if err != nil {
+		problem.Respond(ctx, err)
 		return
 	}
 
 	equipment := Equipment{}
-	err := ctx.ShouldBindJSON(&equipment)
+	err = ctx.ShouldBindJSON(&equipment)
 	if err != nil {
-		ctx.JSON(
-			http.StatusBadRequest,
-			gin.H{
-				"status":  "Bad Request",
-				"message": "Invalid request body",
-				"error":   err.Error(),
-			})
+		problem.Respond(ctx, problem.InvalidBody(err))
 		return
 	}
 
@@ -57,143 +38,60 @@ func (this *implEquipmentAndRequestsManagementAPI) AddRoomEquipment(ctx *gin.Con
 
 	// check if room ID from URL param and room ID from request body are equal
 	if URLroomId != equipment.Room {
-		ctx.JSON(
-			http.StatusBadRequest,
-			gin.H{
-				"status":  "Bad Request",
-				"message": "Room ID provided in request body is not equal to room ID in URL parameter.",
-				"error":   "Room ID provided in request body is not equal to room ID in URL parameter.",
-			})
+		problem.Respond(ctx, idMismatch("room", "roomId"))
 		return
 	}
 
-	// create new UUID
-	if equipment.Id == "" {
-		equipment.Id = uuid.New().String()
-	}
-
-	// create equipment
-	err = db.CreateDocument(ctx, equipment.Id, &equipment)
-
-	switch err {
-	case nil:
-		ctx.JSON(
-			http.StatusCreated,
-			equipment,
-		)
-	case db_service.ErrConflict:
-		ctx.JSON(
-			http.StatusConflict,
-			gin.H{
-				"status":  "Conflict",
-				"message": "Equipment already exists",
-				"error":   err.Error(),
-			})
-	default:
-		ctx.JSON(
-			http.StatusBadGateway,
-			gin.H{
-				"status":  "Bad Gateway",
-				"message": "Failed to create equipment in database",
-				"error":   err.Error(),
-			})
+	// create equipment, new UUID is assigned when the ID is empty
+	err = inventory.CreateEquipment(ctx, &equipment)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
 	}
+
+	ctx.JSON(
+		http.StatusCreated,
+		equipment,
+	)
 }
 
 // DeleteEquipment - Deletes specific equipment
 func (this *implEquipmentAndRequestsManagementAPI) DeleteEquipment(ctx *gin.Context) {
-	fmt.Println("req -> DeleteEquipment")
-
-	value, exists := ctx.Get("equipment_service")
-	if !exists {
-		ctx.JSON(
-			http.StatusInternalServerError,
-			gin.H{
-				"status":  "Internal Server Error",
-				"message": "equipment_service not found",
-				"error":   "equipment_service not found",
-			})
-		return
-	}
+	slog.DebugContext(ctx, "Handling request", "operation", "DeleteEquipment")
 
-	db, ok := value.(db_service.DbService[Equipment])
-	if !ok {
-		ctx.JSON(
-			http.StatusInternalServerError,
-			gin.H{
-				"status":  "Internal Server Error",
-				"message": "equipment_service context is not of type db_service.DbService",
-				"error":   "cannot cast equipment_service context to db_service.DbService",
-			})
+	inventory, err := LookupInventory(ctx)
+	if err != nil {
+		problem.Respond(ctx, err)
 		return
 	}
 
 	// get equipment ID from URL
 	equipmentId := ctx.Param("equipmentId")
 
-	// delete to document
-	err := db.DeleteDocument(ctx, equipmentId)
-
-	switch err {
-	case nil:
-		ctx.AbortWithStatus(http.StatusNoContent)
-	case db_service.ErrNotFound:
-		ctx.JSON(
-			http.StatusNotFound,
-			gin.H{
-				"status":  "Not Found",
-				"message": "Equipment not found",
-				"error":   err.Error(),
-			})
-	default:
-		ctx.JSON(
-			http.StatusBadGateway,
-			gin.H{
-				"status":  "Bad Gateway",
-				"message": "Failed to delete equipment from database",
-				"error":   err.Error(),
-			})
+	// mark document as deleted
+	err = inventory.DeleteEquipment(ctx, RequestCaller(ctx), equipmentId)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
 	}
+
+	ctx.AbortWithStatus(http.StatusNoContent)
 }
 
 // UpdateEquipment - Updates specific equipment
 func (this *implEquipmentAndRequestsManagementAPI) UpdateEquipment(ctx *gin.Context) {
-	fmt.Println("req -> UpdateEquipment")
-
-	value, exists := ctx.Get("equipment_service")
-	if !exists {
-		ctx.JSON(
-			http.StatusInternalServerError,
-			gin.H{
-				"status":  "Internal Server Error",
-				"message": "equipment_service not found",
-				"error":   "equipment_service not found",
-			})
-		return
-	}
+	slog.DebugContext(ctx, "Handling request", "operation", "UpdateEquipment")
 
-	db, ok := value.(db_service.DbService[Equipment])
-	if !ok {
-		ctx.JSON(
-			http.StatusInternalServerError,
-			gin.H{
-				"status":  "Internal Server Error",
-				"message": "equipment_service context is not of type db_service.DbService",
-				"error":   "cannot cast equipment_service context to db_service.DbService",
-			})
+	inventory, err := LookupInventory(ctx)
+	if err != nil {
+		problem.Respond(ctx, err)
 		return
 	}
 
 	equipment := Equipment{}
-	err := ctx.ShouldBindJSON(&equipment)
+	err = ctx.ShouldBindJSON(&equipment)
 	if err != nil {
-		ctx.JSON(
-			http.StatusBadRequest,
-			gin.H{
-				"status":  "Bad Request",
-				"message": "Invalid request body",
-				"error":   err.Error(),
-			})
+		problem.Respond(ctx, problem.InvalidBody(err))
 		return
 	}
 
@@ -202,82 +100,37 @@ func (this *implEquipmentAndRequestsManagementAPI) UpdateEquipment(ctx *gin.Cont
 
 	// check if ID from URL param and ID from request body are equal
 	if URLequipmentId != equipment.Id {
-		ctx.JSON(
-			http.StatusBadRequest,
-			gin.H{
-				"status":  "Bad Request",
-				"message": "ID provided in request body is not equal to ID in URL parameter.",
-				"error":   "ID provided in request body is not equal to ID in URL parameter.",
-			})
+		problem.Respond(ctx, idMismatch("id", "equipmentId"))
 		return
 	}
 
 	// update equipment
-	err = db.UpdateDocument(ctx, equipment.Id, &equipment)
-
-	switch err {
-	case nil:
-		ctx.JSON(
-			http.StatusOK,
-			equipment,
-		)
-	case db_service.ErrNotFound:
-		ctx.JSON(
-			http.StatusNotFound,
-			gin.H{
-				"status":  "Not Found",
-				"message": "Equipment with provided ID was not found.",
-				"error":   err.Error(),
-			})
-	default:
-		ctx.JSON(
-			http.StatusBadGateway,
-			gin.H{
-				"status":  "Bad Gateway",
-				"message": "Failed to update equipment in database.",
-				"error":   err.Error(),
-			})
+	err = inventory.UpdateEquipment(ctx, &equipment)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
 	}
+
+	ctx.JSON(
+		http.StatusOK,
+		equipment,
+	)
 }
 
 // AddRoomRequest - Adds new request to a room
 func (this *implEquipmentAndRequestsManagementAPI) AddRoomRequest(ctx *gin.Context) {
-	fmt.Println("req -> AddRoomRequest")
-
-	value, exists := ctx.Get("request_service")
-	if !exists {
-		ctx.JSON(
-			http.StatusInternalServerError,
-			gin.H{
-				"status":  "Internal Server Error",
-				"message": "request_service not found",
-				"error":   "request_service not found",
-			})
-		return
-	}
+	slog.DebugContext(ctx, "Handling request", "operation", "AddRoomRequest")
 
-	db, ok := value.(db_service.DbService[Request])
-	if !ok {
-		ctx.JSON(
-			http.StatusInternalServerError,
-			gin.H{
-				"status":  "Internal Server Error",
-				"message": "request_service context is not of required type",
-				"error":   "cannot cast request_service context to db_service.DbService",
-			})
+	inventory, err := LookupInventory(ctx)
+	if err != nil {
+		problem.Respond(ctx, err)
 		return
 	}
 
 	request := Request{}
-	err := ctx.ShouldBindJSON(&request)
+	err = ctx.ShouldBindJSON(&request)
 	if err != nil {
-		ctx.JSON(
-			http.StatusBadRequest,
-			gin.H{
-				"status":  "Bad Request",
-				"message": "Invalid request body",
-				"error":   err.Error(),
-			})
+		problem.Respond(ctx, problem.InvalidBody(err))
 		return
 	}
 
@@ -286,143 +139,60 @@ func (this *implEquipmentAndRequestsManagementAPI) AddRoomRequest(ctx *gin.Conte
 
 	// Check if room ID from URL param and room ID from request body are equal
 	if URLroomId != request.Room {
-		ctx.JSON(
-			http.StatusBadRequest,
-			gin.H{
-				"status":  "Bad Request",
-				"message": "Room ID provided in request body is not equal to room ID in URL parameter.",
-				"error":   "Room ID provided in request body is not equal to room ID in URL parameter.",
-			})
+		problem.Respond(ctx, idMismatch("room", "roomId"))
 		return
 	}
 
-	// Create new UUID if request Id is empty
-	if request.Id == "" {
-		request.Id = uuid.New().String()
-	}
-
-	// Create request
-	err = db.CreateDocument(ctx, request.Id, &request)
-
-	switch err {
-	case nil:
-		ctx.JSON(
-			http.StatusCreated,
-			request,
-		)
-	case db_service.ErrConflict:
-		ctx.JSON(
-			http.StatusConflict,
-			gin.H{
-				"status":  "Conflict",
-				"message": "Request already exists",
-				"error":   err.Error(),
-			})
-	default:
-		ctx.JSON(
-			http.StatusBadGateway,
-			gin.H{
-				"status":  "Bad Gateway",
-				"message": "Failed to create request in database",
-				"error":   err.Error(),
-			})
+	// Create request, new UUID is assigned if request Id is empty
+	err = inventory.CreateRequest(ctx, &request)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
 	}
+
+	ctx.JSON(
+		http.StatusCreated,
+		request,
+	)
 }
 
 // DeleteRequest - Deletes specific request
 func (this *implEquipmentAndRequestsManagementAPI) DeleteRequest(ctx *gin.Context) {
-	fmt.Println("req -> DeleteRequest")
-
-	value, exists := ctx.Get("request_service")
-	if !exists {
-		ctx.JSON(
-			http.StatusInternalServerError,
-			gin.H{
-				"status":  "Internal Server Error",
-				"message": "request_service not found",
-				"error":   "request_service not found",
-			})
-		return
-	}
+	slog.DebugContext(ctx, "Handling request", "operation", "DeleteRequest")
 
-	db, ok := value.(db_service.DbService[Request])
-	if !ok {
-		ctx.JSON(
-			http.StatusInternalServerError,
-			gin.H{
-				"status":  "Internal Server Error",
-				"message": "request_service context is not of type db_service.DbService",
-				"error":   "cannot cast request_service context to db_service.DbService",
-			})
+	inventory, err := LookupInventory(ctx)
+	if err != nil {
+		problem.Respond(ctx, err)
 		return
 	}
 
 	// Get request ID from URL
 	requestId := ctx.Param("requestId")
 
-	// Delete the document
-	err := db.DeleteDocument(ctx, requestId)
-
-	switch err {
-	case nil:
-		ctx.AbortWithStatus(http.StatusNoContent)
-	case db_service.ErrNotFound:
-		ctx.JSON(
-			http.StatusNotFound,
-			gin.H{
-				"status":  "Not Found",
-				"message": "Request not found",
-				"error":   err.Error(),
-			})
-	default:
-		ctx.JSON(
-			http.StatusBadGateway,
-			gin.H{
-				"status":  "Bad Gateway",
-				"message": "Failed to delete request from database",
-				"error":   err.Error(),
-			})
+	// Mark the document as deleted
+	err = inventory.DeleteRequest(ctx, RequestCaller(ctx), requestId)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
 	}
+
+	ctx.AbortWithStatus(http.StatusNoContent)
 }
 
 // UpdateRequest - Updates specific request
 func (this *implEquipmentAndRequestsManagementAPI) UpdateRequest(ctx *gin.Context) {
-	fmt.Println("req -> UpdateRequest")
-
-	value, exists := ctx.Get("request_service")
-	if !exists {
-		ctx.JSON(
-			http.StatusInternalServerError,
-			gin.H{
-				"status":  "Internal Server Error",
-				"message": "request_service not found",
-				"error":   "request_service not found",
-			})
-		return
-	}
+	slog.DebugContext(ctx, "Handling request", "operation", "UpdateRequest")
 
-	db, ok := value.(db_service.DbService[Request])
-	if !ok {
-		ctx.JSON(
-			http.StatusInternalServerError,
-			gin.H{
-				"status":  "Internal Server Error",
-				"message": "request_service context is not of type db_service.DbService",
-				"error":   "cannot cast request_service context to db_service.DbService",
-			})
+	inventory, err := LookupInventory(ctx)
+	if err != nil {
+		problem.Respond(ctx, err)
 		return
 	}
 
 	request := Request{}
-	err := ctx.ShouldBindJSON(&request)
+	err = ctx.ShouldBindJSON(&request)
 	if err != nil {
-		ctx.JSON(
-			http.StatusBadRequest,
-			gin.H{
-				"status":  "Bad Request",
-				"message": "Invalid request body",
-				"error":   err.Error(),
-			})
+		problem.Respond(ctx, problem.InvalidBody(err))
 		return
 	}
 
@@ -431,242 +201,79 @@ func (this *implEquipmentAndRequestsManagementAPI) UpdateRequest(ctx *gin.Contex
 
 	// Check if ID from URL param and ID from request body are equal
 	if URLrequestId != request.Id {
-		ctx.JSON(
-			http.StatusBadRequest,
-			gin.H{
-				"status":  "Bad Request",
-				"message": "ID provided in request body is not equal to ID in URL parameter.",
-				"error":   "ID provided in request body is not equal to ID in URL parameter.",
-			})
+		problem.Respond(ctx, idMismatch("id", "requestId"))
 		return
 	}
 
 	// Update request
-	err = db.UpdateDocument(ctx, request.Id, &request)
-
-	switch err {
-	case nil:
-		ctx.JSON(
-			http.StatusOK,
-			request,
-		)
-	case db_service.ErrNotFound:
-		ctx.JSON(
-			http.StatusNotFound,
-			gin.H{
-				"status":  "Not Found",
-				"message": "Request with provided ID was not found.",
-				"error":   err.Error(),
-			})
-	default:
-		ctx.JSON(
-			http.StatusBadGateway,
-			gin.H{
-				"status":  "Bad Gateway",
-				"message": "Failed to update request in database.",
-				"error":   err.Error(),
-			})
+	err = inventory.UpdateRequest(ctx, &request)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
 	}
+
+	ctx.JSON(
+		http.StatusOK,
+		request,
+	)
 }
 
 // GetDepartments - Provides list of all departments
 func (this *implEquipmentAndRequestsManagementAPI) GetDepartments(ctx *gin.Context) {
-	fmt.Println("req -> GetDepartments")
-
-	value, exists := ctx.Get("department_service")
-	if !exists {
-		ctx.JSON(
-			http.StatusInternalServerError,
-			gin.H{
-				"status":  "Internal Server Error",
-				"message": "department_service not found",
-				"error":   "department_service not found",
-			})
-		return
-	}
+	slog.DebugContext(ctx, "Handling request", "operation", "GetDepartments")
 
-	db, ok := value.(db_service.DbService[Department])
-	if !ok {
-		ctx.JSON(
-			http.StatusInternalServerError,
-			gin.H{
-				"status":  "Internal Server Error",
-				"message": "department_service context is not of type db_service.DbService",
-				"error":   "cannot cast department_service context to db_service.DbService",
-			})
+	inventory, err := LookupInventory(ctx)
+	if err != nil {
+		problem.Respond(ctx, err)
 		return
 	}
 
-	//create empty filter
-	filter := bson.M{}
-
 	// get all departments
-	departments, err := db.FindDocuments(ctx, filter)
-
-	if len(departments) == 0 {
-		fmt.Printf("empty")
-	}
-
-	switch err {
-	case nil:
-		ctx.JSON(
-			http.StatusOK,
-			departments,
-		)
-	default:
-		ctx.JSON(
-			http.StatusBadGateway,
-			gin.H{
-				"status":  "Bad Gateway",
-				"message": "Failed to get departments",
-				"error":   err.Error(),
-			})
+	departments, err := inventory.Departments(ctx)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
 	}
+
+	ctx.JSON(
+		http.StatusOK,
+		departments,
+	)
 }
 
 // GetDepartmentEquipment - Provides list of all equipment in a department
 func (this *implEquipmentAndRequestsManagementAPI) GetDepartmentEquipment(ctx *gin.Context) {
-	fmt.Println("req -> GetDepartmentEquipment")
+	slog.DebugContext(ctx, "Handling request", "operation", "GetDepartmentEquipment")
 
 	// get department ID from URL parameter
 	departmentID := ctx.Param("departmentId")
 	if departmentID == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"status":  "Bad Request",
-			"message": "Department ID is required",
-			"error":   "Department ID is missing in URL parameters",
-		})
-		return
-	}
-
-	// department service
-	value, exists := ctx.Get("department_service")
-	if !exists {
-		ctx.JSON(
-			http.StatusInternalServerError,
-			gin.H{
-				"status":  "Internal Server Error",
-				"message": "department_service not found",
-				"error":   "department_service not found",
-			})
-		return
-	}
-
-	departmentService, ok := value.(db_service.DbService[Department])
-	if !ok {
-		ctx.JSON(
-			http.StatusInternalServerError,
-			gin.H{
-				"status":  "Internal Server Error",
-				"message": "department_service context is not of type db_service.DbService",
-				"error":   "cannot cast department_service context to db_service.DbService",
-			})
-		return
-	}
-
-	// get department
-	department, err := departmentService.FindDocument(ctx, departmentID)
-
-	switch err {
-	case nil:
-		// do nothing
-	case db_service.ErrNotFound:
-		ctx.JSON(
-			http.StatusNotFound,
-			gin.H{
-				"status":  "Not Found",
-				"message": "Department with provided ID was not found.",
-				"error":   err.Error(),
-			})
-	default:
-		ctx.JSON(
-			http.StatusBadGateway,
-			gin.H{
-				"status":  "Bad Gateway",
-				"message": "Failed to find Department in database.",
-				"error":   err.Error(),
-			})
-	}
-
-	// room service
-	value, exists = ctx.Get("room_service")
-	if !exists {
-		ctx.JSON(
-			http.StatusInternalServerError,
-			gin.H{
-				"status":  "Internal Server Error",
-				"message": "room_service not found",
-				"error":   "room_service not found",
-			})
+		problem.Respond(ctx, problem.InvalidParameter("departmentId", "Department ID is required."))
 		return
 	}
 
-	roomService, ok := value.(db_service.DbService[Room])
-	if !ok {
-		ctx.JSON(
-			http.StatusInternalServerError,
-			gin.H{
-				"status":  "Internal Server Error",
-				"message": "room_service context is not of type db_service.DbService",
-				"error":   "cannot cast room_service context to db_service.DbService",
-			})
-		return
-	}
+	includeDeleted, _ := strconv.ParseBool(ctx.DefaultQuery("include_deleted", "false"))
 
-	// equipment service
-	value, exists = ctx.Get("equipment_service")
-	if !exists {
-		ctx.JSON(
-			http.StatusInternalServerError,
-			gin.H{
-				"status":  "Internal Server Error",
-				"message": "equipment_service not found",
-				"error":   "equipment_service not found",
-			})
-		return
-	}
-
-	equipmentService, ok := value.(db_service.DbService[Equipment])
-	if !ok {
-		ctx.JSON(
-			http.StatusInternalServerError,
-			gin.H{
-				"status":  "Internal Server Error",
-				"message": "equipment_service context is not of type db_service.DbService",
-				"error":   "cannot cast equipment_service context to db_service.DbService",
-			})
-		return
+	// historical inventory is reconstructed from equipment versions
+	var asOf *time.Time
+	if value := ctx.Query("as_of"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			problem.Respond(ctx, problem.InvalidParameter("as_of", "Parameter as_of must be RFC 3339 timestamp."))
+			return
+		}
+		asOf = &parsed
 	}
 
-	// filter rooms by department
-	roomsFilter := bson.M{"department_id": departmentID}
-
-	// get rooms
-	rooms, err := roomService.FindDocuments(ctx, roomsFilter)
+	inventory, err := LookupInventory(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{
-			"status":  "Internal Server Error",
-			"message": "Failed to retrieve rooms",
-			"error":   err.Error(),
-		})
+		problem.Respond(ctx, err)
 		return
 	}
 
-	// get list of room IDs
-	roomIDs := make([]string, len(rooms))
-	for i, room := range rooms {
-		roomIDs[i] = room.Id
-	}
-
-	// get equipment based on room IDs
-	equipmentFilter := bson.M{"room": bson.M{"$in": roomIDs}}
-	equipment, err := equipmentService.FindDocuments(ctx, equipmentFilter)
+	contents, err := inventory.DepartmentEquipment(ctx, RequestCaller(ctx), departmentID, includeDeleted, asOf)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{
-			"status":  "Internal Server Error",
-			"message": "Failed to retrieve equipment",
-			"error":   err.Error(),
-		})
+		problem.Respond(ctx, err)
 		return
 	}
 
@@ -681,12 +288,12 @@ func (this *implEquipmentAndRequestsManagementAPI) GetDepartmentEquipment(ctx *g
 		} `json:"rooms"`
 	}{
 		Id:   departmentID,
-		Name: department.Name,
+		Name: contents.Department.Name,
 	}
 
-	for _, room := range rooms {
+	for _, room := range contents.Rooms {
 		roomEquip := []Equipment{}
-		for _, eq := range equipment {
+		for _, eq := range contents.Equipment {
 			if eq.Room == room.Id {
 				roomEquip = append(roomEquip, *eq)
 			}
@@ -707,125 +314,25 @@ func (this *implEquipmentAndRequestsManagementAPI) GetDepartmentEquipment(ctx *g
 
 // GetDepartmentRequests - Provides list of all requests in a department
 func (this *implEquipmentAndRequestsManagementAPI) GetDepartmentRequests(ctx *gin.Context) {
-	fmt.Println("req -> GetDepartmentRequests")
+	slog.DebugContext(ctx, "Handling request", "operation", "GetDepartmentRequests")
 
 	departmentID := ctx.Param("departmentId")
 	if departmentID == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"status":  "Bad Request",
-			"message": "Department ID is required",
-			"error":   "Department ID is missing in URL parameters",
-		})
+		problem.Respond(ctx, problem.InvalidParameter("departmentId", "Department ID is required."))
 		return
 	}
 
-	// department service
-	value, exists := ctx.Get("department_service")
-	if !exists {
-		ctx.JSON(
-			http.StatusInternalServerError,
-			gin.H{
-				"status":  "Internal Server Error",
-				"message": "department_service not found",
-				"error":   "department_service not found",
-			})
-		return
-	}
-
-	departmentService, ok := value.(db_service.DbService[Department])
-	if !ok {
-		ctx.JSON(
-			http.StatusInternalServerError,
-			gin.H{
-				"status":  "Internal Server Error",
-				"message": "department_service context is not of type db_service.DbService",
-				"error":   "cannot cast department_service context to db_service.DbService",
-			})
-		return
-	}
-
-	// get department
-	department, err := departmentService.FindDocument(ctx, departmentID)
-
-	// room service
-	value, exists = ctx.Get("room_service")
-	if !exists {
-		ctx.JSON(
-			http.StatusInternalServerError,
-			gin.H{
-				"status":  "Internal Server Error",
-				"message": "room_service not found",
-				"error":   "room_service not found",
-			})
-		return
-	}
-
-	roomService, ok := value.(db_service.DbService[Room])
-	if !ok {
-		ctx.JSON(
-			http.StatusInternalServerError,
-			gin.H{
-				"status":  "Internal Server Error",
-				"message": "room_service context is not of type db_service.DbService",
-				"error":   "cannot cast room_service context to db_service.DbService",
-			})
-		return
-	}
+	includeDeleted, _ := strconv.ParseBool(ctx.DefaultQuery("include_deleted", "false"))
 
-	// request service
-	value, exists = ctx.Get("request_service")
-	if !exists {
-		ctx.JSON(
-			http.StatusInternalServerError,
-			gin.H{
-				"status":  "Internal Server Error",
-				"message": "request_service not found",
-				"error":   "request_service not found",
-			})
-		return
-	}
-
-	requestService, ok := value.(db_service.DbService[Request])
-	if !ok {
-		ctx.JSON(
-			http.StatusInternalServerError,
-			gin.H{
-				"status":  "Internal Server Error",
-				"message": "request_service context is not of type db_service.DbService",
-				"error":   "cannot cast request_service context to db_service.DbService",
-			})
-		return
-	}
-
-	// filter rooms by department
-	roomsFilter := bson.M{"department_id": departmentID}
-
-	// get rooms
-	rooms, err := roomService.FindDocuments(ctx, roomsFilter)
+	inventory, err := LookupInventory(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{
-			"status":  "Internal Server Error",
-			"message": "Failed to retrieve rooms",
-			"error":   err.Error(),
-		})
+		problem.Respond(ctx, err)
 		return
 	}
 
-	// get list of room IDs
-	roomIDs := make([]string, len(rooms))
-	for i, room := range rooms {
-		roomIDs[i] = room.Id
-	}
-
-	// get requests based on room IDs
-	requestFilter := bson.M{"room": bson.M{"$in": roomIDs}}
-	requests, err := requestService.FindDocuments(ctx, requestFilter)
+	contents, err := inventory.DepartmentRequests(ctx, RequestCaller(ctx), departmentID, includeDeleted)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{
-			"status":  "Internal Server Error",
-			"message": "Failed to retrieve requests",
-			"error":   err.Error(),
-		})
+		problem.Respond(ctx, err)
 		return
 	}
 
@@ -840,12 +347,12 @@ func (this *implEquipmentAndRequestsManagementAPI) GetDepartmentRequests(ctx *gi
 		} `json:"rooms"`
 	}{
 		Id:   departmentID,
-		Name: department.Name,
+		Name: contents.Department.Name,
 	}
 
-	for _, room := range rooms {
+	for _, room := range contents.Rooms {
 		roomRequests := []Request{}
-		for _, req := range requests {
+		for _, req := range contents.Requests {
 			if req.Room == room.Id {
 				roomRequests = append(roomRequests, *req)
 			}
@@ -0,0 +1,97 @@
+package fpjp
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/problem"
+)
+
+// PatchEquipment - Partially updates specific equipment
+func (this *implEquipmentAndRequestsManagementAPI) PatchEquipment(ctx *gin.Context) {
+	slog.DebugContext(ctx, "Handling request", "operation", "PatchEquipment")
+
+	db, err := lookupService[Equipment](ctx, "equipment_service")
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+
+	// get equipment ID from URL param
+	equipmentId := ctx.Param("equipmentId")
+
+	// load current state of the equipment
+	original, err := db.FindDocument(ctx, equipmentId)
+	if err != nil {
+		problem.Respond(ctx, dbProblem(err, "equipment"))
+		return
+	}
+
+	// apply and validate the patch
+	patched, changes, err := applyPatch(ctx, original)
+	if err != nil {
+		problem.Respond(ctx, patchProblem(err))
+		return
+	}
+	if err := validateEquipment(patched); err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+
+	// set only the changed fields
+	equipment, err := db.PatchDocument(ctx, equipmentId, changes)
+	if err != nil {
+		problem.Respond(ctx, dbProblem(err, "equipment"))
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		equipment,
+	)
+}
+
+// PatchRequest - Partially updates specific request
+func (this *implEquipmentAndRequestsManagementAPI) PatchRequest(ctx *gin.Context) {
+	slog.DebugContext(ctx, "Handling request", "operation", "PatchRequest")
+
+	db, err := lookupService[Request](ctx, "request_service")
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+
+	// get request ID from URL param
+	requestId := ctx.Param("requestId")
+
+	// load current state of the request
+	original, err := db.FindDocument(ctx, requestId)
+	if err != nil {
+		problem.Respond(ctx, dbProblem(err, "request"))
+		return
+	}
+
+	// apply and validate the patch
+	patched, changes, err := applyPatch(ctx, original)
+	if err != nil {
+		problem.Respond(ctx, patchProblem(err))
+		return
+	}
+	if err := validateRequest(patched); err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+
+	// set only the changed fields
+	request, err := db.PatchDocument(ctx, requestId, changes)
+	if err != nil {
+		problem.Respond(ctx, dbProblem(err, "request"))
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		request,
+	)
+}
@@ -0,0 +1,227 @@
+package fpjp
+
+import (
+	"log/slog"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/problem"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// CreateEquipmentReservation - Reserves specific equipment for a time range
+func (this *implEquipmentAndRequestsManagementAPI) CreateEquipmentReservation(ctx *gin.Context) {
+	slog.DebugContext(ctx, "Handling request", "operation", "CreateEquipmentReservation")
+
+	inventory, err := LookupInventory(ctx)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+
+	reservation := Reservation{}
+	if err := ctx.ShouldBindJSON(&reservation); err != nil {
+		problem.Respond(ctx, problem.InvalidBody(err))
+		return
+	}
+
+	// get equipment ID from URL param
+	equipmentId := ctx.Param("equipmentId")
+
+	// reserve the equipment unless it is already reserved
+	err = inventory.ReserveEquipment(ctx, RequestCaller(ctx), equipmentId, &reservation)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+	ctx.JSON(
+		http.StatusCreated,
+		reservation,
+	)
+}
+
+// GetEquipmentReservations - Provides reservations of specific equipment
+func (this *implEquipmentAndRequestsManagementAPI) GetEquipmentReservations(ctx *gin.Context) {
+	slog.DebugContext(ctx, "Handling request", "operation", "GetEquipmentReservations")
+
+	db, err := lookupService[Reservation](ctx, "reservation_service")
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+
+	from, to, err := calendarWindow(ctx)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+
+	// get equipment ID from URL param
+	equipmentId := ctx.Param("equipmentId")
+
+	// get reservations overlapping the window
+	filter := overlapFilter(from, to)
+	filter["equipmentId"] = equipmentId
+	found, err := db.FindDocuments(ctx, filter)
+	if err != nil {
+		problem.Respond(ctx, problem.Database(err))
+		return
+	}
+
+	reservations := []Reservation{}
+	for _, reservation := range found {
+		reservations = append(reservations, *reservation)
+	}
+	sort.Slice(reservations, func(i, j int) bool {
+		return reservations[i].Start.Before(reservations[j].Start)
+	})
+
+	if wantsICalendar(ctx) {
+		ctx.Data(
+			http.StatusOK,
+			icsMediaType+"; charset=utf-8",
+			[]byte(reservationsToICalendar(reservations, nil)),
+		)
+		return
+	}
+	ctx.JSON(http.StatusOK, reservations)
+}
+
+// CancelReservation - Cancels specific reservation
+func (this *implEquipmentAndRequestsManagementAPI) CancelReservation(ctx *gin.Context) {
+	slog.DebugContext(ctx, "Handling request", "operation", "CancelReservation")
+
+	db, err := lookupService[Reservation](ctx, "reservation_service")
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+
+	// get reservation ID from URL
+	reservationId := ctx.Param("reservationId")
+
+	// mark reservation as cancelled
+	err = db.SoftDeleteDocument(ctx, reservationId, requestUser(ctx))
+	if err != nil {
+		problem.Respond(ctx, dbProblem(err, "reservation"))
+		return
+	}
+	ctx.AbortWithStatus(http.StatusNoContent)
+}
+
+// GetDepartmentReservations - Provides availability calendar of equipment in a department
+func (this *implEquipmentAndRequestsManagementAPI) GetDepartmentReservations(ctx *gin.Context) {
+	slog.DebugContext(ctx, "Handling request", "operation", "GetDepartmentReservations")
+
+	departmentID := ctx.Param("departmentId")
+	if departmentID == "" {
+		problem.Respond(ctx, problem.InvalidParameter("departmentId", "Department ID is required."))
+		return
+	}
+
+	from, to, err := calendarWindow(ctx)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+
+	roomService, err := lookupService[Room](ctx, "room_service")
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+
+	equipmentService, err := lookupService[Equipment](ctx, "equipment_service")
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+
+	reservationService, err := lookupService[Reservation](ctx, "reservation_service")
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+
+	// get rooms of the department
+	rooms, err := roomService.FindDocuments(ctx, bson.M{"department_id": departmentID})
+	if err != nil {
+		problem.Respond(ctx, problem.Database(err))
+		return
+	}
+
+	roomIDs := make([]string, len(rooms))
+	for i, room := range rooms {
+		roomIDs[i] = room.Id
+	}
+
+	// get equipment located in the rooms
+	equipment, err := equipmentService.FindDocuments(ctx, bson.M{"room": bson.M{"$in": roomIDs}})
+	if err != nil {
+		problem.Respond(ctx, problem.Database(err))
+		return
+	}
+
+	equipmentIDs := make([]string, len(equipment))
+	equipmentNames := map[string]string{}
+	for i, eq := range equipment {
+		equipmentIDs[i] = eq.Id
+		equipmentNames[eq.Id] = eq.Name
+	}
+
+	// get reservations of the equipment overlapping the window
+	filter := overlapFilter(from, to)
+	filter["equipmentId"] = bson.M{"$in": equipmentIDs}
+	reservations, err := reservationService.FindDocuments(ctx, filter)
+	if err != nil {
+		problem.Respond(ctx, problem.Database(err))
+		return
+	}
+
+	sort.Slice(reservations, func(i, j int) bool {
+		return reservations[i].Start.Before(reservations[j].Start)
+	})
+
+	if wantsICalendar(ctx) {
+		all := make([]Reservation, len(reservations))
+		for i, reservation := range reservations {
+			all[i] = *reservation
+		}
+		ctx.Data(
+			http.StatusOK,
+			icsMediaType+"; charset=utf-8",
+			[]byte(reservationsToICalendar(all, equipmentNames)),
+		)
+		return
+	}
+
+	// create response object
+	calendar := ReservationCalendar{
+		DepartmentId: departmentID,
+		From:         from,
+		To:           to,
+		Equipment:    []EquipmentAvailability{},
+	}
+	for _, eq := range equipment {
+		equipmentReservations := []*Reservation{}
+		availability := EquipmentAvailability{
+			Equipment:    *eq,
+			Reservations: []Reservation{},
+		}
+		for _, reservation := range reservations {
+			if reservation.EquipmentId == eq.Id {
+				equipmentReservations = append(equipmentReservations, reservation)
+				availability.Reservations = append(availability.Reservations, *reservation)
+			}
+		}
+		availability.PeakReserved = peakReserved(equipmentReservations, from, to)
+		availability.Available = eq.Count - availability.PeakReserved
+		if availability.Available < 0 {
+			availability.Available = 0
+		}
+		calendar.Equipment = append(calendar.Equipment, availability)
+	}
+
+	ctx.JSON(http.StatusOK, calendar)
+}
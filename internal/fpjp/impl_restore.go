@@ -0,0 +1,61 @@
+package fpjp
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/problem"
+)
+
+// RestoreEquipment - Restores previously deleted equipment
+func (this *implEquipmentAndRequestsManagementAPI) RestoreEquipment(ctx *gin.Context) {
+	slog.DebugContext(ctx, "Handling request", "operation", "RestoreEquipment")
+
+	inventory, err := LookupInventory(ctx)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+
+	// get equipment ID from URL
+	equipmentId := ctx.Param("equipmentId")
+
+	// remove deletion markers, only deleted equipment is found
+	equipment, err := inventory.RestoreEquipment(ctx, equipmentId)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		equipment,
+	)
+}
+
+// RestoreRequest - Restores previously deleted request
+func (this *implEquipmentAndRequestsManagementAPI) RestoreRequest(ctx *gin.Context) {
+	slog.DebugContext(ctx, "Handling request", "operation", "RestoreRequest")
+
+	inventory, err := LookupInventory(ctx)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+
+	// Get request ID from URL
+	requestId := ctx.Param("requestId")
+
+	// Remove deletion markers, only deleted request is found
+	request, err := inventory.RestoreRequest(ctx, requestId)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		request,
+	)
+}
@@ -0,0 +1,114 @@
+package fpjp
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/problem"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// sign of the count change expected for each adjustment reason
+var adjustmentSigns = map[string]int32{
+	"consumed":  -1,
+	"lost":      -1,
+	"found":     1,
+	"restocked": 1,
+}
+
+// AddEquipmentAdjustment - Atomically changes count of specific equipment
+func (this *implEquipmentAndRequestsManagementAPI) AddEquipmentAdjustment(ctx *gin.Context) {
+	slog.DebugContext(ctx, "Handling request", "operation", "AddEquipmentAdjustment")
+
+	inventory, err := LookupInventory(ctx)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+
+	adjustment := StockAdjustment{}
+	if err := ctx.ShouldBindJSON(&adjustment); err != nil {
+		problem.Respond(ctx, problem.InvalidBody(err))
+		return
+	}
+
+	// get equipment ID from URL param
+	equipmentId := ctx.Param("equipmentId")
+
+	// change count and record the change in the ledger
+	movement, err := inventory.AdjustEquipment(ctx, RequestCaller(ctx), equipmentId, &adjustment)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+	ctx.JSON(
+		http.StatusCreated,
+		movement,
+	)
+}
+
+// GetEquipmentAdjustments - Provides stock movement ledger of specific equipment
+func (this *implEquipmentAndRequestsManagementAPI) GetEquipmentAdjustments(ctx *gin.Context) {
+	slog.DebugContext(ctx, "Handling request", "operation", "GetEquipmentAdjustments")
+
+	db, err := lookupService[StockMovement](ctx, "movement_service")
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+
+	// get equipment ID from URL param
+	equipmentId := ctx.Param("equipmentId")
+
+	// filter movements by optional query parameters
+	filter := bson.M{"equipmentId": equipmentId}
+	if reason := ctx.Query("reason"); reason != "" {
+		filter["reason"] = reason
+	}
+	recordedAt := bson.M{}
+	for param, operator := range map[string]string{"since": "$gte", "until": "$lt"} {
+		value := ctx.Query(param)
+		if value == "" {
+			continue
+		}
+		timestamp, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			detail := fmt.Sprintf("Parameter %v must be RFC 3339 timestamp.", param)
+			problem.Respond(ctx, problem.InvalidParameter(param, detail).WithCause(err))
+			return
+		}
+		recordedAt[operator] = timestamp
+	}
+	if len(recordedAt) > 0 {
+		filter["recordedAt"] = recordedAt
+	}
+
+	// get movements
+	movements, err := db.FindDocuments(ctx, filter)
+	if err != nil {
+		problem.Respond(ctx, problem.Database(err))
+		return
+	}
+
+	sort.Slice(movements, func(i, j int) bool {
+		return movements[i].RecordedAt.Before(movements[j].RecordedAt)
+	})
+
+	// create response object
+	ledger := StockLedger{
+		EquipmentId:    equipmentId,
+		Movements:      []StockMovement{},
+		TotalsByReason: map[string]int32{},
+	}
+	for _, movement := range movements {
+		ledger.Movements = append(ledger.Movements, *movement)
+		ledger.Total += movement.Delta
+		ledger.TotalsByReason[movement.Reason] += movement.Delta
+	}
+
+	ctx.JSON(http.StatusOK, ledger)
+}
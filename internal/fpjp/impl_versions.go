@@ -0,0 +1,81 @@
+package fpjp
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/problem"
+)
+
+// GetEquipmentVersions - Provides history of changes of specific equipment
+func (this *implEquipmentAndRequestsManagementAPI) GetEquipmentVersions(ctx *gin.Context) {
+	slog.DebugContext(ctx, "Handling request", "operation", "GetEquipmentVersions")
+
+	db, err := lookupService[Equipment](ctx, "equipment_service")
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+
+	// get equipment ID from URL
+	equipmentId := ctx.Param("equipmentId")
+
+	// get all recorded versions
+	versions, err := db.FindVersions(ctx, equipmentId)
+	if err != nil {
+		problem.Respond(ctx, dbProblem(err, "equipment"))
+		return
+	}
+
+	response := make([]EquipmentVersion, len(versions))
+	for i, version := range versions {
+		response[i] = EquipmentVersion{
+			Id:         version.Id,
+			Version:    version.Version,
+			Operation:  version.Operation,
+			RecordedAt: version.RecordedAt,
+			Document:   *version.Document,
+		}
+	}
+	ctx.JSON(
+		http.StatusOK,
+		response,
+	)
+}
+
+// GetRequestVersions - Provides history of changes of specific request
+func (this *implEquipmentAndRequestsManagementAPI) GetRequestVersions(ctx *gin.Context) {
+	slog.DebugContext(ctx, "Handling request", "operation", "GetRequestVersions")
+
+	db, err := lookupService[Request](ctx, "request_service")
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+
+	// Get request ID from URL
+	requestId := ctx.Param("requestId")
+
+	// Get all recorded versions
+	versions, err := db.FindVersions(ctx, requestId)
+	if err != nil {
+		problem.Respond(ctx, dbProblem(err, "request"))
+		return
+	}
+
+	response := make([]RequestVersion, len(versions))
+	for i, version := range versions {
+		response[i] = RequestVersion{
+			Id:         version.Id,
+			Version:    version.Version,
+			Operation:  version.Operation,
+			RecordedAt: version.RecordedAt,
+			Document:   *version.Document,
+		}
+	}
+	ctx.JSON(
+		http.StatusOK,
+		response,
+	)
+}
@@ -0,0 +1,590 @@
+package fpjp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/events"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/problem"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// types of the requests
+const (
+	requestTypeMissingEquipment = "missing-equipment"
+	requestTypeRepair           = "repair"
+)
+
+type InventoryConfig struct {
+	Departments db_service.DbService[Department]
+	Rooms       db_service.DbService[Room]
+	Equipment   db_service.DbService[Equipment]
+	Requests    db_service.DbService[Request]
+	// ledger of the equipment count adjustments
+	Movements db_service.DbService[StockMovement]
+	// reservations of the equipment and the locks serializing them
+	Reservations db_service.DbService[Reservation]
+	Locks        db_service.DbService[Lock]
+	// brokers of the changes made through the Equipment and Requests services
+	EquipmentChanges *events.Broker[Equipment]
+	RequestChanges   *events.Broker[Request]
+	// runs the function in a database transaction, nil if transactions are not available
+	Transactions func(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Inventory implements the business rules of the departments, rooms, equipment and requests,
+// it is shared by the REST handlers and the other APIs of the service so they behave the same,
+// errors are returned as problems
+type Inventory struct {
+	config InventoryConfig
+	// set once the database turns out to be standalone server without transactions
+	standalone atomic.Bool
+	locks      lockKeys
+}
+
+func NewInventory(config InventoryConfig) *Inventory {
+	return &Inventory{config: config}
+}
+
+// DepartmentEquipment is the department with its rooms and the equipment located in them,
+// the versions of the API differ only in the shape of the response
+type DepartmentEquipment struct {
+	Department *Department
+	Rooms      []*Room
+	Equipment  []*Equipment
+}
+
+// DepartmentRequests is the department with its rooms and the requests associated with them
+type DepartmentRequests struct {
+	Department *Department
+	Rooms      []*Room
+	Requests   []*Request
+}
+
+// ChangeFilter limits the watched changes to single department or room, empty filter matches all changes
+type ChangeFilter struct {
+	DepartmentId string
+	RoomId       string
+}
+
+func (this *Inventory) Departments(ctx context.Context) ([]*Department, error) {
+	departments, err := this.config.Departments.FindDocuments(ctx, bson.M{})
+	if err != nil {
+		return nil, dbProblem(err, "department")
+	}
+	return departments, nil
+}
+
+func (this *Inventory) Department(ctx context.Context, id string) (*Department, error) {
+	department, err := this.config.Departments.FindDocument(ctx, id)
+	if err != nil {
+		return nil, dbProblem(err, "department")
+	}
+	return department, nil
+}
+
+func (this *Inventory) Room(ctx context.Context, id string) (*Room, error) {
+	room, err := this.config.Rooms.FindDocument(ctx, id)
+	if err != nil {
+		return nil, dbProblem(err, "room")
+	}
+	return room, nil
+}
+
+// DepartmentRooms provides the rooms of the existing department
+func (this *Inventory) DepartmentRooms(ctx context.Context, departmentId string) ([]*Room, error) {
+	_, rooms, err := this.departmentRooms(ctx, departmentId)
+	return rooms, err
+}
+
+// DepartmentEquipment provides the equipment of the department, deleted equipment are
+// included only for administrators, asOf reconstructs the inventory from the recorded versions
+func (this *Inventory) DepartmentEquipment(ctx context.Context, caller Caller, departmentId string, includeDeleted bool, asOf *time.Time) (*DepartmentEquipment, error) {
+	listCtx, err := listingContext(ctx, caller, includeDeleted, "Only administrators can list deleted equipment.")
+	if err != nil {
+		return nil, err
+	}
+
+	department, rooms, err := this.departmentRooms(ctx, departmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	// get equipment based on room IDs
+	equipmentFilter := bson.M{"room": bson.M{"$in": roomIds(rooms)}}
+	var equipment []*Equipment
+	if asOf != nil {
+		equipment, err = this.config.Equipment.FindDocumentsAsOf(listCtx, equipmentFilter, *asOf)
+	} else {
+		equipment, err = this.config.Equipment.FindDocuments(listCtx, equipmentFilter)
+	}
+	if err != nil {
+		return nil, dbProblem(err, "equipment")
+	}
+
+	return &DepartmentEquipment{
+		Department: department,
+		Rooms:      rooms,
+		Equipment:  equipment,
+	}, nil
+}
+
+// DepartmentRequests provides the requests of the department, deleted requests are
+// included only for administrators
+func (this *Inventory) DepartmentRequests(ctx context.Context, caller Caller, departmentId string, includeDeleted bool) (*DepartmentRequests, error) {
+	listCtx, err := listingContext(ctx, caller, includeDeleted, "Only administrators can list deleted requests.")
+	if err != nil {
+		return nil, err
+	}
+
+	department, rooms, err := this.departmentRooms(ctx, departmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	// get requests based on room IDs
+	requests, err := this.config.Requests.FindDocuments(listCtx, bson.M{"room": bson.M{"$in": roomIds(rooms)}})
+	if err != nil {
+		return nil, dbProblem(err, "request")
+	}
+
+	return &DepartmentRequests{
+		Department: department,
+		Rooms:      rooms,
+		Requests:   requests,
+	}, nil
+}
+
+// Equipment provides single equipment, deleted equipment is provided only to administrators
+func (this *Inventory) Equipment(ctx context.Context, caller Caller, id string, includeDeleted bool) (*Equipment, error) {
+	findCtx, err := listingContext(ctx, caller, includeDeleted, "Only administrators can read deleted equipment.")
+	if err != nil {
+		return nil, err
+	}
+	equipment, err := this.config.Equipment.FindDocument(findCtx, id)
+	if err != nil {
+		return nil, dbProblem(err, "equipment")
+	}
+	return equipment, nil
+}
+
+// CreateEquipment stores new equipment, the id is generated when it is empty
+func (this *Inventory) CreateEquipment(ctx context.Context, equipment *Equipment) error {
+	if err := validateEquipment(equipment); err != nil {
+		return err
+	}
+
+	// deletion markers are managed by the service only
+	equipment.DeletedAt = nil
+	equipment.DeletedBy = ""
+
+	if equipment.Id == "" {
+		equipment.Id = uuid.New().String()
+	}
+
+	if err := this.config.Equipment.CreateDocument(ctx, equipment.Id, equipment); err != nil {
+		return dbProblem(err, "equipment")
+	}
+	return nil
+}
+
+func (this *Inventory) UpdateEquipment(ctx context.Context, equipment *Equipment) error {
+	if err := validateEquipment(equipment); err != nil {
+		return err
+	}
+
+	// deletion markers are managed by the service only
+	equipment.DeletedAt = nil
+	equipment.DeletedBy = ""
+
+	if err := this.config.Equipment.UpdateDocument(ctx, equipment.Id, equipment); err != nil {
+		return dbProblem(err, "equipment")
+	}
+	return nil
+}
+
+// DeleteEquipment marks the equipment as deleted, it can be restored until it is purged
+func (this *Inventory) DeleteEquipment(ctx context.Context, caller Caller, id string) error {
+	if err := this.config.Equipment.SoftDeleteDocument(ctx, id, caller.User); err != nil {
+		return dbProblem(err, "equipment")
+	}
+	return nil
+}
+
+// RestoreEquipment removes deletion markers, only deleted equipment is found
+func (this *Inventory) RestoreEquipment(ctx context.Context, id string) (*Equipment, error) {
+	equipment, err := this.config.Equipment.RestoreDocument(ctx, id)
+	if err != nil {
+		return nil, dbProblem(err, "equipment")
+	}
+	return equipment, nil
+}
+
+// Request provides single request, deleted request is provided only to administrators
+func (this *Inventory) Request(ctx context.Context, caller Caller, id string, includeDeleted bool) (*Request, error) {
+	findCtx, err := listingContext(ctx, caller, includeDeleted, "Only administrators can read deleted requests.")
+	if err != nil {
+		return nil, err
+	}
+	request, err := this.config.Requests.FindDocument(findCtx, id)
+	if err != nil {
+		return nil, dbProblem(err, "request")
+	}
+	return request, nil
+}
+
+// CreateRequest stores new request, the id is generated when it is empty
+func (this *Inventory) CreateRequest(ctx context.Context, request *Request) error {
+	if err := validateRequest(request); err != nil {
+		return err
+	}
+
+	// deletion markers are managed by the service only
+	request.DeletedAt = nil
+	request.DeletedBy = ""
+
+	if request.Id == "" {
+		request.Id = uuid.New().String()
+	}
+
+	if err := this.config.Requests.CreateDocument(ctx, request.Id, request); err != nil {
+		return dbProblem(err, "request")
+	}
+	return nil
+}
+
+func (this *Inventory) UpdateRequest(ctx context.Context, request *Request) error {
+	if err := validateRequest(request); err != nil {
+		return err
+	}
+
+	// deletion markers are managed by the service only
+	request.DeletedAt = nil
+	request.DeletedBy = ""
+
+	if err := this.config.Requests.UpdateDocument(ctx, request.Id, request); err != nil {
+		return dbProblem(err, "request")
+	}
+	return nil
+}
+
+// DeleteRequest marks the request as deleted, it can be restored until it is purged
+func (this *Inventory) DeleteRequest(ctx context.Context, caller Caller, id string) error {
+	if err := this.config.Requests.SoftDeleteDocument(ctx, id, caller.User); err != nil {
+		return dbProblem(err, "request")
+	}
+	return nil
+}
+
+// RestoreRequest removes deletion markers, only deleted request is found
+func (this *Inventory) RestoreRequest(ctx context.Context, id string) (*Request, error) {
+	request, err := this.config.Requests.RestoreDocument(ctx, id)
+	if err != nil {
+		return nil, dbProblem(err, "request")
+	}
+	return request, nil
+}
+
+// AdjustEquipment changes the count of the equipment and records the change in the ledger in one transaction,
+// the sign of the change must match its reason and the count never drops below zero
+func (this *Inventory) AdjustEquipment(ctx context.Context, caller Caller, equipmentId string, adjustment *StockAdjustment) (*StockMovement, error) {
+	// the HTTP binding checks the reason, other callers like batches and gRPC do not
+	sign, ok := adjustmentSigns[adjustment.Reason]
+	if !ok {
+		return nil, invalidFields([]problem.FieldError{
+			{Field: "reason", Code: "oneof", Message: "must be one of consumed, lost, found, restocked"},
+		})
+	}
+	if adjustment.Delta*sign < 0 {
+		detail := fmt.Sprintf("Delta %v is not allowed for reason %v.", adjustment.Delta, adjustment.Reason)
+		return nil, problem.New(http.StatusBadRequest, codeReasonMismatch, detail).
+			WithErrors(problem.FieldError{Field: "delta", Code: "sign", Message: "sign must match the reason of the adjustment"})
+	}
+
+	var movement *StockMovement
+	err := this.transactionally(ctx, func(ctx context.Context) error {
+		// the guarded increment serializes concurrent adjustments of the equipment
+		equipment, err := this.config.Equipment.IncrementField(ctx, equipmentId, "count", adjustment.Delta)
+		switch {
+		case err == nil:
+		case errors.Is(err, db_service.ErrNegativeValue):
+			return problem.New(http.StatusConflict, codeInsufficientStock, "Not enough equipment items available.")
+		default:
+			return dbProblem(err, "equipment")
+		}
+
+		movement = &StockMovement{
+			Id:          uuid.New().String(),
+			EquipmentId: equipmentId,
+			Delta:       adjustment.Delta,
+			Reason:      adjustment.Reason,
+			Note:        adjustment.Note,
+			CountAfter:  equipment.Count,
+			RecordedAt:  time.Now().UTC(),
+			RecordedBy:  caller.User,
+		}
+		if err := this.config.Movements.CreateDocument(ctx, movement.Id, movement); err != nil {
+			return problem.Database(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return movement, nil
+}
+
+// ReserveEquipment reserves items of the equipment for the time range requested by the room, reservations
+// of the same equipment are serialized so that more items than available are never reserved at the same time
+func (this *Inventory) ReserveEquipment(ctx context.Context, caller Caller, equipmentId string, reservation *Reservation) error {
+	// fill in fields managed by the service
+	reservation.Id = uuid.New().String()
+	reservation.EquipmentId = equipmentId
+	reservation.ReservedBy = caller.User
+	reservation.CreatedAt = time.Now().UTC()
+	if reservation.Count == 0 {
+		reservation.Count = 1
+	}
+
+	return this.serialized(ctx, "reservations/"+equipmentId, func(ctx context.Context) error {
+		equipment, err := this.config.Equipment.FindDocument(ctx, equipmentId)
+		if err != nil {
+			return dbProblem(err, "equipment")
+		}
+
+		// check that the requesting room exists
+		_, err = this.config.Rooms.FindDocument(ctx, reservation.Room)
+		switch {
+		case err == nil:
+		case errors.Is(err, db_service.ErrNotFound):
+			return problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Requesting room does not exist.").
+				WithErrors(problem.FieldError{Field: "room", Code: "exists", Message: "must be ID of existing room"})
+		default:
+			return problem.Database(err)
+		}
+
+		// detect conflicts with existing reservations
+		filter := overlapFilter(reservation.Start, reservation.End)
+		filter["equipmentId"] = equipmentId
+		overlapping, err := this.config.Reservations.FindDocuments(ctx, filter)
+		if err != nil {
+			return problem.Database(err)
+		}
+
+		reserved := peakReserved(overlapping, reservation.Start, reservation.End)
+		if reserved+reservation.Count > equipment.Count {
+			detail := fmt.Sprintf(
+				"Equipment is not available for the whole requested time range, %v of %v items are already reserved, %v requested.",
+				reserved, equipment.Count, reservation.Count)
+			return problem.New(http.StatusConflict, codeNotAvailable, detail).With("conflicts", overlapping)
+		}
+
+		if err := this.config.Reservations.CreateDocument(ctx, reservation.Id, reservation); err != nil {
+			return dbProblem(err, "reservation")
+		}
+		return nil
+	})
+}
+
+// InTransaction runs the function so that either all its changes are stored or none of them,
+// the changes are published to the watchers only after the transaction is committed
+func (this *Inventory) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if this.config.Transactions == nil {
+		return transactionsNotSupported()
+	}
+
+	var deferred *events.Deferred
+	err := this.config.Transactions(ctx, func(ctx context.Context) error {
+		// the function may be retried, only changes of the committed attempt are published
+		var deferCtx context.Context
+		deferCtx, deferred = events.Defer(ctx)
+		return fn(deferCtx)
+	})
+
+	var failure *problem.Problem
+	switch {
+	case err == nil:
+		deferred.Publish()
+		return nil
+	case errors.Is(err, db_service.ErrTransactionsNotSupported):
+		return transactionsNotSupported()
+	case errors.As(err, &failure):
+		return failure
+	default:
+		return problem.Database(err)
+	}
+}
+
+// transactionally runs the function in transaction when the database supports them, standalone servers
+// run it directly, so its changes are no longer stored all or none if it fails in the middle
+func (this *Inventory) transactionally(ctx context.Context, fn func(ctx context.Context) error) error {
+	if supported, err := this.tryTransaction(ctx, fn); supported {
+		return err
+	}
+	return fn(ctx)
+}
+
+// tryTransaction runs the function in transaction, it reports false without running the function
+// when the database turns out to be standalone server
+func (this *Inventory) tryTransaction(ctx context.Context, fn func(ctx context.Context) error) (bool, error) {
+	if this.standalone.Load() {
+		return false, nil
+	}
+	err := this.InTransaction(ctx, fn)
+	if err == nil || problem.From(err).Code != codeTransactionsNotSupported {
+		return true, err
+	}
+	this.standalone.Store(true)
+	slog.WarnContext(ctx, "Database does not support transactions, related changes are not stored atomically")
+	return false, nil
+}
+
+// WatchEquipment streams the changes of the equipment until the context is done
+func (this *Inventory) WatchEquipment(ctx context.Context, filter ChangeFilter) <-chan events.Change[Equipment] {
+	return watch(ctx, this, this.config.EquipmentChanges, filter, func(equipment *Equipment) string { return equipment.Room })
+}
+
+// WatchRequests streams the changes of the requests until the context is done
+func (this *Inventory) WatchRequests(ctx context.Context, filter ChangeFilter) <-chan events.Change[Request] {
+	return watch(ctx, this, this.config.RequestChanges, filter, func(request *Request) string { return request.Room })
+}
+
+// watch forwards the changes matching the filter, permanently removed documents
+// are delivered only to the watchers without filter
+func watch[DocType interface{}](ctx context.Context, inventory *Inventory, broker *events.Broker[DocType], filter ChangeFilter, room func(*DocType) string) <-chan events.Change[DocType] {
+	matching := make(chan events.Change[DocType])
+	if broker == nil {
+		close(matching)
+		return matching
+	}
+
+	changes := broker.Subscribe(ctx)
+	go func() {
+		defer close(matching)
+		// department of the room is looked up once per watcher
+		departments := map[string]string{}
+		for change := range changes {
+			if filter != (ChangeFilter{}) {
+				if change.Document == nil {
+					continue
+				}
+				roomId := room(change.Document)
+				if filter.RoomId != "" && roomId != filter.RoomId {
+					continue
+				}
+				if filter.DepartmentId != "" {
+					department, known := departments[roomId]
+					if !known {
+						found, err := inventory.config.Rooms.FindDocument(ctx, roomId)
+						if err != nil {
+							slog.WarnContext(ctx, "Cannot find room of the changed document", "room", roomId, "error", err)
+							continue
+						}
+						department = found.DepartmentId
+						departments[roomId] = department
+					}
+					if department != filter.DepartmentId {
+						continue
+					}
+				}
+			}
+
+			select {
+			case matching <- change:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return matching
+}
+
+// validateEquipment checks the fields of the equipment, so that it is valid regardless of the API it came from
+func validateEquipment(equipment *Equipment) error {
+	invalid := []problem.FieldError{}
+	invalid = appendRequired(invalid, "room", equipment.Room)
+	invalid = appendRequired(invalid, "type", equipment.Type)
+	invalid = appendRequired(invalid, "name", equipment.Name)
+	if equipment.Count < 0 {
+		invalid = append(invalid, problem.FieldError{Field: "count", Code: "min", Message: "must be at least 0"})
+	}
+	return invalidFields(invalid)
+}
+
+// validateRequest checks the fields of the request, so that it is valid regardless of the API it came from
+func validateRequest(request *Request) error {
+	invalid := []problem.FieldError{}
+	invalid = appendRequired(invalid, "room", request.Room)
+	switch request.Type {
+	case "":
+		invalid = appendRequired(invalid, "type", request.Type)
+	case requestTypeMissingEquipment, requestTypeRepair:
+	default:
+		invalid = append(invalid, problem.FieldError{Field: "type", Code: "oneof",
+			Message: fmt.Sprintf("must be one of: %v, %v", requestTypeMissingEquipment, requestTypeRepair)})
+	}
+	invalid = appendRequired(invalid, "name", request.Name)
+	if request.Count != nil && *request.Count < 0 {
+		invalid = append(invalid, problem.FieldError{Field: "count", Code: "min", Message: "must be at least 0"})
+	}
+	return invalidFields(invalid)
+}
+
+func appendRequired(invalid []problem.FieldError, field string, value string) []problem.FieldError {
+	if value != "" {
+		return invalid
+	}
+	return append(invalid, problem.FieldError{Field: field, Code: "required", Message: "is required"})
+}
+
+func invalidFields(invalid []problem.FieldError) error {
+	if len(invalid) == 0 {
+		return nil
+	}
+	return problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Request body is not valid.").WithErrors(invalid...)
+}
+
+func transactionsNotSupported() *problem.Problem {
+	return problem.New(http.StatusNotImplemented, codeTransactionsNotSupported,
+		"The database deployment does not support transactions, use best-effort mode.")
+}
+
+// listingContext lets administrators read also the soft deleted documents
+func listingContext(ctx context.Context, caller Caller, includeDeleted bool, forbidden string) (context.Context, error) {
+	if !includeDeleted {
+		return ctx, nil
+	}
+	if !caller.Admin {
+		return nil, adminOnly(forbidden)
+	}
+	return db_service.WithDeleted(ctx), nil
+}
+
+func (this *Inventory) departmentRooms(ctx context.Context, departmentId string) (*Department, []*Room, error) {
+	department, err := this.config.Departments.FindDocument(ctx, departmentId)
+	if err != nil {
+		return nil, nil, dbProblem(err, "department")
+	}
+
+	// filter rooms by department
+	rooms, err := this.config.Rooms.FindDocuments(ctx, bson.M{"department_id": departmentId})
+	if err != nil {
+		return nil, nil, dbProblem(err, "room")
+	}
+	return department, rooms, nil
+}
+
+func roomIds(rooms []*Room) []string {
+	ids := make([]string, len(rooms))
+	for i, room := range rooms {
+		ids[i] = room.Id
+	}
+	return ids
+}
@@ -0,0 +1,98 @@
+package fpjp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/problem"
+)
+
+func TestInventoryValidatesEquipment(t *testing.T) {
+	tests := map[string]struct {
+		equipment Equipment
+		fields    []string
+	}{
+		"valid":          {Equipment{Room: "room-1", Type: "monitor", Name: "Monitor", Count: 1}, nil},
+		"zero count":     {Equipment{Room: "room-1", Type: "monitor", Name: "Monitor"}, nil},
+		"negative count": {Equipment{Room: "room-1", Type: "monitor", Name: "Monitor", Count: -1}, []string{"count"}},
+		"missing fields": {Equipment{Count: 1}, []string{"room", "type", "name"}},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := newTestServer(t)
+			equipment := test.equipment
+			checkFieldErrors(t, server.inventory.CreateEquipment(context.Background(), &equipment), test.fields)
+
+			equipment = test.equipment
+			equipment.Id = "eq-1"
+			err := server.inventory.UpdateEquipment(context.Background(), &equipment)
+			if test.fields != nil {
+				checkFieldErrors(t, err, test.fields)
+			}
+		})
+	}
+}
+
+func TestInventoryValidatesRequest(t *testing.T) {
+	tests := map[string]struct {
+		request Request
+		fields  []string
+	}{
+		"valid":          {Request{Room: "room-1", Type: "repair", Name: "Monitor"}, nil},
+		"unknown type":   {Request{Room: "room-1", Type: "purchase", Name: "Monitor"}, []string{"type"}},
+		"negative count": {Request{Room: "room-1", Type: "missing-equipment", Name: "Monitor", Count: int32Pointer(-2)}, []string{"count"}},
+		"missing fields": {Request{}, []string{"room", "type", "name"}},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := newTestServer(t)
+			request := test.request
+			checkFieldErrors(t, server.inventory.CreateRequest(context.Background(), &request), test.fields)
+
+			request = test.request
+			request.Id = "req-1"
+			err := server.inventory.UpdateRequest(context.Background(), &request)
+			if test.fields != nil {
+				checkFieldErrors(t, err, test.fields)
+			}
+		})
+	}
+}
+
+func TestPatchIsValidatedByInventory(t *testing.T) {
+	server := newTestServer(t)
+	seed(t, server.requests.CreateDocument(context.Background(), "req-1",
+		&Request{Id: "req-1", Room: "room-1", Type: "missing-equipment", Name: "Monitor", Count: int32Pointer(1)}))
+
+	response := server.serve(http.MethodPatch, "/api/requests/req-1", mergePatchMediaType, `{"type": "purchase"}`)
+
+	if code := problemCode(t, response, http.StatusBadRequest); code != problem.CodeInvalidBody {
+		t.Errorf("unexpected problem %v", code)
+	}
+}
+
+// checkFieldErrors checks that the error is invalid body problem reporting exactly the fields, or nil without fields
+func checkFieldErrors(t *testing.T, err error, fields []string) {
+	t.Helper()
+	if fields == nil {
+		if err != nil {
+			t.Errorf("unexpected error %v", err)
+		}
+		return
+	}
+
+	var failure *problem.Problem
+	if !errors.As(err, &failure) || failure.Status != http.StatusBadRequest || failure.Code != problem.CodeInvalidBody {
+		t.Fatalf("expected invalid body problem, got %v", err)
+	}
+	if len(failure.Errors) != len(fields) {
+		t.Fatalf("expected errors of fields %v, got %+v", fields, failure.Errors)
+	}
+	for i, field := range fields {
+		if failure.Errors[i].Field != field {
+			t.Errorf("expected error of field %v, got %+v", field, failure.Errors[i])
+		}
+	}
+}
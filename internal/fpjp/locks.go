@@ -0,0 +1,61 @@
+package fpjp
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service"
+)
+
+// Lock is the document written by each transaction which must not run concurrently with the others
+// holding the same key, the database lets only one of the conflicting transactions commit
+// and retries the others, so they see its changes
+type Lock struct {
+	Id string `json:"id" bson:"id"`
+	// number of the transactions which held the lock
+	Acquired int64 `json:"acquired" bson:"acquired"`
+}
+
+// lockKeys keeps the locks known to exist and the mutexes used instead of them without transactions
+type lockKeys struct {
+	created sync.Map
+	mutexes sync.Map
+}
+
+// serialized runs the function in transaction which holds the lock of the key, on standalone servers
+// it runs directly and is serialized only with the functions running in this replica of the service
+func (this *Inventory) serialized(ctx context.Context, key string, fn func(ctx context.Context) error) error {
+	if !this.standalone.Load() {
+		if err := this.ensureLock(ctx, key); err != nil {
+			return err
+		}
+	}
+	supported, err := this.tryTransaction(ctx, func(ctx context.Context) error {
+		if _, err := this.config.Locks.IncrementField(ctx, key, "acquired", 1); err != nil {
+			return dbProblem(err, "lock")
+		}
+		return fn(ctx)
+	})
+	if supported {
+		return err
+	}
+
+	mutex, _ := this.locks.mutexes.LoadOrStore(key, &sync.Mutex{})
+	mutex.(*sync.Mutex).Lock()
+	defer mutex.(*sync.Mutex).Unlock()
+	return fn(ctx)
+}
+
+// ensureLock creates the lock document before its first use, so that the transactions only update it
+func (this *Inventory) ensureLock(ctx context.Context, key string) error {
+	if _, ok := this.locks.created.Load(key); ok {
+		return nil
+	}
+	err := this.config.Locks.CreateDocument(ctx, key, &Lock{Id: key})
+	if err != nil && !errors.Is(err, db_service.ErrConflict) {
+		return dbProblem(err, "lock")
+	}
+	this.locks.created.Store(key, true)
+	return nil
+}
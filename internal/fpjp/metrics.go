@@ -0,0 +1,123 @@
+package fpjp
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var (
+	// the request is open until it is deleted once resolved, soft deleted requests are closed
+	openRequestsDesc = prometheus.NewDesc(
+		"fpjp_open_requests",
+		"Number of open requests per department and type, the request is closed when it is deleted",
+		[]string{"department", "type"}, nil,
+	)
+	equipmentItemsDesc = prometheus.NewDesc(
+		"fpjp_equipment_items",
+		"Number of equipment items per department and type",
+		[]string{"department", "type"}, nil,
+	)
+)
+
+// domainCollector computes domain gauges from the database, the collections are loaded at most
+// once per refresh interval and the scrapes in between get the cached values
+type domainCollector struct {
+	roomService      db_service.DbService[Room]
+	requestService   db_service.DbService[Request]
+	equipmentService db_service.DbService[Equipment]
+	timeout          time.Duration
+	refresh          time.Duration
+
+	mutex       sync.Mutex
+	refreshedAt time.Time
+	metrics     []prometheus.Metric
+}
+
+func NewDomainCollector(
+	roomService db_service.DbService[Room],
+	requestService db_service.DbService[Request],
+	equipmentService db_service.DbService[Equipment],
+	refresh time.Duration,
+) prometheus.Collector {
+	return &domainCollector{
+		roomService:      roomService,
+		requestService:   requestService,
+		equipmentService: equipmentService,
+		timeout:          5 * time.Second,
+		refresh:          refresh,
+	}
+}
+
+func (this *domainCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- openRequestsDesc
+	ch <- equipmentItemsDesc
+}
+
+func (this *domainCollector) Collect(ch chan<- prometheus.Metric) {
+	// concurrent scrapes wait for single refresh
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
+	if this.refreshedAt.IsZero() || time.Since(this.refreshedAt) >= this.refresh {
+		if metrics, ok := this.compute(); ok {
+			this.metrics = metrics
+			this.refreshedAt = time.Now()
+		}
+	}
+	for _, metric := range this.metrics {
+		ch <- metric
+	}
+}
+
+// compute loads the collections, it reports false when no value could be computed
+// so that the previous values are kept until the database is available again
+func (this *domainCollector) compute() ([]prometheus.Metric, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), this.timeout)
+	defer cancel()
+
+	rooms, err := this.roomService.FindDocuments(ctx, bson.M{})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to collect domain metrics", "error", err)
+		return nil, false
+	}
+	departments := map[string]string{}
+	for _, room := range rooms {
+		departments[room.Id] = room.DepartmentId
+	}
+
+	type key struct{ department, kind string }
+	metrics := []prometheus.Metric{}
+
+	requests, err := this.requestService.FindDocuments(ctx, bson.M{})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to collect request metrics", "error", err)
+		return nil, false
+	}
+	counts := map[key]float64{}
+	for _, request := range requests {
+		counts[key{departments[request.Room], request.Type}]++
+	}
+	for k, count := range counts {
+		metrics = append(metrics, prometheus.MustNewConstMetric(openRequestsDesc, prometheus.GaugeValue, count, k.department, k.kind))
+	}
+
+	equipment, err := this.equipmentService.FindDocuments(ctx, bson.M{})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to collect equipment metrics", "error", err)
+		return nil, false
+	}
+	counts = map[key]float64{}
+	for _, eq := range equipment {
+		counts[key{departments[eq.Room], eq.Type}] += float64(eq.Count)
+	}
+	for k, count := range counts {
+		metrics = append(metrics, prometheus.MustNewConstMetric(equipmentItemsDesc, prometheus.GaugeValue, count, k.department, k.kind))
+	}
+	return metrics, true
+}
@@ -0,0 +1,80 @@
+package fpjp
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// countingService counts the queries, so that the caching can be checked
+type countingService[DocType interface{}] struct {
+	db_service.DbService[DocType]
+	queries *atomic.Int32
+}
+
+func (this countingService[DocType]) FindDocuments(ctx context.Context, filter bson.M) ([]*DocType, error) {
+	this.queries.Add(1)
+	return this.DbService.FindDocuments(ctx, filter)
+}
+
+func TestDomainCollectorCountsOpenRequests(t *testing.T) {
+	server := newTestServer(t)
+	ctx := context.Background()
+	for _, id := range []string{"req-1", "req-2", "req-3"} {
+		seed(t, server.requests.CreateDocument(ctx, id, &Request{Id: id, Room: "room-1", Type: "repair", Name: "Monitor"}))
+	}
+	seed(t, server.requests.SoftDeleteDocument(ctx, "req-3", "nurse"))
+	server.addEquipment(t, Equipment{Id: "eq-1", Room: "room-1", Type: "monitor", Name: "Monitor", Count: 2})
+	server.addEquipment(t, Equipment{Id: "eq-2", Room: "room-2", Type: "monitor", Name: "Monitor", Count: 3})
+
+	values := collect(NewDomainCollector(server.rooms, server.requests, server.equipment, time.Minute))
+
+	if open := values["fpjp_open_requests"]; open != 2 {
+		t.Errorf("deleted request is closed, expected 2 open requests, got %v", open)
+	}
+	if items := values["fpjp_equipment_items"]; items != 5 {
+		t.Errorf("expected 5 equipment items, got %v", items)
+	}
+}
+
+func TestDomainCollectorCachesValues(t *testing.T) {
+	server := newTestServer(t)
+	queries := &atomic.Int32{}
+	collector := NewDomainCollector(countingService[Room]{server.rooms, queries}, server.requests, server.equipment, time.Minute)
+
+	collect(collector)
+	collect(collector)
+
+	if queries.Load() != 1 {
+		t.Errorf("expected single refresh within the interval, got %v", queries.Load())
+	}
+}
+
+// collect scrapes the collector and sums the values of each metric
+func collect(collector prometheus.Collector) map[string]float64 {
+	ch := make(chan prometheus.Metric, 100)
+	collector.Collect(ch)
+	close(ch)
+
+	values := map[string]float64{}
+	for metric := range ch {
+		sample := &dto.Metric{}
+		if err := metric.Write(sample); err != nil {
+			panic(err)
+		}
+		name := metric.Desc().String()
+		for _, known := range []string{"fpjp_open_requests", "fpjp_equipment_items"} {
+			if strings.Contains(name, `"`+known+`"`) {
+				values[known] += sample.GetGauge().GetValue()
+			}
+		}
+	}
+	return values
+}
@@ -0,0 +1,17 @@
+package fpjp
+
+type BatchOperation struct {
+
+	// Operation applied to the resource
+	Action string `json:"action" binding:"required,oneof=create update delete"`
+
+	// Type of the resource
+	Resource string `json:"resource" binding:"required,oneof=equipment request"`
+
+	// Identifier of the updated or deleted resource, the update defaults to id of the body
+	Id string `json:"id,omitempty"`
+
+	Equipment *Equipment `json:"equipment,omitempty"`
+
+	Request *Request `json:"request,omitempty"`
+}
@@ -0,0 +1,10 @@
+package fpjp
+
+type BatchRequest struct {
+
+	// All-or-nothing or independent execution of the operations
+	Mode string `json:"mode,omitempty" binding:"omitempty,oneof=transactional best-effort"`
+
+	// Operations executed in the given order
+	Operations []BatchOperation `json:"operations" binding:"required,min=1,max=100,dive"`
+}
@@ -0,0 +1,10 @@
+package fpjp
+
+type BatchResponse struct {
+
+	// Mode in which the operations were executed
+	Mode string `json:"mode"`
+
+	// Results in the order of the operations
+	Results []BatchResult `json:"results"`
+}
@@ -0,0 +1,16 @@
+package fpjp
+
+type BatchResult struct {
+
+	// Position of the operation in the batch
+	Index int32 `json:"index"`
+
+	// HTTP status code the operation would have as a separate request
+	Status int32 `json:"status"`
+
+	// Identifier of the affected resource
+	Id string `json:"id,omitempty"`
+
+	// Created or updated resource, or the problem of the failed operation
+	Body interface{} `json:"body,omitempty"`
+}
@@ -10,6 +10,10 @@
 
 package fpjp
 
+import (
+	"time"
+)
+
 type Equipment struct {
 
 	// Unique identifier of the equipment
@@ -26,4 +30,10 @@ type Equipment struct {
 
 	// Number of equipment items available
 	Count int32 `json:"count" bson:"count" binding:"required"`
+
+	// Time when the equipment was deleted, present only for deleted equipment
+	DeletedAt *time.Time `json:"deletedAt,omitempty" bson:"deletedAt,omitempty"`
+
+	// Identity of the user who deleted the equipment
+	DeletedBy string `json:"deletedBy,omitempty" bson:"deletedBy,omitempty"`
 }
@@ -0,0 +1,14 @@
+package fpjp
+
+type EquipmentAvailability struct {
+	Equipment Equipment `json:"equipment" bson:"equipment"`
+
+	// Reservations of the equipment overlapping the calendar window
+	Reservations []Reservation `json:"reservations" bson:"reservations"`
+
+	// Highest number of items reserved at the same time within the calendar window
+	PeakReserved int32 `json:"peakReserved" bson:"peakReserved"`
+
+	// Number of items available for the whole calendar window
+	Available int32 `json:"available" bson:"available"`
+}
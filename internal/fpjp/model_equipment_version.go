@@ -0,0 +1,22 @@
+package fpjp
+
+import (
+	"time"
+)
+
+type EquipmentVersion struct {
+
+	// Unique identifier of the equipment
+	Id string `json:"id" bson:"id"`
+
+	// Sequence number of the version, starting with 1
+	Version int64 `json:"version" bson:"version"`
+
+	// Operation which produced the version
+	Operation string `json:"operation" bson:"operation"`
+
+	// Time when the version was recorded
+	RecordedAt time.Time `json:"recordedAt" bson:"recordedAt"`
+
+	Document Equipment `json:"document" bson:"document"`
+}
@@ -10,6 +10,10 @@
 
 package fpjp
 
+import (
+	"time"
+)
+
 type Request struct {
 
 	// Unique identifier of the request
@@ -29,4 +33,10 @@ type Request struct {
 
 	// Detailed description of the request
 	Description string `json:"description" bson:"description"`
+
+	// Time when the request was deleted, present only for deleted requests
+	DeletedAt *time.Time `json:"deletedAt,omitempty" bson:"deletedAt,omitempty"`
+
+	// Identity of the user who deleted the request
+	DeletedBy string `json:"deletedBy,omitempty" bson:"deletedBy,omitempty"`
 }
@@ -0,0 +1,35 @@
+package fpjp
+
+import (
+	"time"
+)
+
+type Reservation struct {
+
+	// Unique identifier of the reservation
+	Id string `json:"id" bson:"id"`
+
+	// Identifier of the reserved equipment
+	EquipmentId string `json:"equipmentId" bson:"equipmentId"`
+
+	// Identifier of the room requesting the equipment
+	Room string `json:"room" bson:"room" binding:"required"`
+
+	// Purpose of the reservation
+	Purpose string `json:"purpose" bson:"purpose" binding:"required"`
+
+	// Beginning of the reservation
+	Start time.Time `json:"start" bson:"start" binding:"required"`
+
+	// End of the reservation
+	End time.Time `json:"end" bson:"end" binding:"required,gtfield=Start"`
+
+	// Number of reserved equipment items, defaults to 1
+	Count int32 `json:"count" bson:"count" binding:"omitempty,min=1"`
+
+	// Identity of the user who made the reservation
+	ReservedBy string `json:"reservedBy" bson:"reservedBy"`
+
+	// Time when the reservation was made
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
+}
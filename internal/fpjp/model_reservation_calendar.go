@@ -0,0 +1,20 @@
+package fpjp
+
+import (
+	"time"
+)
+
+type ReservationCalendar struct {
+
+	// Identifier of the department
+	DepartmentId string `json:"departmentId" bson:"departmentId"`
+
+	// Beginning of the calendar window
+	From time.Time `json:"from" bson:"from"`
+
+	// End of the calendar window
+	To time.Time `json:"to" bson:"to"`
+
+	// Availability of the equipment located in the department rooms
+	Equipment []EquipmentAvailability `json:"equipment" bson:"equipment"`
+}
@@ -0,0 +1,13 @@
+package fpjp
+
+type StockAdjustment struct {
+
+	// Signed change of the equipment count, negative for consumed or lost items
+	Delta int32 `json:"delta" bson:"delta" binding:"required"`
+
+	// Reason of the change
+	Reason string `json:"reason" bson:"reason" binding:"required,oneof=consumed lost found restocked"`
+
+	// Optional note describing the change
+	Note string `json:"note,omitempty" bson:"note,omitempty"`
+}
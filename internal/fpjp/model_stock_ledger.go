@@ -0,0 +1,16 @@
+package fpjp
+
+type StockLedger struct {
+
+	// Identifier of the equipment
+	EquipmentId string `json:"equipmentId" bson:"equipmentId"`
+
+	// Movements matching the query, oldest first
+	Movements []StockMovement `json:"movements" bson:"movements"`
+
+	// Sum of all listed changes
+	Total int32 `json:"total" bson:"total"`
+
+	// Sum of listed changes per reason
+	TotalsByReason map[string]int32 `json:"totalsByReason" bson:"totalsByReason"`
+}
@@ -0,0 +1,32 @@
+package fpjp
+
+import (
+	"time"
+)
+
+type StockMovement struct {
+
+	// Unique identifier of the movement
+	Id string `json:"id" bson:"id"`
+
+	// Identifier of the adjusted equipment
+	EquipmentId string `json:"equipmentId" bson:"equipmentId"`
+
+	// Signed change of the equipment count
+	Delta int32 `json:"delta" bson:"delta"`
+
+	// Reason of the change
+	Reason string `json:"reason" bson:"reason"`
+
+	// Optional note describing the change
+	Note string `json:"note,omitempty" bson:"note,omitempty"`
+
+	// Equipment count after the change was applied
+	CountAfter int32 `json:"countAfter" bson:"countAfter"`
+
+	// Time when the change was applied
+	RecordedAt time.Time `json:"recordedAt" bson:"recordedAt"`
+
+	// Identity of the user who applied the change
+	RecordedBy string `json:"recordedBy" bson:"recordedBy"`
+}
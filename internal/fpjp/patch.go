@@ -0,0 +1,121 @@
+package fpjp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/problem"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// media types of the supported patch formats
+const (
+	mergePatchMediaType = "application/merge-patch+json" // RFC 7396
+	jsonPatchMediaType  = "application/json-patch+json"  // RFC 6902
+)
+
+var errUnsupportedPatch = errors.New("unsupported patch media type, use " + mergePatchMediaType + " or " + jsonPatchMediaType)
+
+// fields which cannot be changed by patch
+var immutableFields = []string{"id", db_service.DeletedAtField, db_service.DeletedByField}
+
+// patchProblem reports the error of applyPatch to the client
+func patchProblem(err error) *problem.Problem {
+	if errors.Is(err, errUnsupportedPatch) {
+		return problem.New(http.StatusUnsupportedMediaType, problem.CodeUnsupportedMediaType, err.Error())
+	}
+	return problem.InvalidBody(err)
+}
+
+// applyPatch applies the patch from the request body to the original document,
+// validates the result and returns it together with the changed fields
+func applyPatch[DocType interface{}](ctx *gin.Context, original *DocType) (*DocType, bson.M, error) {
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	originalJson, err := json.Marshal(original)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var patchedJson []byte
+	switch ctx.ContentType() {
+	case mergePatchMediaType, binding.MIMEJSON:
+		patchedJson, err = jsonpatch.MergePatch(originalJson, body)
+	case jsonPatchMediaType:
+		var patch jsonpatch.Patch
+		patch, err = jsonpatch.DecodePatch(body)
+		if err == nil {
+			patchedJson, err = patch.Apply(originalJson)
+		}
+	default:
+		return nil, nil, errUnsupportedPatch
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	patched := new(DocType)
+	if err := json.Unmarshal(patchedJson, patched); err != nil {
+		return nil, nil, err
+	}
+	if err := binding.Validator.ValidateStruct(patched); err != nil {
+		return nil, nil, err
+	}
+
+	changes, err := changedFields(original, patched)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, field := range immutableFields {
+		if _, changed := changes[field]; changed {
+			return nil, nil, fmt.Errorf("field %v cannot be changed", field)
+		}
+	}
+	return patched, changes, nil
+}
+
+// changedFields compares stored representation of the documents, removed fields have nil value
+func changedFields[DocType interface{}](original *DocType, patched *DocType) (bson.M, error) {
+	before, err := toBson(original)
+	if err != nil {
+		return nil, err
+	}
+	after, err := toBson(patched)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := bson.M{}
+	for field, value := range after {
+		if !reflect.DeepEqual(before[field], value) {
+			changes[field] = value
+		}
+	}
+	for field := range before {
+		if _, ok := after[field]; !ok {
+			changes[field] = nil
+		}
+	}
+	return changes, nil
+}
+
+func toBson(document interface{}) (bson.M, error) {
+	data, err := bson.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	result := bson.M{}
+	err = bson.Unmarshal(data, &result)
+	return result, err
+}
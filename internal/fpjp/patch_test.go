@@ -0,0 +1,114 @@
+package fpjp
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func int32Pointer(value int32) *int32 {
+	return &value
+}
+
+func TestMergePatchChangesOnlyProvidedFields(t *testing.T) {
+	server := newTestServer(t)
+	server.addEquipment(t, Equipment{Id: "eq-1", Room: "room-1", Type: "monitor", Name: "Monitor", Count: 2})
+
+	response := server.serve(http.MethodPatch, "/api/equipment/eq-1", mergePatchMediaType, `{"count": 5}`)
+
+	patched := decode[Equipment](t, response, http.StatusOK)
+	if patched.Count != 5 || patched.Name != "Monitor" || patched.Room != "room-1" {
+		t.Errorf("unexpected patched equipment %+v", patched)
+	}
+}
+
+func TestMergePatchNullRemovesField(t *testing.T) {
+	server := newTestServer(t)
+	seed(t, server.requests.CreateDocument(context.Background(), "req-1",
+		&Request{Id: "req-1", Room: "room-1", Type: "missing-equipment", Name: "Gloves", Count: int32Pointer(3)}))
+
+	response := server.serve(http.MethodPatch, "/api/requests/req-1", mergePatchMediaType, `{"count": null}`)
+
+	decode[Request](t, response, http.StatusOK)
+	stored, err := server.requests.FindDocument(context.Background(), "req-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Count != nil {
+		t.Errorf("count should be removed, got %v", *stored.Count)
+	}
+}
+
+func TestPlainJsonIsMergePatch(t *testing.T) {
+	server := newTestServer(t)
+	server.addEquipment(t, Equipment{Id: "eq-1", Room: "room-1", Type: "monitor", Name: "Monitor", Count: 2})
+
+	response := server.serve(http.MethodPatch, "/api/equipment/eq-1", "application/json", `{"name": "Patient monitor"}`)
+
+	if patched := decode[Equipment](t, response, http.StatusOK); patched.Name != "Patient monitor" {
+		t.Errorf("unexpected name %v", patched.Name)
+	}
+}
+
+func TestJsonPatchAppliesOperations(t *testing.T) {
+	server := newTestServer(t)
+	server.addEquipment(t, Equipment{Id: "eq-1", Room: "room-1", Type: "monitor", Name: "Monitor", Count: 2})
+
+	response := server.serve(http.MethodPatch, "/api/equipment/eq-1", jsonPatchMediaType,
+		`[{"op": "test", "path": "/count", "value": 2}, {"op": "replace", "path": "/room", "value": "room-2"}]`)
+
+	if patched := decode[Equipment](t, response, http.StatusOK); patched.Room != "room-2" {
+		t.Errorf("unexpected room %v", patched.Room)
+	}
+}
+
+func TestJsonPatchFailedTestIsNotApplied(t *testing.T) {
+	server := newTestServer(t)
+	server.addEquipment(t, Equipment{Id: "eq-1", Room: "room-1", Type: "monitor", Name: "Monitor", Count: 2})
+
+	response := server.serve(http.MethodPatch, "/api/equipment/eq-1", jsonPatchMediaType,
+		`[{"op": "test", "path": "/count", "value": 3}, {"op": "replace", "path": "/count", "value": 0}]`)
+
+	problemCode(t, response, http.StatusBadRequest)
+	stored, _ := server.equipment.FindDocument(context.Background(), "eq-1")
+	if stored.Count != 2 {
+		t.Errorf("equipment should not change, count is %v", stored.Count)
+	}
+}
+
+func TestPatchRejectsInvalidResult(t *testing.T) {
+	server := newTestServer(t)
+	server.addEquipment(t, Equipment{Id: "eq-1", Room: "room-1", Type: "monitor", Name: "Monitor", Count: 2})
+
+	tests := map[string]struct {
+		contentType string
+		body        string
+	}{
+		"required field removed": {mergePatchMediaType, `{"name": null}`},
+		"id changed":             {mergePatchMediaType, `{"id": "eq-2"}`},
+		"malformed patch":        {jsonPatchMediaType, `{"op": "remove"}`},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			response := server.serve(http.MethodPatch, "/api/equipment/eq-1", test.contentType, test.body)
+			problemCode(t, response, http.StatusBadRequest)
+		})
+	}
+}
+
+func TestPatchRejectsUnsupportedMediaType(t *testing.T) {
+	server := newTestServer(t)
+	server.addEquipment(t, Equipment{Id: "eq-1", Room: "room-1", Type: "monitor", Name: "Monitor", Count: 2})
+
+	response := server.serve(http.MethodPatch, "/api/equipment/eq-1", "text/plain", `count=3`)
+
+	problemCode(t, response, http.StatusUnsupportedMediaType)
+}
+
+func TestPatchOfMissingEquipment(t *testing.T) {
+	server := newTestServer(t)
+
+	response := server.serve(http.MethodPatch, "/api/equipment/eq-1", mergePatchMediaType, `{"count": 1}`)
+
+	problemCode(t, response, http.StatusNotFound)
+}
@@ -0,0 +1,142 @@
+package fpjp
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/problem"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	icsMediaType  = "text/calendar"
+	icsTimeFormat = "20060102T150405Z"
+)
+
+// peakReserved returns the highest number of items reserved at the same time within the interval,
+// the peak is always reached at the interval start or at the start of some reservation
+func peakReserved(reservations []*Reservation, start time.Time, end time.Time) int32 {
+	points := []time.Time{start}
+	for _, reservation := range reservations {
+		if reservation.Start.After(start) && reservation.Start.Before(end) {
+			points = append(points, reservation.Start)
+		}
+	}
+
+	peak := int32(0)
+	for _, point := range points {
+		reserved := int32(0)
+		for _, reservation := range reservations {
+			if !reservation.Start.After(point) && reservation.End.After(point) {
+				reserved += reservation.Count
+			}
+		}
+		if reserved > peak {
+			peak = reserved
+		}
+	}
+	return peak
+}
+
+// overlapFilter matches reservations overlapping the interval
+func overlapFilter(start time.Time, end time.Time) bson.M {
+	return bson.M{
+		"start": bson.M{"$lt": end},
+		"end":   bson.M{"$gt": start},
+	}
+}
+
+// calendarWindow reads the from and to query parameters, by default the window covers one week
+func calendarWindow(ctx *gin.Context) (time.Time, time.Time, error) {
+	from := time.Now().UTC()
+	if value := ctx.Query("from"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return from, from, problem.InvalidParameter("from", "Parameter from must be RFC 3339 timestamp.").WithCause(err)
+		}
+		from = parsed
+	}
+
+	to := from.Add(7 * 24 * time.Hour)
+	if value := ctx.Query("to"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return from, to, problem.InvalidParameter("to", "Parameter to must be RFC 3339 timestamp.").WithCause(err)
+		}
+		to = parsed
+	}
+
+	if !to.After(from) {
+		return from, to, problem.InvalidParameter("to", "Parameter to must be after from.")
+	}
+	return from, to, nil
+}
+
+// wantsICalendar checks if the client asks for iCalendar export instead of JSON
+func wantsICalendar(ctx *gin.Context) bool {
+	return ctx.Query("format") == "ics" || strings.Contains(ctx.GetHeader("Accept"), icsMediaType)
+}
+
+// reservationsToICalendar renders the reservations as RFC 5545 calendar
+func reservationsToICalendar(reservations []Reservation, equipmentNames map[string]string) string {
+	var builder strings.Builder
+	line := func(format string, args ...interface{}) {
+		builder.WriteString(foldICalendarLine(fmt.Sprintf(format, args...)))
+		builder.WriteString("\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//FPJP//Hospital Equipment Management//EN")
+	line("CALSCALE:GREGORIAN")
+	for _, reservation := range reservations {
+		name := equipmentNames[reservation.EquipmentId]
+		if name == "" {
+			name = reservation.EquipmentId
+		}
+		line("BEGIN:VEVENT")
+		line("UID:%s@fpjp-ambulance-webapi", reservation.Id)
+		line("DTSTAMP:%s", reservation.CreatedAt.UTC().Format(icsTimeFormat))
+		line("DTSTART:%s", reservation.Start.UTC().Format(icsTimeFormat))
+		line("DTEND:%s", reservation.End.UTC().Format(icsTimeFormat))
+		line("SUMMARY:%s", escapeICalendarText(fmt.Sprintf("%s (%d)", name, reservation.Count)))
+		line("DESCRIPTION:%s", escapeICalendarText(fmt.Sprintf("%s\nReserved by: %s", reservation.Purpose, reservation.ReservedBy)))
+		line("LOCATION:%s", escapeICalendarText(reservation.Room))
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	return builder.String()
+}
+
+func escapeICalendarText(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(text)
+}
+
+// foldICalendarLine splits lines longer than 75 octets without breaking UTF-8 sequences
+func foldICalendarLine(line string) string {
+	const limit = 75
+	if len(line) <= limit {
+		return line
+	}
+
+	var builder strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > limit {
+			builder.WriteString("\r\n ")
+			width = 1
+		}
+		builder.WriteRune(r)
+		width += size
+	}
+	return builder.String()
+}
@@ -0,0 +1,148 @@
+package fpjp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestPeakReserved(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2025, 1, 1, hour, 0, 0, 0, time.UTC) }
+	reservations := []*Reservation{
+		{Start: at(8), End: at(10), Count: 1},
+		{Start: at(9), End: at(11), Count: 2},
+		{Start: at(11), End: at(12), Count: 1},
+	}
+
+	tests := map[string]struct {
+		start, end time.Time
+		expected   int32
+	}{
+		"overlap of two reservations":   {at(8), at(12), 3},
+		"single reservation":            {at(11), at(12), 1},
+		"reservation ending at start":   {at(10), at(11), 2},
+		"before all reservations":       {at(6), at(8), 0},
+		"inside of single reservation":  {at(8), at(9), 1},
+		"reservations following in row": {at(10), at(12), 2},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			filtered := []*Reservation{}
+			for _, reservation := range reservations {
+				if reservation.Start.Before(test.end) && reservation.End.After(test.start) {
+					filtered = append(filtered, reservation)
+				}
+			}
+			if peak := peakReserved(filtered, test.start, test.end); peak != test.expected {
+				t.Errorf("expected peak %v, got %v", test.expected, peak)
+			}
+		})
+	}
+}
+
+func TestReservationRejectsOverlap(t *testing.T) {
+	server := newTestServer(t)
+	server.addEquipment(t, Equipment{Id: "eq-1", Room: "room-1", Type: "monitor", Name: "Monitor", Count: 2})
+
+	reserve := func(start string, end string, count int) *Reservation {
+		body := fmt.Sprintf(`{"room": "room-2", "purpose": "surgery", "start": %q, "end": %q, "count": %v}`, start, end, count)
+		response := server.serve(http.MethodPost, "/api/equipment/eq-1/reservations", "application/json", body)
+		if response.Code != http.StatusCreated {
+			if code := problemCode(t, response, http.StatusConflict); code != codeNotAvailable {
+				t.Errorf("unexpected problem %v", code)
+			}
+			return nil
+		}
+		reservation := decode[Reservation](t, response, http.StatusCreated)
+		return &reservation
+	}
+
+	if reserve("2025-01-01T08:00:00Z", "2025-01-01T10:00:00Z", 1) == nil {
+		t.Fatal("first reservation should succeed")
+	}
+	if reserve("2025-01-01T09:00:00Z", "2025-01-01T11:00:00Z", 1) == nil {
+		t.Fatal("second item should be available")
+	}
+	if reserve("2025-01-01T09:30:00Z", "2025-01-01T09:45:00Z", 1) != nil {
+		t.Error("both items are reserved at the time")
+	}
+	if reserve("2025-01-01T10:00:00Z", "2025-01-01T12:00:00Z", 1) == nil {
+		t.Error("reservation following the first one should succeed")
+	}
+	if reserve("2025-01-01T12:00:00Z", "2025-01-01T13:00:00Z", 3) != nil {
+		t.Error("more items than available should not be reserved")
+	}
+}
+
+func TestReservationDefaults(t *testing.T) {
+	server := newTestServer(t)
+	server.addEquipment(t, Equipment{Id: "eq-1", Room: "room-1", Type: "monitor", Name: "Monitor", Count: 1})
+
+	response := server.serve(http.MethodPost, "/api/equipment/eq-1/reservations", "application/json",
+		`{"room": "room-2", "purpose": "surgery", "start": "2025-01-01T08:00:00Z", "end": "2025-01-01T10:00:00Z"}`)
+
+	reservation := decode[Reservation](t, response, http.StatusCreated)
+	if reservation.Count != 1 || reservation.EquipmentId != "eq-1" || reservation.ReservedBy != "nurse" || reservation.Id == "" {
+		t.Errorf("unexpected reservation %+v", reservation)
+	}
+}
+
+func TestReservationOfMissingRoomOrEquipment(t *testing.T) {
+	server := newTestServer(t)
+	server.addEquipment(t, Equipment{Id: "eq-1", Room: "room-1", Type: "monitor", Name: "Monitor", Count: 1})
+	body := `{"room": "%v", "purpose": "surgery", "start": "2025-01-01T08:00:00Z", "end": "2025-01-01T10:00:00Z"}`
+
+	response := server.serve(http.MethodPost, "/api/equipment/eq-1/reservations", "application/json", fmt.Sprintf(body, "room-9"))
+	problemCode(t, response, http.StatusBadRequest)
+
+	response = server.serve(http.MethodPost, "/api/equipment/eq-9/reservations", "application/json", fmt.Sprintf(body, "room-2"))
+	problemCode(t, response, http.StatusNotFound)
+}
+
+func TestConcurrentReservationsDoNotOverbook(t *testing.T) {
+	for name, transactions := range map[string]bool{"transactions": true, "standalone": false} {
+		t.Run(name, func(t *testing.T) {
+			server := newTestServer(t)
+			server.store.TransactionsNotSupported = !transactions
+			server.addEquipment(t, Equipment{Id: "eq-1", Room: "room-1", Type: "monitor", Name: "Monitor", Count: 2})
+			// widens the window between the overlap check and the creation of the reservation
+			server.inventory.config.Reservations = slowService[Reservation]{server.reservations}
+
+			var wait sync.WaitGroup
+			for i := 0; i < 10; i++ {
+				wait.Add(1)
+				go func() {
+					defer wait.Done()
+					server.serve(http.MethodPost, "/api/equipment/eq-1/reservations", "application/json",
+						`{"room": "room-2", "purpose": "surgery", "start": "2025-01-01T08:00:00Z", "end": "2025-01-01T10:00:00Z"}`)
+				}()
+			}
+			wait.Wait()
+
+			reservations, err := server.reservations.FindDocuments(context.Background(), bson.M{"equipmentId": "eq-1"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(reservations) != 2 {
+				t.Errorf("expected 2 reservations, got %v", len(reservations))
+			}
+		})
+	}
+}
+
+// slowService delays the results of the queries, so that the concurrent requests act on the outdated results
+type slowService[DocType interface{}] struct {
+	db_service.DbService[DocType]
+}
+
+func (this slowService[DocType]) FindDocuments(ctx context.Context, filter bson.M) ([]*DocType, error) {
+	documents, err := this.DbService.FindDocuments(ctx, filter)
+	time.Sleep(10 * time.Millisecond)
+	return documents, err
+}
@@ -14,8 +14,13 @@ import (
     "github.com/gin-gonic/gin"
 )
 
+// AddRoutes registers the operations under the base path of the first server of the spec
 func AddRoutes(engine *gin.Engine) {
-  group := engine.Group("/api")
+  AddRoutesAt(engine.Group("/api/v1"))
+}
+
+// AddRoutesAt registers the operations to the group, e.g. under an alias of the base path
+func AddRoutesAt(group *gin.RouterGroup) {
   
   {
     api := newEquipmentAndRequestsManagementAPI()
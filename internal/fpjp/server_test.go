@@ -0,0 +1,127 @@
+package fpjp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service/dbtest"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/events"
+)
+
+// testServer serves the API the same way main does, with the services kept in memory
+type testServer struct {
+	engine       *gin.Engine
+	inventory    *Inventory
+	store        *dbtest.MemoryStore
+	rooms        db_service.DbService[Room]
+	equipment    db_service.DbService[Equipment]
+	requests     db_service.DbService[Request]
+	movements    db_service.DbService[StockMovement]
+	reservations db_service.DbService[Reservation]
+}
+
+// newTestServer creates the server with department dep-1 and its rooms room-1 and room-2
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	store := dbtest.NewMemoryStore()
+	config := dbtest.MemoryServiceConfig{Store: store}
+	versioned := dbtest.MemoryServiceConfig{Store: store, Versioned: true}
+	departments := dbtest.NewMemoryService[Department](config)
+	server := &testServer{
+		engine:       gin.New(),
+		store:        store,
+		rooms:        dbtest.NewMemoryService[Room](config),
+		equipment:    dbtest.NewMemoryService[Equipment](versioned),
+		requests:     dbtest.NewMemoryService[Request](versioned),
+		movements:    dbtest.NewMemoryService[StockMovement](config),
+		reservations: dbtest.NewMemoryService[Reservation](config),
+	}
+	server.inventory = NewInventory(InventoryConfig{
+		Departments:      departments,
+		Rooms:            server.rooms,
+		Equipment:        server.equipment,
+		Requests:         server.requests,
+		Movements:        server.movements,
+		Reservations:     server.reservations,
+		Locks:            dbtest.NewMemoryService[Lock](config),
+		EquipmentChanges: events.NewBroker[Equipment]("equipment"),
+		RequestChanges:   events.NewBroker[Request]("requests"),
+		Transactions:     store.WithTransaction,
+	})
+
+	seed(t, departments.CreateDocument(context.Background(), "dep-1", &Department{Id: "dep-1", Name: "Surgery"}))
+	seed(t, server.rooms.CreateDocument(context.Background(), "room-1", &Room{Id: "room-1", DepartmentId: "dep-1", Name: "Operating room"}))
+	seed(t, server.rooms.CreateDocument(context.Background(), "room-2", &Room{Id: "room-2", DepartmentId: "dep-1", Name: "Recovery room"}))
+
+	server.engine.Use(func(ctx *gin.Context) {
+		ctx.Set("equipment_service", server.equipment)
+		ctx.Set("request_service", server.requests)
+		ctx.Set("room_service", server.rooms)
+		ctx.Set("movement_service", server.movements)
+		ctx.Set("reservation_service", server.reservations)
+		ctx.Set("inventory", server.inventory)
+		ctx.Next()
+	})
+	AddRoutesAt(server.engine.Group("/api"))
+	return server
+}
+
+func seed(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("cannot seed test data: %v", err)
+	}
+}
+
+// serve sends the request with the JSON body, or with no body when it is empty
+func (this *testServer) serve(method string, path string, contentType string, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+	request.Header.Set(userHeader, "nurse")
+	recorder := httptest.NewRecorder()
+	this.engine.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func (this *testServer) addEquipment(t *testing.T, equipment Equipment) {
+	t.Helper()
+	seed(t, this.equipment.CreateDocument(context.Background(), equipment.Id, &equipment))
+}
+
+// decode reads the JSON response after checking its status
+func decode[T interface{}](t *testing.T, response *httptest.ResponseRecorder, status int) T {
+	t.Helper()
+	if response.Code != status {
+		t.Fatalf("expected status %v, got %v: %v", status, response.Code, response.Body.String())
+	}
+	var value T
+	if err := json.Unmarshal(response.Body.Bytes(), &value); err != nil {
+		t.Fatalf("cannot decode response %q: %v", response.Body.String(), err)
+	}
+	return value
+}
+
+// failingService fails creation of the documents, e.g. to check that the transaction is rolled back
+type failingService[DocType interface{}] struct {
+	db_service.DbService[DocType]
+}
+
+func (this failingService[DocType]) CreateDocument(ctx context.Context, id string, document *DocType) error {
+	return errors.New("database is not available")
+}
+
+// problemCode extracts the code of the problem response
+func problemCode(t *testing.T, response *httptest.ResponseRecorder, status int) string {
+	t.Helper()
+	return decode[map[string]interface{}](t, response, status)["code"].(string)
+}
@@ -0,0 +1,106 @@
+package fpjp
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestAdjustmentChangesCountAndRecordsMovement(t *testing.T) {
+	server := newTestServer(t)
+	server.addEquipment(t, Equipment{Id: "eq-1", Room: "room-1", Type: "gloves", Name: "Gloves", Count: 10})
+
+	response := server.serve(http.MethodPost, "/api/equipment/eq-1/adjustments", "application/json",
+		`{"delta": -4, "reason": "consumed", "note": "surgery"}`)
+
+	movement := decode[StockMovement](t, response, http.StatusCreated)
+	if movement.CountAfter != 6 || movement.RecordedBy != "nurse" {
+		t.Errorf("unexpected movement %+v", movement)
+	}
+	ledger := decode[StockLedger](t, server.serve(http.MethodGet, "/api/equipment/eq-1/adjustments", "", ""), http.StatusOK)
+	if len(ledger.Movements) != 1 || ledger.Total != -4 || ledger.TotalsByReason["consumed"] != -4 {
+		t.Errorf("unexpected ledger %+v", ledger)
+	}
+}
+
+func TestAdjustmentCannotMakeCountNegative(t *testing.T) {
+	server := newTestServer(t)
+	server.addEquipment(t, Equipment{Id: "eq-1", Room: "room-1", Type: "gloves", Name: "Gloves", Count: 3})
+
+	response := server.serve(http.MethodPost, "/api/equipment/eq-1/adjustments", "application/json",
+		`{"delta": -4, "reason": "lost"}`)
+
+	if code := problemCode(t, response, http.StatusConflict); code != codeInsufficientStock {
+		t.Errorf("unexpected problem %v", code)
+	}
+	assertStock(t, server, "eq-1", 3, 0)
+}
+
+func TestAdjustmentSignMustMatchReason(t *testing.T) {
+	server := newTestServer(t)
+	server.addEquipment(t, Equipment{Id: "eq-1", Room: "room-1", Type: "gloves", Name: "Gloves", Count: 3})
+
+	response := server.serve(http.MethodPost, "/api/equipment/eq-1/adjustments", "application/json",
+		`{"delta": 2, "reason": "consumed"}`)
+
+	if code := problemCode(t, response, http.StatusBadRequest); code != codeReasonMismatch {
+		t.Errorf("unexpected problem %v", code)
+	}
+	assertStock(t, server, "eq-1", 3, 0)
+}
+
+func TestAdjustmentReasonMustBeKnown(t *testing.T) {
+	server := newTestServer(t)
+	server.addEquipment(t, Equipment{Id: "eq-1", Room: "room-1", Type: "gloves", Name: "Gloves", Count: 3})
+
+	_, err := server.inventory.AdjustEquipment(context.Background(), Caller{User: "nurse"}, "eq-1",
+		&StockAdjustment{Delta: -2, Reason: "stolen"})
+
+	checkFieldErrors(t, err, []string{"reason"})
+	assertStock(t, server, "eq-1", 3, 0)
+}
+
+func TestAdjustmentIsRolledBackWhenLedgerFails(t *testing.T) {
+	server := newTestServer(t)
+	server.addEquipment(t, Equipment{Id: "eq-1", Room: "room-1", Type: "gloves", Name: "Gloves", Count: 3})
+	server.inventory.config.Movements = failingService[StockMovement]{server.movements}
+
+	response := server.serve(http.MethodPost, "/api/equipment/eq-1/adjustments", "application/json",
+		`{"delta": 5, "reason": "restocked"}`)
+
+	problemCode(t, response, http.StatusBadGateway)
+	assertStock(t, server, "eq-1", 3, 0)
+}
+
+func TestAdjustmentWithoutTransactions(t *testing.T) {
+	server := newTestServer(t)
+	server.store.TransactionsNotSupported = true
+	server.addEquipment(t, Equipment{Id: "eq-1", Room: "room-1", Type: "gloves", Name: "Gloves", Count: 3})
+
+	response := server.serve(http.MethodPost, "/api/equipment/eq-1/adjustments", "application/json",
+		`{"delta": 5, "reason": "restocked"}`)
+
+	decode[StockMovement](t, response, http.StatusCreated)
+	assertStock(t, server, "eq-1", 8, 1)
+}
+
+// assertStock checks the count of the equipment and the number of its recorded movements
+func assertStock(t *testing.T, server *testServer, equipmentId string, count int32, movements int) {
+	t.Helper()
+	equipment, err := server.equipment.FindDocument(context.Background(), equipmentId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if equipment.Count != count {
+		t.Errorf("expected count %v, got %v", count, equipment.Count)
+	}
+	recorded, err := server.movements.FindDocuments(context.Background(), bson.M{"equipmentId": equipmentId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recorded) != movements {
+		t.Errorf("expected %v movements, got %v", movements, len(recorded))
+	}
+}
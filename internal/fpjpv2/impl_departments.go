@@ -0,0 +1,100 @@
+package fpjpv2
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/fpjp"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/problem"
+)
+
+// GetDepartmentEquipment - Provides list of all equipment in a department
+func (this *implEquipmentAndRequestsManagementAPI) GetDepartmentEquipment(ctx *gin.Context) {
+	slog.DebugContext(ctx, "Handling request", "operation", "GetDepartmentEquipment", "version", 2)
+
+	inventory, err := fpjp.LookupInventory(ctx)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+
+	departmentId := ctx.Param("departmentId")
+	includeDeleted, _ := strconv.ParseBool(ctx.DefaultQuery("includeDeleted", "false"))
+
+	// historical inventory is reconstructed from equipment versions
+	var asOf *time.Time
+	if value := ctx.Query("asOf"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			problem.Respond(ctx, problem.InvalidParameter("asOf", "Parameter asOf must be RFC 3339 timestamp."))
+			return
+		}
+		asOf = &parsed
+	}
+
+	contents, err := inventory.DepartmentEquipment(ctx, fpjp.RequestCaller(ctx), departmentId, includeDeleted, asOf)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+
+	// rooms are always listed, also those without any equipment
+	response := DepartmentEquipment{
+		Id:    contents.Department.Id,
+		Name:  contents.Department.Name,
+		Rooms: make([]RoomEquipment, len(contents.Rooms)),
+	}
+	index := make(map[string]*RoomEquipment, len(contents.Rooms))
+	for i, room := range contents.Rooms {
+		response.Rooms[i] = RoomEquipment{Id: room.Id, Name: room.Name, Equipment: []Equipment{}}
+		index[room.Id] = &response.Rooms[i]
+	}
+	for _, equipment := range contents.Equipment {
+		if room, ok := index[equipment.Room]; ok {
+			room.Equipment = append(room.Equipment, Equipment(*equipment))
+		}
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetDepartmentRequests - Provides list of all requests in a department
+func (this *implEquipmentAndRequestsManagementAPI) GetDepartmentRequests(ctx *gin.Context) {
+	slog.DebugContext(ctx, "Handling request", "operation", "GetDepartmentRequests", "version", 2)
+
+	inventory, err := fpjp.LookupInventory(ctx)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+
+	departmentId := ctx.Param("departmentId")
+	includeDeleted, _ := strconv.ParseBool(ctx.DefaultQuery("includeDeleted", "false"))
+
+	contents, err := inventory.DepartmentRequests(ctx, fpjp.RequestCaller(ctx), departmentId, includeDeleted)
+	if err != nil {
+		problem.Respond(ctx, err)
+		return
+	}
+
+	response := DepartmentRequests{
+		Id:    contents.Department.Id,
+		Name:  contents.Department.Name,
+		Rooms: make([]RoomRequests, len(contents.Rooms)),
+	}
+	index := make(map[string]*RoomRequests, len(contents.Rooms))
+	for i, room := range contents.Rooms {
+		response.Rooms[i] = RoomRequests{Id: room.Id, Name: room.Name, Requests: []Request{}}
+		index[room.Id] = &response.Rooms[i]
+	}
+	for _, request := range contents.Requests {
+		if room, ok := index[request.Room]; ok {
+			room.Requests = append(room.Requests, Request(*request))
+		}
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,109 @@
+package fpjpv2
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/fpjp"
+)
+
+// operations not redesigned by version 2 behave exactly as in version 1
+var v1 = fpjp.NewEquipmentAndRequestsManagementAPI()
+
+// AddEquipmentAdjustment - Atomically changes count of specific equipment
+func (this *implEquipmentAndRequestsManagementAPI) AddEquipmentAdjustment(ctx *gin.Context) {
+	v1.AddEquipmentAdjustment(ctx)
+}
+
+// AddRoomEquipment - Adds new equipment to a room
+func (this *implEquipmentAndRequestsManagementAPI) AddRoomEquipment(ctx *gin.Context) {
+	v1.AddRoomEquipment(ctx)
+}
+
+// AddRoomRequest - Adds new request to a room
+func (this *implEquipmentAndRequestsManagementAPI) AddRoomRequest(ctx *gin.Context) {
+	v1.AddRoomRequest(ctx)
+}
+
+// CancelReservation - Cancels specific reservation
+func (this *implEquipmentAndRequestsManagementAPI) CancelReservation(ctx *gin.Context) {
+	v1.CancelReservation(ctx)
+}
+
+// CreateEquipmentReservation - Reserves specific equipment for a time range
+func (this *implEquipmentAndRequestsManagementAPI) CreateEquipmentReservation(ctx *gin.Context) {
+	v1.CreateEquipmentReservation(ctx)
+}
+
+// DeleteEquipment - Deletes specific equipment
+func (this *implEquipmentAndRequestsManagementAPI) DeleteEquipment(ctx *gin.Context) {
+	v1.DeleteEquipment(ctx)
+}
+
+// DeleteRequest - Deletes specific request
+func (this *implEquipmentAndRequestsManagementAPI) DeleteRequest(ctx *gin.Context) {
+	v1.DeleteRequest(ctx)
+}
+
+// ExecuteBatch - Executes multiple operations on equipment and requests
+func (this *implEquipmentAndRequestsManagementAPI) ExecuteBatch(ctx *gin.Context) {
+	v1.ExecuteBatch(ctx)
+}
+
+// GetDepartmentReservations - Provides availability calendar of equipment in a department
+func (this *implEquipmentAndRequestsManagementAPI) GetDepartmentReservations(ctx *gin.Context) {
+	v1.GetDepartmentReservations(ctx)
+}
+
+// GetDepartments - Provides list of all departments
+func (this *implEquipmentAndRequestsManagementAPI) GetDepartments(ctx *gin.Context) {
+	v1.GetDepartments(ctx)
+}
+
+// GetEquipmentAdjustments - Provides stock movement ledger of specific equipment
+func (this *implEquipmentAndRequestsManagementAPI) GetEquipmentAdjustments(ctx *gin.Context) {
+	v1.GetEquipmentAdjustments(ctx)
+}
+
+// GetEquipmentReservations - Provides reservations of specific equipment
+func (this *implEquipmentAndRequestsManagementAPI) GetEquipmentReservations(ctx *gin.Context) {
+	v1.GetEquipmentReservations(ctx)
+}
+
+// GetEquipmentVersions - Provides history of changes of specific equipment
+func (this *implEquipmentAndRequestsManagementAPI) GetEquipmentVersions(ctx *gin.Context) {
+	v1.GetEquipmentVersions(ctx)
+}
+
+// GetRequestVersions - Provides history of changes of specific request
+func (this *implEquipmentAndRequestsManagementAPI) GetRequestVersions(ctx *gin.Context) {
+	v1.GetRequestVersions(ctx)
+}
+
+// PatchEquipment - Partially updates specific equipment
+func (this *implEquipmentAndRequestsManagementAPI) PatchEquipment(ctx *gin.Context) {
+	v1.PatchEquipment(ctx)
+}
+
+// PatchRequest - Partially updates specific request
+func (this *implEquipmentAndRequestsManagementAPI) PatchRequest(ctx *gin.Context) {
+	v1.PatchRequest(ctx)
+}
+
+// RestoreEquipment - Restores previously deleted equipment
+func (this *implEquipmentAndRequestsManagementAPI) RestoreEquipment(ctx *gin.Context) {
+	v1.RestoreEquipment(ctx)
+}
+
+// RestoreRequest - Restores previously deleted request
+func (this *implEquipmentAndRequestsManagementAPI) RestoreRequest(ctx *gin.Context) {
+	v1.RestoreRequest(ctx)
+}
+
+// UpdateEquipment - Updates specific equipment
+func (this *implEquipmentAndRequestsManagementAPI) UpdateEquipment(ctx *gin.Context) {
+	v1.UpdateEquipment(ctx)
+}
+
+// UpdateRequest - Updates specific request
+func (this *implEquipmentAndRequestsManagementAPI) UpdateRequest(ctx *gin.Context) {
+	v1.UpdateRequest(ctx)
+}
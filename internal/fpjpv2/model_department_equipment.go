@@ -0,0 +1,13 @@
+package fpjpv2
+
+type DepartmentEquipment struct {
+
+	// Unique identifier of the department
+	Id string `json:"id"`
+
+	// Name of the department
+	Name string `json:"name"`
+
+	// Rooms of the department with the equipment located in them
+	Rooms []RoomEquipment `json:"rooms"`
+}
@@ -0,0 +1,29 @@
+package fpjpv2
+
+import (
+	"time"
+)
+
+type Equipment struct {
+
+	// Unique identifier of the equipment
+	Id string `json:"id"`
+
+	// Identifier of the room the equipment belongs to
+	Room string `json:"room" binding:"required"`
+
+	// Type of the equipment
+	Type string `json:"type" binding:"required"`
+
+	// Name of the equipment
+	Name string `json:"name" binding:"required"`
+
+	// Number of equipment items available
+	Count int32 `json:"count" binding:"required"`
+
+	// Time when the equipment was deleted, present only for deleted equipment
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+
+	// Identity of the user who deleted the equipment
+	DeletedBy string `json:"deletedBy,omitempty"`
+}
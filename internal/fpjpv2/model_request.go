@@ -0,0 +1,32 @@
+package fpjpv2
+
+import (
+	"time"
+)
+
+type Request struct {
+
+	// Unique identifier of the request
+	Id string `json:"id"`
+
+	// Identifier of the room the request is associated with
+	Room string `json:"room"`
+
+	// Type of the request
+	Type string `json:"type"`
+
+	// Name of the equipment requested or to be repaired
+	Name string `json:"name"`
+
+	// Number of items requested (only applicable for missing-equipment requests)
+	Count *int32 `json:"count,omitempty"`
+
+	// Detailed description of the request
+	Description string `json:"description"`
+
+	// Time when the request was deleted, present only for deleted requests
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+
+	// Identity of the user who deleted the request
+	DeletedBy string `json:"deletedBy,omitempty"`
+}
@@ -0,0 +1,13 @@
+package fpjpv2
+
+type RoomEquipment struct {
+
+	// Unique identifier of the room
+	Id string `json:"id"`
+
+	// Name of the room
+	Name string `json:"name"`
+
+	// Equipment located in the room
+	Equipment []Equipment `json:"equipment"`
+}
@@ -0,0 +1,13 @@
+package fpjpv2
+
+type RoomRequests struct {
+
+	// Unique identifier of the room
+	Id string `json:"id"`
+
+	// Name of the room
+	Name string `json:"name"`
+
+	// Requests associated with the room
+	Requests []Request `json:"requests"`
+}
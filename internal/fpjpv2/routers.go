@@ -0,0 +1,20 @@
+package fpjpv2
+
+import (
+    "github.com/gin-gonic/gin"
+)
+
+// AddRoutes registers the operations under the base path of the first server of the spec
+func AddRoutes(engine *gin.Engine) {
+  AddRoutesAt(engine.Group("/api/v2"))
+}
+
+// AddRoutesAt registers the operations to the group, e.g. under an alias of the base path
+func AddRoutesAt(group *gin.RouterGroup) {
+  
+  {
+    api := newEquipmentAndRequestsManagementAPI()
+    api.addRoutes(group)
+  }
+  
+}
\ No newline at end of file
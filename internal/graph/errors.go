@@ -0,0 +1,67 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/problem"
+)
+
+// resolverError carries the problem to the client as extensions of the GraphQL error,
+// so the clients can rely on the same stable codes as the clients of the REST API
+type resolverError struct {
+	problem *problem.Problem
+}
+
+func (this *resolverError) Error() string {
+	return this.problem.Detail
+}
+
+func (this *resolverError) Extensions() map[string]interface{} {
+	extensions := map[string]interface{}{
+		"code":   this.problem.Code,
+		"status": this.problem.Status,
+	}
+	if len(this.problem.Errors) > 0 {
+		extensions["errors"] = this.problem.Errors
+	}
+	return extensions
+}
+
+// fail converts the error returned by the resolver, server side failures are logged together with their cause
+func fail(ctx context.Context, err error) error {
+	failure := problem.From(err)
+	if failure.Status >= http.StatusInternalServerError {
+		slog.ErrorContext(ctx, "GraphQL resolver failed", "code", failure.Code, "error", failure)
+	}
+	return &resolverError{problem: failure}
+}
+
+// dbError translates error of the database service related to the resource
+func dbError(err error, resource string) *problem.Problem {
+	noun := strings.ToUpper(resource[:1]) + resource[1:]
+	switch {
+	case errors.Is(err, db_service.ErrNotFound):
+		return problem.NotFound(resource, fmt.Sprintf("%v with provided ID was not found.", noun))
+	case errors.Is(err, db_service.ErrConflict):
+		return problem.New(http.StatusConflict, problem.CodeAlreadyExists, fmt.Sprintf("%v with provided ID already exists.", noun))
+	default:
+		return problem.Database(err)
+	}
+}
+
+// isNotFound checks if the service reported missing document, the nullable fields resolve it to null
+func isNotFound(err error) bool {
+	var failure *problem.Problem
+	return errors.As(err, &failure) && failure.Status == http.StatusNotFound
+}
+
+// adminOnly reports that the operation requires admin role
+func adminOnly(detail string) *problem.Problem {
+	return problem.New(http.StatusForbidden, problem.CodeForbidden, detail)
+}
@@ -0,0 +1,153 @@
+package graph
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/fpjp"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/problem"
+)
+
+//go:embed schema.graphql
+var schemaDefinition string
+
+// maximal nesting of the selections, department → rooms → equipment → room → ... is limited by it
+const maxDepth = 8
+
+// interval of the comments keeping idle subscription open through the proxies
+const heartbeatInterval = 15 * time.Second
+
+// Services are the database services read by the batched loaders and the inventory
+// which applies the business rules to the mutations and streams the changes
+type Services struct {
+	Departments db_service.DbService[fpjp.Department]
+	Rooms       db_service.DbService[fpjp.Room]
+	Equipment   db_service.DbService[fpjp.Equipment]
+	Requests    db_service.DbService[fpjp.Request]
+	Inventory   *fpjp.Inventory
+}
+
+// Handler serves the GraphQL endpoint, queries and mutations are answered by JSON,
+// subscriptions are streamed as server-sent events (GraphQL over SSE, distinct connections mode)
+type Handler struct {
+	schema   *graphql.Schema
+	services Services
+	// done when the service shuts down, it ends the running subscriptions
+	shutdown context.Context
+	close    context.CancelFunc
+}
+
+func NewHandler(services Services) (*Handler, error) {
+	schema, err := graphql.ParseSchema(
+		schemaDefinition,
+		&resolver{services: services},
+		graphql.UseStringDescriptions(),
+		graphql.MaxDepth(maxDepth),
+		graphql.Logger(panicLogger{}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse GraphQL schema: %w", err)
+	}
+
+	shutdown, close := context.WithCancel(context.Background())
+	return &Handler{
+		schema:   schema,
+		services: services,
+		shutdown: shutdown,
+		close:    close,
+	}, nil
+}
+
+// Close ends the running subscriptions so that the server can shut down
+func (this *Handler) Close() {
+	this.close()
+}
+
+type operation struct {
+	Query         string                 `json:"query" binding:"required"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Handle executes the operation from the body of POST request
+func (this *Handler) Handle(ctx *gin.Context) {
+	request := operation{}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		problem.Respond(ctx, problem.InvalidBody(err))
+		return
+	}
+	slog.DebugContext(ctx, "Handling GraphQL operation", "operationName", request.OperationName)
+
+	operationCtx := withIdentity(ctx.Request.Context(), fpjp.RequestCaller(ctx))
+
+	if strings.Contains(ctx.GetHeader("Accept"), "text/event-stream") {
+		this.stream(ctx, operationCtx, request)
+		return
+	}
+
+	operationCtx = withLoaders(operationCtx, newLoaders(this.services, true))
+	response := this.schema.Exec(operationCtx, request.Query, request.OperationName, request.Variables)
+	for _, err := range response.Errors {
+		// the library assumes websocket transport for the subscriptions
+		if err.Message == "graphql-ws protocol header is missing" {
+			err.Message = "Subscriptions are streamed as server-sent events, request them with Accept: text/event-stream header."
+		}
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// stream sends each result of the operation as next event followed by complete event
+func (this *Handler) stream(ctx *gin.Context, operationCtx context.Context, request operation) {
+	operationCtx, cancel := context.WithCancel(operationCtx)
+	defer cancel()
+	stop := context.AfterFunc(this.shutdown, cancel)
+	defer stop()
+
+	// the subscription must observe the changes, so the loaded documents are not cached
+	operationCtx = withLoaders(operationCtx, newLoaders(this.services, false))
+	responses, err := this.schema.Subscribe(operationCtx, request.Query, request.OperationName, request.Variables)
+	if err != nil {
+		problem.Respond(ctx, problem.Internal(err))
+		return
+	}
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	// disable response buffering by nginx
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+	ctx.Writer.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case response, ok := <-responses:
+			if !ok {
+				ctx.SSEvent("complete", "")
+				ctx.Writer.Flush()
+				return
+			}
+			ctx.SSEvent("next", response)
+			ctx.Writer.Flush()
+		case <-heartbeat.C:
+			ctx.Writer.WriteString(":\n\n")
+			ctx.Writer.Flush()
+		}
+	}
+}
+
+// panicLogger reports panics of the resolvers by the service logger
+type panicLogger struct{}
+
+func (panicLogger) LogPanic(ctx context.Context, value interface{}) {
+	slog.ErrorContext(ctx, "GraphQL resolver panicked", "error", value)
+}
@@ -0,0 +1,113 @@
+package graph
+
+import (
+	"context"
+	"time"
+
+	"github.com/graph-gophers/dataloader/v7"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/fpjp"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/problem"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// time to collect the keys requested by sibling fields before the batch is loaded
+const batchWait = 2 * time.Millisecond
+
+// loaders batch the lookups of related documents made while resolving single operation
+// into one FindDocuments call with $in filter, avoiding query per parent object
+type loaders struct {
+	departments        *dataloader.Loader[string, *fpjp.Department]
+	rooms              *dataloader.Loader[string, *fpjp.Room]
+	roomsByDepartment  *dataloader.Loader[string, []*fpjp.Room]
+	equipmentByRoom    *dataloader.Loader[string, []*fpjp.Equipment]
+	allEquipmentByRoom *dataloader.Loader[string, []*fpjp.Equipment]
+	requestsByRoom     *dataloader.Loader[string, []*fpjp.Request]
+	allRequestsByRoom  *dataloader.Loader[string, []*fpjp.Request]
+}
+
+type loadersKey struct{}
+
+// newLoaders creates loaders for single operation, the loaded documents are cached
+// unless the operation is long running subscription which must observe the changes
+func newLoaders(services Services, cache bool) *loaders {
+	return &loaders{
+		departments:        newLoader(cache, byId(services.Departments, "department", func(department *fpjp.Department) string { return department.Id })),
+		rooms:              newLoader(cache, byId(services.Rooms, "room", func(room *fpjp.Room) string { return room.Id })),
+		roomsByDepartment:  newLoader(cache, groupedBy(services.Rooms, "department_id", false, func(room *fpjp.Room) string { return room.DepartmentId })),
+		equipmentByRoom:    newLoader(cache, groupedBy(services.Equipment, "room", false, func(equipment *fpjp.Equipment) string { return equipment.Room })),
+		allEquipmentByRoom: newLoader(cache, groupedBy(services.Equipment, "room", true, func(equipment *fpjp.Equipment) string { return equipment.Room })),
+		requestsByRoom:     newLoader(cache, groupedBy(services.Requests, "room", false, func(request *fpjp.Request) string { return request.Room })),
+		allRequestsByRoom:  newLoader(cache, groupedBy(services.Requests, "room", true, func(request *fpjp.Request) string { return request.Room })),
+	}
+}
+
+func withLoaders(ctx context.Context, loaders *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, loaders)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+func newLoader[Value interface{}](cache bool, batch dataloader.BatchFunc[string, Value]) *dataloader.Loader[string, Value] {
+	options := []dataloader.Option[string, Value]{dataloader.WithWait[string, Value](batchWait)}
+	if !cache {
+		options = append(options, dataloader.WithCache[string, Value](&dataloader.NoCache[string, Value]{}))
+	}
+	return dataloader.NewBatchedLoader(batch, options...)
+}
+
+// byId loads the documents with the requested ids, missing documents are reported as not found
+func byId[DocType interface{}](service db_service.DbService[DocType], resource string, id func(*DocType) string) dataloader.BatchFunc[string, *DocType] {
+	return func(ctx context.Context, keys []string) []*dataloader.Result[*DocType] {
+		results := make([]*dataloader.Result[*DocType], len(keys))
+		documents, err := service.FindDocuments(ctx, bson.M{"id": bson.M{"$in": keys}})
+		if err != nil {
+			for i := range keys {
+				results[i] = &dataloader.Result[*DocType]{Error: dbError(err, resource)}
+			}
+			return results
+		}
+
+		index := make(map[string]*DocType, len(documents))
+		for _, document := range documents {
+			index[id(document)] = document
+		}
+		for i, key := range keys {
+			if document, ok := index[key]; ok {
+				results[i] = &dataloader.Result[*DocType]{Data: document}
+			} else {
+				results[i] = &dataloader.Result[*DocType]{Error: dbError(db_service.ErrNotFound, resource)}
+			}
+		}
+		return results
+	}
+}
+
+// groupedBy loads the documents referencing the requested keys by the field,
+// deleted documents are included only when requested
+func groupedBy[DocType interface{}](service db_service.DbService[DocType], field string, withDeleted bool, key func(*DocType) string) dataloader.BatchFunc[string, []*DocType] {
+	return func(ctx context.Context, keys []string) []*dataloader.Result[[]*DocType] {
+		if withDeleted {
+			ctx = db_service.WithDeleted(ctx)
+		}
+		results := make([]*dataloader.Result[[]*DocType], len(keys))
+		documents, err := service.FindDocuments(ctx, bson.M{field: bson.M{"$in": keys}})
+		if err != nil {
+			for i := range keys {
+				results[i] = &dataloader.Result[[]*DocType]{Error: problem.Database(err)}
+			}
+			return results
+		}
+
+		groups := make(map[string][]*DocType, len(keys))
+		for _, document := range documents {
+			groups[key(document)] = append(groups[key(document)], document)
+		}
+		for i, key := range keys {
+			results[i] = &dataloader.Result[[]*DocType]{Data: groups[key]}
+		}
+		return results
+	}
+}
@@ -0,0 +1,113 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service/dbtest"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/fpjp"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// countingService counts the queries of the collection
+type countingService[DocType interface{}] struct {
+	db_service.DbService[DocType]
+	queries *atomic.Int32
+}
+
+func (this countingService[DocType]) FindDocuments(ctx context.Context, filter bson.M) ([]*DocType, error) {
+	this.queries.Add(1)
+	return this.DbService.FindDocuments(ctx, filter)
+}
+
+func TestNestedListsAreLoadedInBatches(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	config := dbtest.MemoryServiceConfig{Store: dbtest.NewMemoryStore()}
+	var roomQueries, equipmentQueries atomic.Int32
+	services := Services{
+		Departments: dbtest.NewMemoryService[fpjp.Department](config),
+		Rooms:       countingService[fpjp.Room]{dbtest.NewMemoryService[fpjp.Room](config), &roomQueries},
+		Equipment:   countingService[fpjp.Equipment]{dbtest.NewMemoryService[fpjp.Equipment](config), &equipmentQueries},
+		Requests:    dbtest.NewMemoryService[fpjp.Request](config),
+	}
+
+	ctx := context.Background()
+	departments, rooms := 2, 0
+	for d := 0; d < departments; d++ {
+		departmentId := fmt.Sprintf("dep-%v", d)
+		seed(t, services.Departments.CreateDocument(ctx, departmentId, &fpjp.Department{Id: departmentId, Name: "Surgery"}))
+		for r := 0; r < 3; r++ {
+			roomId := fmt.Sprintf("room-%v-%v", d, r)
+			seed(t, services.Rooms.CreateDocument(ctx, roomId, &fpjp.Room{Id: roomId, DepartmentId: departmentId, Name: "Operating room"}))
+			equipmentId := "eq-" + roomId
+			seed(t, services.Equipment.CreateDocument(ctx, equipmentId,
+				&fpjp.Equipment{Id: equipmentId, Room: roomId, Type: "monitor", Name: "Monitor", Count: 1}))
+			rooms++
+		}
+	}
+
+	handler, err := NewHandler(services)
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine := gin.New()
+	engine.POST("/graphql", handler.Handle)
+	request := httptest.NewRequest(http.MethodPost, "/graphql",
+		strings.NewReader(`{"query": "{ departments { rooms { equipment { name room { id } } } } }"}`))
+	request.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, request)
+
+	response := struct {
+		Data struct {
+			Departments []struct {
+				Rooms []struct {
+					Equipment []struct {
+						Room struct{ Id string }
+					}
+				}
+			}
+		}
+		Errors []interface{}
+	}{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil || len(response.Errors) > 0 {
+		t.Fatalf("unexpected response %v: %v", err, recorder.Body.String())
+	}
+	resolved := 0
+	for _, department := range response.Data.Departments {
+		for _, room := range department.Rooms {
+			for _, equipment := range room.Equipment {
+				if equipment.Room.Id != "" {
+					resolved++
+				}
+			}
+		}
+	}
+	if resolved != rooms {
+		t.Errorf("expected equipment of %v rooms, got %v", rooms, resolved)
+	}
+
+	// the batches are collected for a short time, under heavy load they may be split,
+	// but never down to query per parent
+	if queries := roomQueries.Load(); queries >= int32(departments) {
+		t.Errorf("rooms of %v departments should be loaded in batch, got %v queries", departments, queries)
+	}
+	if queries := equipmentQueries.Load(); queries >= int32(rooms) {
+		t.Errorf("equipment of %v rooms should be loaded in batch, got %v queries", rooms, queries)
+	}
+}
+
+func seed(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,239 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/fpjp"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// resolver is the root of the schema, it resolves the queries, mutations and subscriptions
+type resolver struct {
+	services Services
+}
+
+type identityKey struct{}
+
+// withIdentity stores the caller identified by the headers set by the authenticating proxy
+func withIdentity(ctx context.Context, caller fpjp.Caller) context.Context {
+	return context.WithValue(ctx, identityKey{}, caller)
+}
+
+func identityFrom(ctx context.Context) fpjp.Caller {
+	caller, _ := ctx.Value(identityKey{}).(fpjp.Caller)
+	return caller
+}
+
+func (this *resolver) Departments(ctx context.Context, args struct{ Ids *[]graphql.ID }) ([]*departmentResolver, error) {
+	filter := bson.M{}
+	if args.Ids != nil {
+		ids := make([]string, len(*args.Ids))
+		for i, id := range *args.Ids {
+			ids[i] = string(id)
+		}
+		filter["id"] = bson.M{"$in": ids}
+	}
+
+	departments, err := this.services.Departments.FindDocuments(ctx, filter)
+	if err != nil {
+		return nil, fail(ctx, dbError(err, "department"))
+	}
+	resolvers := make([]*departmentResolver, len(departments))
+	for i, department := range departments {
+		resolvers[i] = &departmentResolver{department: department}
+	}
+	return resolvers, nil
+}
+
+func (this *resolver) Department(ctx context.Context, args struct{ Id graphql.ID }) (*departmentResolver, error) {
+	department, err := this.services.Departments.FindDocument(ctx, string(args.Id))
+	if errors.Is(err, db_service.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fail(ctx, dbError(err, "department"))
+	}
+	return &departmentResolver{department: department}, nil
+}
+
+func (this *resolver) Room(ctx context.Context, args struct{ Id graphql.ID }) (*roomResolver, error) {
+	room, err := this.services.Rooms.FindDocument(ctx, string(args.Id))
+	if errors.Is(err, db_service.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fail(ctx, dbError(err, "room"))
+	}
+	return &roomResolver{room: room}, nil
+}
+
+type documentArgs struct {
+	Id             graphql.ID
+	IncludeDeleted bool
+}
+
+func (this *resolver) Equipment(ctx context.Context, args documentArgs) (*equipmentResolver, error) {
+	equipment, err := this.services.Inventory.Equipment(ctx, identityFrom(ctx), string(args.Id), args.IncludeDeleted)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fail(ctx, err)
+	}
+	return &equipmentResolver{equipment: equipment}, nil
+}
+
+func (this *resolver) Request(ctx context.Context, args documentArgs) (*requestResolver, error) {
+	request, err := this.services.Inventory.Request(ctx, identityFrom(ctx), string(args.Id), args.IncludeDeleted)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fail(ctx, err)
+	}
+	return &requestResolver{request: request}, nil
+}
+
+type equipmentInput struct {
+	Room  graphql.ID
+	Type  string
+	Name  string
+	Count int32
+}
+
+func (this equipmentInput) equipment(id string) *fpjp.Equipment {
+	// deletion markers are managed by the service only
+	return &fpjp.Equipment{
+		Id:    id,
+		Room:  string(this.Room),
+		Type:  this.Type,
+		Name:  this.Name,
+		Count: this.Count,
+	}
+}
+
+func (this *resolver) AddRoomEquipment(ctx context.Context, args struct {
+	Id    *graphql.ID
+	Input equipmentInput
+}) (*equipmentResolver, error) {
+	slog.DebugContext(ctx, "Handling request", "operation", "AddRoomEquipment", "api", "graphql")
+
+	// new UUID is assigned when the ID is not provided
+	equipment := args.Input.equipment(optionalId(args.Id))
+	if err := this.services.Inventory.CreateEquipment(ctx, equipment); err != nil {
+		return nil, fail(ctx, err)
+	}
+	return &equipmentResolver{equipment: equipment}, nil
+}
+
+func (this *resolver) UpdateEquipment(ctx context.Context, args struct {
+	Id    graphql.ID
+	Input equipmentInput
+}) (*equipmentResolver, error) {
+	slog.DebugContext(ctx, "Handling request", "operation", "UpdateEquipment", "api", "graphql")
+
+	equipment := args.Input.equipment(string(args.Id))
+	if err := this.services.Inventory.UpdateEquipment(ctx, equipment); err != nil {
+		return nil, fail(ctx, err)
+	}
+	return &equipmentResolver{equipment: equipment}, nil
+}
+
+func (this *resolver) DeleteEquipment(ctx context.Context, args struct{ Id graphql.ID }) (bool, error) {
+	slog.DebugContext(ctx, "Handling request", "operation", "DeleteEquipment", "api", "graphql")
+
+	if err := this.services.Inventory.DeleteEquipment(ctx, identityFrom(ctx), string(args.Id)); err != nil {
+		return false, fail(ctx, err)
+	}
+	return true, nil
+}
+
+func (this *resolver) RestoreEquipment(ctx context.Context, args struct{ Id graphql.ID }) (*equipmentResolver, error) {
+	slog.DebugContext(ctx, "Handling request", "operation", "RestoreEquipment", "api", "graphql")
+
+	// only deleted equipment is found
+	equipment, err := this.services.Inventory.RestoreEquipment(ctx, string(args.Id))
+	if err != nil {
+		return nil, fail(ctx, err)
+	}
+	return &equipmentResolver{equipment: equipment}, nil
+}
+
+type requestInput struct {
+	Room        graphql.ID
+	Type        string
+	Name        string
+	Count       *int32
+	Description string
+}
+
+func (this requestInput) request(id string) *fpjp.Request {
+	// deletion markers are managed by the service only
+	return &fpjp.Request{
+		Id:          id,
+		Room:        string(this.Room),
+		Type:        requestTypeValue(this.Type),
+		Name:        this.Name,
+		Count:       this.Count,
+		Description: this.Description,
+	}
+}
+
+func (this *resolver) AddRoomRequest(ctx context.Context, args struct {
+	Id    *graphql.ID
+	Input requestInput
+}) (*requestResolver, error) {
+	slog.DebugContext(ctx, "Handling request", "operation", "AddRoomRequest", "api", "graphql")
+
+	// new UUID is assigned when the ID is not provided
+	request := args.Input.request(optionalId(args.Id))
+	if err := this.services.Inventory.CreateRequest(ctx, request); err != nil {
+		return nil, fail(ctx, err)
+	}
+	return &requestResolver{request: request}, nil
+}
+
+func (this *resolver) UpdateRequest(ctx context.Context, args struct {
+	Id    graphql.ID
+	Input requestInput
+}) (*requestResolver, error) {
+	slog.DebugContext(ctx, "Handling request", "operation", "UpdateRequest", "api", "graphql")
+
+	request := args.Input.request(string(args.Id))
+	if err := this.services.Inventory.UpdateRequest(ctx, request); err != nil {
+		return nil, fail(ctx, err)
+	}
+	return &requestResolver{request: request}, nil
+}
+
+func (this *resolver) DeleteRequest(ctx context.Context, args struct{ Id graphql.ID }) (bool, error) {
+	slog.DebugContext(ctx, "Handling request", "operation", "DeleteRequest", "api", "graphql")
+
+	if err := this.services.Inventory.DeleteRequest(ctx, identityFrom(ctx), string(args.Id)); err != nil {
+		return false, fail(ctx, err)
+	}
+	return true, nil
+}
+
+func (this *resolver) RestoreRequest(ctx context.Context, args struct{ Id graphql.ID }) (*requestResolver, error) {
+	slog.DebugContext(ctx, "Handling request", "operation", "RestoreRequest", "api", "graphql")
+
+	// only deleted request is found
+	request, err := this.services.Inventory.RestoreRequest(ctx, string(args.Id))
+	if err != nil {
+		return nil, fail(ctx, err)
+	}
+	return &requestResolver{request: request}, nil
+}
+
+// optionalId returns the provided ID or empty string when it is missing
+func optionalId(id *graphql.ID) string {
+	if id == nil {
+		return ""
+	}
+	return string(*id)
+}
@@ -0,0 +1,57 @@
+package graph
+
+import (
+	"context"
+	"strings"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/events"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/fpjp"
+)
+
+func (this *resolver) RequestChanged(ctx context.Context, args struct {
+	DepartmentId *graphql.ID
+	RoomId       *graphql.ID
+}) (<-chan *requestChangeResolver, error) {
+	// permanently removed requests are delivered only to the subscribers without filter
+	changes := this.services.Inventory.WatchRequests(ctx, fpjp.ChangeFilter{
+		DepartmentId: optionalId(args.DepartmentId),
+		RoomId:       optionalId(args.RoomId),
+	})
+	resolvers := make(chan *requestChangeResolver)
+
+	go func() {
+		defer close(resolvers)
+		for change := range changes {
+			select {
+			case resolvers <- &requestChangeResolver{change: change}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return resolvers, nil
+}
+
+type requestChangeResolver struct {
+	change events.Change[fpjp.Request]
+}
+
+func (this *requestChangeResolver) Operation() string {
+	return strings.ToUpper(string(this.change.Operation))
+}
+
+func (this *requestChangeResolver) ID() graphql.ID {
+	return graphql.ID(this.change.Id)
+}
+
+func (this *requestChangeResolver) Time() graphql.Time {
+	return graphql.Time{Time: this.change.Time}
+}
+
+func (this *requestChangeResolver) Request() *requestResolver {
+	if this.change.Document == nil {
+		return nil
+	}
+	return &requestResolver{request: this.change.Document}
+}
@@ -0,0 +1,231 @@
+package graph
+
+import (
+	"context"
+	"strings"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/fpjp"
+)
+
+type departmentResolver struct {
+	department *fpjp.Department
+}
+
+func (this *departmentResolver) ID() graphql.ID {
+	return graphql.ID(this.department.Id)
+}
+
+func (this *departmentResolver) Name() string {
+	return this.department.Name
+}
+
+func (this *departmentResolver) Rooms(ctx context.Context, args struct{ Ids *[]graphql.ID }) ([]*roomResolver, error) {
+	loaders := loadersFrom(ctx)
+	rooms, err := loaders.roomsByDepartment.Load(ctx, this.department.Id)()
+	if err != nil {
+		return nil, fail(ctx, err)
+	}
+
+	resolvers := []*roomResolver{}
+	for _, room := range rooms {
+		// equipment and requests in the rooms resolve their room without another query
+		loaders.rooms.Prime(ctx, room.Id, room)
+		if args.Ids == nil || containsId(*args.Ids, room.Id) {
+			resolvers = append(resolvers, &roomResolver{room: room})
+		}
+	}
+	return resolvers, nil
+}
+
+type roomResolver struct {
+	room *fpjp.Room
+}
+
+func (this *roomResolver) ID() graphql.ID {
+	return graphql.ID(this.room.Id)
+}
+
+func (this *roomResolver) Name() string {
+	return this.room.Name
+}
+
+func (this *roomResolver) Department(ctx context.Context) (*departmentResolver, error) {
+	department, err := loadersFrom(ctx).departments.Load(ctx, this.room.DepartmentId)()
+	if err != nil {
+		return nil, fail(ctx, err)
+	}
+	return &departmentResolver{department: department}, nil
+}
+
+type equipmentFilter struct {
+	Type           *string
+	Name           *string
+	MinCount       *int32
+	IncludeDeleted bool
+}
+
+func (this *roomResolver) Equipment(ctx context.Context, args equipmentFilter) ([]*equipmentResolver, error) {
+	loader := loadersFrom(ctx).equipmentByRoom
+	if args.IncludeDeleted {
+		if !identityFrom(ctx).Admin {
+			return nil, fail(ctx, adminOnly("Only administrators can list deleted equipment."))
+		}
+		loader = loadersFrom(ctx).allEquipmentByRoom
+	}
+	equipment, err := loader.Load(ctx, this.room.Id)()
+	if err != nil {
+		return nil, fail(ctx, err)
+	}
+
+	resolvers := []*equipmentResolver{}
+	for _, item := range equipment {
+		if args.Type != nil && item.Type != *args.Type {
+			continue
+		}
+		if args.Name != nil && !strings.Contains(strings.ToLower(item.Name), strings.ToLower(*args.Name)) {
+			continue
+		}
+		if args.MinCount != nil && item.Count < *args.MinCount {
+			continue
+		}
+		resolvers = append(resolvers, &equipmentResolver{equipment: item})
+	}
+	return resolvers, nil
+}
+
+type requestFilter struct {
+	Type           *string
+	IncludeDeleted bool
+}
+
+func (this *roomResolver) Requests(ctx context.Context, args requestFilter) ([]*requestResolver, error) {
+	loader := loadersFrom(ctx).requestsByRoom
+	if args.IncludeDeleted {
+		if !identityFrom(ctx).Admin {
+			return nil, fail(ctx, adminOnly("Only administrators can list deleted requests."))
+		}
+		loader = loadersFrom(ctx).allRequestsByRoom
+	}
+	requests, err := loader.Load(ctx, this.room.Id)()
+	if err != nil {
+		return nil, fail(ctx, err)
+	}
+
+	resolvers := []*requestResolver{}
+	for _, request := range requests {
+		if args.Type != nil && request.Type != requestTypeValue(*args.Type) {
+			continue
+		}
+		resolvers = append(resolvers, &requestResolver{request: request})
+	}
+	return resolvers, nil
+}
+
+type equipmentResolver struct {
+	equipment *fpjp.Equipment
+}
+
+func (this *equipmentResolver) ID() graphql.ID {
+	return graphql.ID(this.equipment.Id)
+}
+
+func (this *equipmentResolver) Room(ctx context.Context) (*roomResolver, error) {
+	return loadRoom(ctx, this.equipment.Room)
+}
+
+func (this *equipmentResolver) Type() string {
+	return this.equipment.Type
+}
+
+func (this *equipmentResolver) Name() string {
+	return this.equipment.Name
+}
+
+func (this *equipmentResolver) Count() int32 {
+	return this.equipment.Count
+}
+
+func (this *equipmentResolver) DeletedAt() *graphql.Time {
+	if this.equipment.DeletedAt == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *this.equipment.DeletedAt}
+}
+
+func (this *equipmentResolver) DeletedBy() *string {
+	return optional(this.equipment.DeletedBy)
+}
+
+type requestResolver struct {
+	request *fpjp.Request
+}
+
+func (this *requestResolver) ID() graphql.ID {
+	return graphql.ID(this.request.Id)
+}
+
+func (this *requestResolver) Room(ctx context.Context) (*roomResolver, error) {
+	return loadRoom(ctx, this.request.Room)
+}
+
+func (this *requestResolver) Type() string {
+	return requestTypeName(this.request.Type)
+}
+
+func (this *requestResolver) Name() string {
+	return this.request.Name
+}
+
+func (this *requestResolver) Count() *int32 {
+	return this.request.Count
+}
+
+func (this *requestResolver) Description() string {
+	return this.request.Description
+}
+
+func (this *requestResolver) DeletedAt() *graphql.Time {
+	if this.request.DeletedAt == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *this.request.DeletedAt}
+}
+
+func (this *requestResolver) DeletedBy() *string {
+	return optional(this.request.DeletedBy)
+}
+
+func loadRoom(ctx context.Context, roomId string) (*roomResolver, error) {
+	room, err := loadersFrom(ctx).rooms.Load(ctx, roomId)()
+	if err != nil {
+		return nil, fail(ctx, err)
+	}
+	return &roomResolver{room: room}, nil
+}
+
+// requestTypeName converts type of the request to the GraphQL enum value, e.g. missing-equipment to MISSING_EQUIPMENT
+func requestTypeName(value string) string {
+	return strings.ToUpper(strings.ReplaceAll(value, "-", "_"))
+}
+
+// requestTypeValue converts the GraphQL enum value to the type of the request stored by the service
+func requestTypeValue(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", "-"))
+}
+
+func containsId(ids []graphql.ID, id string) bool {
+	for _, candidate := range ids {
+		if string(candidate) == id {
+			return true
+		}
+	}
+	return false
+}
+
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
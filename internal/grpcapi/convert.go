@@ -0,0 +1,111 @@
+package grpcapi
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/events"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/fpjp"
+	inventoryv1 "github.com/ns-super-team/fpjp-ambulance-webapi/pkg/grpc/inventoryv1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toDepartment(department *fpjp.Department) *inventoryv1.Department {
+	return &inventoryv1.Department{
+		Id:   department.Id,
+		Name: department.Name,
+	}
+}
+
+func toRoom(room *fpjp.Room) *inventoryv1.Room {
+	return &inventoryv1.Room{
+		Id:           room.Id,
+		DepartmentId: room.DepartmentId,
+		Name:         room.Name,
+	}
+}
+
+func toEquipment(equipment *fpjp.Equipment) *inventoryv1.Equipment {
+	return &inventoryv1.Equipment{
+		Id:        equipment.Id,
+		Room:      equipment.Room,
+		Type:      equipment.Type,
+		Name:      equipment.Name,
+		Count:     equipment.Count,
+		DeletedAt: toTimestamp(equipment.DeletedAt),
+		DeletedBy: equipment.DeletedBy,
+	}
+}
+
+// fromEquipment converts the equipment of the message, its fields are validated by the inventory,
+// deletion markers are managed by the service only
+func fromEquipment(equipment *inventoryv1.Equipment) (*fpjp.Equipment, error) {
+	if equipment == nil {
+		return nil, invalidMessage(requiredField("equipment"))
+	}
+
+	return &fpjp.Equipment{
+		Id:    equipment.Id,
+		Room:  equipment.Room,
+		Type:  equipment.Type,
+		Name:  equipment.Name,
+		Count: equipment.Count,
+	}, nil
+}
+
+func toRequest(request *fpjp.Request) *inventoryv1.Request {
+	return &inventoryv1.Request{
+		Id:          request.Id,
+		Room:        request.Room,
+		Type:        toRequestType(request.Type),
+		Name:        request.Name,
+		Count:       request.Count,
+		Description: request.Description,
+		DeletedAt:   toTimestamp(request.DeletedAt),
+		DeletedBy:   request.DeletedBy,
+	}
+}
+
+// fromRequest converts the request of the message, its fields are validated by the inventory,
+// deletion markers are managed by the service only
+func fromRequest(request *inventoryv1.Request) (*fpjp.Request, error) {
+	if request == nil {
+		return nil, invalidMessage(requiredField("request"))
+	}
+	if request.Type == inventoryv1.RequestType_REQUEST_TYPE_UNSPECIFIED {
+		return nil, invalidMessage(requiredField("request.type"))
+	}
+
+	return &fpjp.Request{
+		Id:          request.Id,
+		Room:        request.Room,
+		Type:        fromRequestType(request.Type),
+		Name:        request.Name,
+		Count:       request.Count,
+		Description: request.Description,
+	}, nil
+}
+
+// toRequestType converts type of the request to the enum value, e.g. missing-equipment to REQUEST_TYPE_MISSING_EQUIPMENT
+func toRequestType(value string) inventoryv1.RequestType {
+	name := "REQUEST_TYPE_" + strings.ToUpper(strings.ReplaceAll(value, "-", "_"))
+	return inventoryv1.RequestType(inventoryv1.RequestType_value[name])
+}
+
+// fromRequestType converts the enum value to the type of the request stored by the service
+func fromRequestType(value inventoryv1.RequestType) string {
+	name := strings.TrimPrefix(value.String(), "REQUEST_TYPE_")
+	return strings.ToLower(strings.ReplaceAll(name, "_", "-"))
+}
+
+func toOperation(operation events.Operation) inventoryv1.ChangeOperation {
+	name := "CHANGE_OPERATION_" + strings.ToUpper(string(operation))
+	return inventoryv1.ChangeOperation(inventoryv1.ChangeOperation_value[name])
+}
+
+func toTimestamp(value *time.Time) *timestamppb.Timestamp {
+	if value == nil {
+		return nil
+	}
+	return timestamppb.New(*value)
+}
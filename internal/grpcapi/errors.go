@@ -0,0 +1,76 @@
+package grpcapi
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/problem"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// domain of the error reasons, the reasons are the same stable codes as the REST API uses
+const errorDomain = "fpjp-ambulance-webapi"
+
+// fail converts the error returned by the inventory to the status of the call,
+// server side failures are logged together with their cause
+func fail(ctx context.Context, err error) error {
+	failure := problem.From(err)
+	if failure.Status >= http.StatusInternalServerError {
+		slog.ErrorContext(ctx, "gRPC call failed", "code", failure.Code, "error", failure)
+	}
+
+	failed := status.New(statusCode(failure.Status), failure.Detail)
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: failure.Code, Domain: errorDomain}}
+	if len(failure.Errors) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, fieldError := range failure.Errors {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       fieldError.Field,
+				Description: fieldError.Message,
+			})
+		}
+		details = append(details, badRequest)
+	}
+	if detailed, err := failed.WithDetails(details...); err == nil {
+		failed = detailed
+	}
+	return failed.Err()
+}
+
+// statusCode maps the HTTP status of the problem to the gRPC status code
+func statusCode(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusPreconditionFailed, http.StatusUnprocessableEntity:
+		return codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
+
+// invalidMessage reports the fields of the request message which are not valid
+func invalidMessage(errors ...problem.FieldError) *problem.Problem {
+	return problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Request message is not valid.").
+		WithErrors(errors...)
+}
+
+func requiredField(field string) problem.FieldError {
+	return problem.FieldError{Field: field, Code: "required", Message: "is required"}
+}
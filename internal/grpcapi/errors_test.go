@@ -0,0 +1,143 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service/dbtest"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/fpjp"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/problem"
+	inventoryv1 "github.com/ns-super-team/fpjp-ambulance-webapi/pkg/grpc/inventoryv1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestClient serves the inventory kept in memory over in-process connection,
+// equipment eq-1 exists in room room-1
+func newTestClient(t *testing.T) inventoryv1.InventoryServiceClient {
+	t.Helper()
+	config := dbtest.MemoryServiceConfig{Store: dbtest.NewMemoryStore()}
+	inventory := fpjp.NewInventory(fpjp.InventoryConfig{
+		Departments: dbtest.NewMemoryService[fpjp.Department](config),
+		Rooms:       dbtest.NewMemoryService[fpjp.Room](config),
+		Equipment:   dbtest.NewMemoryService[fpjp.Equipment](config),
+		Requests:    dbtest.NewMemoryService[fpjp.Request](config),
+	})
+	err := inventory.CreateEquipment(context.Background(), &fpjp.Equipment{Id: "eq-1", Room: "room-1", Type: "monitor", Name: "Monitor", Count: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := NewServer(Config{Inventory: inventory})
+	go server.Serve(listener)
+	t.Cleanup(func() { server.Shutdown(context.Background()) })
+
+	connection, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { connection.Close() })
+	return inventoryv1.NewInventoryServiceClient(connection)
+}
+
+func TestErrorsAreMappedToStatus(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+	valid := &inventoryv1.Equipment{Room: "room-1", Type: "monitor", Name: "Monitor", Count: 1}
+	unnamed := &inventoryv1.Equipment{Room: "room-1", Type: "monitor", Count: 1}
+	existing := &inventoryv1.Equipment{Id: "eq-1", Room: "room-1", Type: "monitor", Name: "Monitor", Count: 1}
+
+	tests := map[string]struct {
+		call       func() error
+		code       codes.Code
+		reason     string
+		violations []string
+	}{
+		"not found": {
+			call: func() error {
+				_, err := client.GetEquipment(ctx, &inventoryv1.GetEquipmentRequest{Id: "eq-2"})
+				return err
+			},
+			code:   codes.NotFound,
+			reason: "equipment-not-found",
+		},
+		"invalid": {
+			call: func() error {
+				_, err := client.CreateEquipment(ctx, &inventoryv1.CreateEquipmentRequest{Equipment: unnamed})
+				return err
+			},
+			code:       codes.InvalidArgument,
+			reason:     problem.CodeInvalidBody,
+			violations: []string{"name"},
+		},
+		"missing message": {
+			call:       func() error { _, err := client.CreateEquipment(ctx, &inventoryv1.CreateEquipmentRequest{}); return err },
+			code:       codes.InvalidArgument,
+			reason:     problem.CodeInvalidBody,
+			violations: []string{"equipment"},
+		},
+		"already exists": {
+			call: func() error {
+				_, err := client.CreateEquipment(ctx, &inventoryv1.CreateEquipmentRequest{Equipment: existing})
+				return err
+			},
+			code:   codes.AlreadyExists,
+			reason: problem.CodeAlreadyExists,
+		},
+		"forbidden": {
+			call: func() error {
+				_, err := client.GetEquipment(ctx, &inventoryv1.GetEquipmentRequest{Id: "eq-1", IncludeDeleted: true})
+				return err
+			},
+			code: codes.PermissionDenied,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			failed := status.Convert(test.call())
+			if failed.Code() != test.code {
+				t.Fatalf("expected %v, got %v: %v", test.code, failed.Code(), failed.Message())
+			}
+
+			violations := []string{}
+			reason := ""
+			for _, detail := range failed.Details() {
+				switch detail := detail.(type) {
+				case *errdetails.ErrorInfo:
+					reason = detail.Reason
+					if detail.Domain != errorDomain {
+						t.Errorf("unexpected domain %v", detail.Domain)
+					}
+				case *errdetails.BadRequest:
+					for _, violation := range detail.FieldViolations {
+						violations = append(violations, violation.Field)
+					}
+				}
+			}
+			if reason == "" || (test.reason != "" && reason != test.reason) {
+				t.Errorf("expected reason %q, got %q", test.reason, reason)
+			}
+			if len(violations) != len(test.violations) {
+				t.Fatalf("expected violations of %v, got %v", test.violations, violations)
+			}
+			for i := range violations {
+				if violations[i] != test.violations[i] {
+					t.Errorf("expected violations of %v, got %v", test.violations, violations)
+				}
+			}
+		})
+	}
+
+	if _, err := client.CreateEquipment(ctx, &inventoryv1.CreateEquipmentRequest{Equipment: valid}); err != nil {
+		t.Errorf("valid equipment should be created, got %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package grpcapi
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// metadata key of the request ID, the same as the HTTP header
+const requestIDMetadata = "x-request-id"
+
+// unaryLogging assigns ID to each call, accepting the one provided by the caller,
+// and writes access log record once the call is handled
+func unaryLogging(ctx context.Context, request interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	ctx = withRequestID(ctx)
+	response, err := handler(ctx, request)
+	logCall(ctx, info.FullMethod, start, err)
+	return response, err
+}
+
+func streamLogging(server interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	ctx := withRequestID(stream.Context())
+	err := handler(server, &contextStream{ServerStream: stream, ctx: ctx})
+	logCall(ctx, info.FullMethod, start, err)
+	return err
+}
+
+// unaryRecovery logs panics of the handlers and reports internal error
+func unaryRecovery(ctx context.Context, request interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (response interface{}, err error) {
+	defer func() {
+		if value := recover(); value != nil {
+			slog.ErrorContext(ctx, "Panic recovered", "error", value, "method", info.FullMethod)
+			err = status.Error(codes.Internal, "Unexpected error occurred while handling the request.")
+		}
+	}()
+	return handler(ctx, request)
+}
+
+func streamRecovery(server interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if value := recover(); value != nil {
+			slog.ErrorContext(stream.Context(), "Panic recovered", "error", value, "method", info.FullMethod)
+			err = status.Error(codes.Internal, "Unexpected error occurred while handling the request.")
+		}
+	}()
+	return handler(server, stream)
+}
+
+func withRequestID(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	requestID := firstValue(md, requestIDMetadata)
+	if requestID == "" || len(requestID) > 128 {
+		requestID = uuid.New().String()
+	}
+	grpc.SetHeader(ctx, metadata.Pairs(requestIDMetadata, requestID))
+	return logging.WithRequestID(ctx, requestID)
+}
+
+func logCall(ctx context.Context, method string, start time.Time, err error) {
+	code := status.Code(err)
+	level := slog.LevelInfo
+	switch code {
+	case codes.OK, codes.Canceled:
+	case codes.Internal, codes.Unknown, codes.Unavailable, codes.DataLoss:
+		level = slog.LevelError
+	default:
+		level = slog.LevelWarn
+	}
+	slog.LogAttrs(ctx, level, "Call handled",
+		slog.String("method", method),
+		slog.String("code", code.String()),
+		slog.Float64("duration_ms", float64(time.Since(start).Microseconds())/1000),
+	)
+}
+
+// contextStream lets the stream handlers observe the request ID
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (this *contextStream) Context() context.Context {
+	return this.ctx
+}
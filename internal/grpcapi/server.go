@@ -0,0 +1,141 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/fpjp"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/health"
+	inventoryv1 "github.com/ns-super-team/fpjp-ambulance-webapi/pkg/grpc/inventoryv1"
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
+)
+
+// identity of the caller is provided by the authenticating proxy in front of the service
+const (
+	userMetadata   = "x-forwarded-user"
+	groupsMetadata = "x-forwarded-groups"
+)
+
+type Config struct {
+	Inventory *fpjp.Inventory
+	// readiness of the dependencies reported by the gRPC health service
+	Health *health.Checker
+	// how often the dependencies are checked, defaults to 10 seconds
+	HealthInterval time.Duration
+}
+
+// Server serves the inventory service together with the standard gRPC health service
+type Server struct {
+	server         *grpc.Server
+	health         *grpchealth.Server
+	checker        *health.Checker
+	healthInterval time.Duration
+	// done when the server shuts down, it ends the running watches and health updates
+	shutdown context.Context
+	close    context.CancelFunc
+}
+
+func NewServer(config Config) *Server {
+	if config.HealthInterval <= 0 {
+		config.HealthInterval = 10 * time.Second
+	}
+
+	shutdown, close := context.WithCancel(context.Background())
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryRecovery, unaryLogging),
+		grpc.ChainStreamInterceptor(streamRecovery, streamLogging),
+	)
+	inventoryv1.RegisterInventoryServiceServer(server, &inventoryService{
+		inventory: config.Inventory,
+		shutdown:  shutdown,
+	})
+
+	// not serving until the first check of the dependencies
+	healthServer := grpchealth.NewServer()
+	healthServer.SetServingStatus("", healthv1.HealthCheckResponse_NOT_SERVING)
+	healthServer.SetServingStatus(inventoryv1.InventoryService_ServiceDesc.ServiceName, healthv1.HealthCheckResponse_NOT_SERVING)
+	healthv1.RegisterHealthServer(server, healthServer)
+
+	// let the tools like grpcurl discover the services
+	reflection.Register(server)
+
+	return &Server{
+		server:         server,
+		health:         healthServer,
+		checker:        config.Health,
+		healthInterval: config.HealthInterval,
+		shutdown:       shutdown,
+		close:          close,
+	}
+}
+
+// Serve accepts the connections until the server is shut down
+func (this *Server) Serve(listener net.Listener) error {
+	go this.updateHealth()
+	return this.server.Serve(listener)
+}
+
+// Shutdown reports not serving status, ends the running watches and waits for the unary calls,
+// calls still running when the context is done are cancelled
+func (this *Server) Shutdown(ctx context.Context) {
+	this.close()
+	this.health.Shutdown()
+
+	stopped := make(chan struct{})
+	go func() {
+		this.server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		this.server.Stop()
+	}
+}
+
+// updateHealth keeps the serving status in sync with the readiness of the dependencies
+func (this *Server) updateHealth() {
+	ticker := time.NewTicker(this.healthInterval)
+	defer ticker.Stop()
+	for {
+		status := healthv1.HealthCheckResponse_SERVING
+		if this.checker != nil && this.checker.Ready(this.shutdown).Status != "ready" {
+			status = healthv1.HealthCheckResponse_NOT_SERVING
+		}
+		// the shutdown already set the final status
+		if this.shutdown.Err() != nil {
+			return
+		}
+		this.health.SetServingStatus("", status)
+		this.health.SetServingStatus(inventoryv1.InventoryService_ServiceDesc.ServiceName, status)
+
+		select {
+		case <-ticker.C:
+		case <-this.shutdown.Done():
+			return
+		}
+	}
+}
+
+// callerFrom identifies the user by the metadata set by the authenticating proxy,
+// calls which did not come through a trusted proxy are anonymous
+func callerFrom(ctx context.Context) fpjp.Caller {
+	if caller, ok := peer.FromContext(ctx); !ok || !fpjp.TrustedProxy(caller.Addr.String()) {
+		return fpjp.NewCaller("", "")
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	return fpjp.NewCaller(firstValue(md, userMetadata), firstValue(md, groupsMetadata))
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
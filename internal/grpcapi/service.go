@@ -0,0 +1,251 @@
+package grpcapi
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/fpjp"
+	inventoryv1 "github.com/ns-super-team/fpjp-ambulance-webapi/pkg/grpc/inventoryv1"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// inventoryService exposes the inventory by gRPC, the business rules are applied by the inventory
+// shared with the REST handlers, so the APIs report the same failures
+type inventoryService struct {
+	inventoryv1.UnimplementedInventoryServiceServer
+	inventory *fpjp.Inventory
+	// done when the server shuts down, it ends the running watches
+	shutdown context.Context
+}
+
+func (this *inventoryService) ListDepartments(ctx context.Context, request *inventoryv1.ListDepartmentsRequest) (*inventoryv1.ListDepartmentsResponse, error) {
+	departments, err := this.inventory.Departments(ctx)
+	if err != nil {
+		return nil, fail(ctx, err)
+	}
+
+	response := &inventoryv1.ListDepartmentsResponse{Departments: make([]*inventoryv1.Department, len(departments))}
+	for i, department := range departments {
+		response.Departments[i] = toDepartment(department)
+	}
+	return response, nil
+}
+
+func (this *inventoryService) ListRooms(ctx context.Context, request *inventoryv1.ListRoomsRequest) (*inventoryv1.ListRoomsResponse, error) {
+	rooms, err := this.inventory.DepartmentRooms(ctx, request.DepartmentId)
+	if err != nil {
+		return nil, fail(ctx, err)
+	}
+
+	response := &inventoryv1.ListRoomsResponse{Rooms: make([]*inventoryv1.Room, len(rooms))}
+	for i, room := range rooms {
+		response.Rooms[i] = toRoom(room)
+	}
+	return response, nil
+}
+
+func (this *inventoryService) GetDepartmentEquipment(ctx context.Context, request *inventoryv1.GetDepartmentEquipmentRequest) (*inventoryv1.DepartmentEquipment, error) {
+	// historical inventory is reconstructed from equipment versions
+	var asOf *time.Time
+	if request.AsOf != nil {
+		value := request.AsOf.AsTime()
+		asOf = &value
+	}
+
+	contents, err := this.inventory.DepartmentEquipment(ctx, callerFrom(ctx), request.DepartmentId, request.IncludeDeleted, asOf)
+	if err != nil {
+		return nil, fail(ctx, err)
+	}
+
+	// rooms are always listed, also those without any equipment
+	response := &inventoryv1.DepartmentEquipment{
+		Department: toDepartment(contents.Department),
+		Rooms:      make([]*inventoryv1.DepartmentEquipment_RoomEquipment, len(contents.Rooms)),
+	}
+	index := make(map[string]*inventoryv1.DepartmentEquipment_RoomEquipment, len(contents.Rooms))
+	for i, room := range contents.Rooms {
+		response.Rooms[i] = &inventoryv1.DepartmentEquipment_RoomEquipment{Room: toRoom(room)}
+		index[room.Id] = response.Rooms[i]
+	}
+	for _, equipment := range contents.Equipment {
+		if room, ok := index[equipment.Room]; ok {
+			room.Equipment = append(room.Equipment, toEquipment(equipment))
+		}
+	}
+	return response, nil
+}
+
+func (this *inventoryService) GetDepartmentRequests(ctx context.Context, request *inventoryv1.GetDepartmentRequestsRequest) (*inventoryv1.DepartmentRequests, error) {
+	contents, err := this.inventory.DepartmentRequests(ctx, callerFrom(ctx), request.DepartmentId, request.IncludeDeleted)
+	if err != nil {
+		return nil, fail(ctx, err)
+	}
+
+	response := &inventoryv1.DepartmentRequests{
+		Department: toDepartment(contents.Department),
+		Rooms:      make([]*inventoryv1.DepartmentRequests_RoomRequests, len(contents.Rooms)),
+	}
+	index := make(map[string]*inventoryv1.DepartmentRequests_RoomRequests, len(contents.Rooms))
+	for i, room := range contents.Rooms {
+		response.Rooms[i] = &inventoryv1.DepartmentRequests_RoomRequests{Room: toRoom(room)}
+		index[room.Id] = response.Rooms[i]
+	}
+	for _, item := range contents.Requests {
+		if room, ok := index[item.Room]; ok {
+			room.Requests = append(room.Requests, toRequest(item))
+		}
+	}
+	return response, nil
+}
+
+func (this *inventoryService) GetEquipment(ctx context.Context, request *inventoryv1.GetEquipmentRequest) (*inventoryv1.Equipment, error) {
+	equipment, err := this.inventory.Equipment(ctx, callerFrom(ctx), request.Id, request.IncludeDeleted)
+	if err != nil {
+		return nil, fail(ctx, err)
+	}
+	return toEquipment(equipment), nil
+}
+
+func (this *inventoryService) CreateEquipment(ctx context.Context, request *inventoryv1.CreateEquipmentRequest) (*inventoryv1.Equipment, error) {
+	equipment, err := fromEquipment(request.Equipment)
+	if err != nil {
+		return nil, fail(ctx, err)
+	}
+	if err := this.inventory.CreateEquipment(ctx, equipment); err != nil {
+		return nil, fail(ctx, err)
+	}
+	return toEquipment(equipment), nil
+}
+
+func (this *inventoryService) UpdateEquipment(ctx context.Context, request *inventoryv1.UpdateEquipmentRequest) (*inventoryv1.Equipment, error) {
+	equipment, err := fromEquipment(request.Equipment)
+	if err == nil && equipment.Id == "" {
+		err = invalidMessage(requiredField("equipment.id"))
+	}
+	if err != nil {
+		return nil, fail(ctx, err)
+	}
+	if err := this.inventory.UpdateEquipment(ctx, equipment); err != nil {
+		return nil, fail(ctx, err)
+	}
+	return toEquipment(equipment), nil
+}
+
+func (this *inventoryService) DeleteEquipment(ctx context.Context, request *inventoryv1.DeleteEquipmentRequest) (*emptypb.Empty, error) {
+	if err := this.inventory.DeleteEquipment(ctx, callerFrom(ctx), request.Id); err != nil {
+		return nil, fail(ctx, err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (this *inventoryService) RestoreEquipment(ctx context.Context, request *inventoryv1.RestoreEquipmentRequest) (*inventoryv1.Equipment, error) {
+	equipment, err := this.inventory.RestoreEquipment(ctx, request.Id)
+	if err != nil {
+		return nil, fail(ctx, err)
+	}
+	return toEquipment(equipment), nil
+}
+
+func (this *inventoryService) GetRequest(ctx context.Context, request *inventoryv1.GetRequestRequest) (*inventoryv1.Request, error) {
+	found, err := this.inventory.Request(ctx, callerFrom(ctx), request.Id, request.IncludeDeleted)
+	if err != nil {
+		return nil, fail(ctx, err)
+	}
+	return toRequest(found), nil
+}
+
+func (this *inventoryService) CreateRequest(ctx context.Context, request *inventoryv1.CreateRequestRequest) (*inventoryv1.Request, error) {
+	created, err := fromRequest(request.Request)
+	if err != nil {
+		return nil, fail(ctx, err)
+	}
+	if err := this.inventory.CreateRequest(ctx, created); err != nil {
+		return nil, fail(ctx, err)
+	}
+	return toRequest(created), nil
+}
+
+func (this *inventoryService) UpdateRequest(ctx context.Context, request *inventoryv1.UpdateRequestRequest) (*inventoryv1.Request, error) {
+	updated, err := fromRequest(request.Request)
+	if err == nil && updated.Id == "" {
+		err = invalidMessage(requiredField("request.id"))
+	}
+	if err != nil {
+		return nil, fail(ctx, err)
+	}
+	if err := this.inventory.UpdateRequest(ctx, updated); err != nil {
+		return nil, fail(ctx, err)
+	}
+	return toRequest(updated), nil
+}
+
+func (this *inventoryService) DeleteRequest(ctx context.Context, request *inventoryv1.DeleteRequestRequest) (*emptypb.Empty, error) {
+	if err := this.inventory.DeleteRequest(ctx, callerFrom(ctx), request.Id); err != nil {
+		return nil, fail(ctx, err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (this *inventoryService) RestoreRequest(ctx context.Context, request *inventoryv1.RestoreRequestRequest) (*inventoryv1.Request, error) {
+	restored, err := this.inventory.RestoreRequest(ctx, request.Id)
+	if err != nil {
+		return nil, fail(ctx, err)
+	}
+	return toRequest(restored), nil
+}
+
+func (this *inventoryService) WatchEquipment(request *inventoryv1.WatchRequest, stream inventoryv1.InventoryService_WatchEquipmentServer) error {
+	ctx, cancel := this.watchContext(stream.Context())
+	defer cancel()
+
+	changes := this.inventory.WatchEquipment(ctx, fpjp.ChangeFilter{DepartmentId: request.DepartmentId, RoomId: request.RoomId})
+	for change := range changes {
+		message := &inventoryv1.EquipmentChange{
+			Operation: toOperation(change.Operation),
+			Id:        change.Id,
+			Time:      timestamppb.New(change.Time),
+		}
+		if change.Document != nil {
+			message.Equipment = toEquipment(change.Document)
+		}
+		if err := stream.Send(message); err != nil {
+			slog.DebugContext(ctx, "Cannot send equipment change", "error", err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (this *inventoryService) WatchRequests(request *inventoryv1.WatchRequest, stream inventoryv1.InventoryService_WatchRequestsServer) error {
+	ctx, cancel := this.watchContext(stream.Context())
+	defer cancel()
+
+	changes := this.inventory.WatchRequests(ctx, fpjp.ChangeFilter{DepartmentId: request.DepartmentId, RoomId: request.RoomId})
+	for change := range changes {
+		message := &inventoryv1.RequestChange{
+			Operation: toOperation(change.Operation),
+			Id:        change.Id,
+			Time:      timestamppb.New(change.Time),
+		}
+		if change.Document != nil {
+			message.Request = toRequest(change.Document)
+		}
+		if err := stream.Send(message); err != nil {
+			slog.DebugContext(ctx, "Cannot send request change", "error", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// watchContext ends the watch when the client cancels the call or the server shuts down
+func (this *inventoryService) watchContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(this.shutdown, cancel)
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+}
@@ -0,0 +1,116 @@
+package health
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Check verifies availability of a single dependency
+type Check func(ctx context.Context) error
+
+// DependencyStatus describes outcome of a single check, the reason of the failure is only logged
+// since the probes are served without authentication
+type DependencyStatus struct {
+	Status string `json:"status"`
+}
+
+// Status is the body of the readiness response
+type Status struct {
+	Status       string                      `json:"status"`
+	Dependencies map[string]DependencyStatus `json:"dependencies"`
+}
+
+// Checker serves liveness and readiness probes
+type Checker struct {
+	timeout      time.Duration
+	checks       map[string]Check
+	shuttingDown atomic.Bool
+}
+
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{
+		timeout: timeout,
+		checks:  map[string]Check{},
+	}
+}
+
+// Register adds dependency which must be available for the service to be ready
+func (this *Checker) Register(name string, check Check) {
+	this.checks[name] = check
+}
+
+// SetShuttingDown makes the service report not ready, so that no new traffic is routed to it
+func (this *Checker) SetShuttingDown() {
+	this.shuttingDown.Store(true)
+}
+
+// HandleLiveness reports that the process is able to serve requests
+func (this *Checker) HandleLiveness(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{"status": "alive"})
+}
+
+// HandleReadiness reports the outcome of the dependency checks
+func (this *Checker) HandleReadiness(ctx *gin.Context) {
+	status := this.Ready(ctx)
+	code := http.StatusOK
+	if status.Status != "ready" {
+		code = http.StatusServiceUnavailable
+	}
+	ctx.JSON(code, status)
+}
+
+// Ready checks all dependencies in parallel, the status is ready when all of them are up
+func (this *Checker) Ready(ctx context.Context) Status {
+	if this.shuttingDown.Load() {
+		return Status{
+			Status:       "shutting-down",
+			Dependencies: map[string]DependencyStatus{},
+		}
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, this.timeout)
+	defer cancel()
+
+	var lock sync.Mutex
+	var wait sync.WaitGroup
+	dependencies := map[string]DependencyStatus{}
+	for name, check := range this.checks {
+		wait.Add(1)
+		go func(name string, check Check) {
+			defer wait.Done()
+			start := time.Now()
+			err := check(checkCtx)
+			status := DependencyStatus{Status: "up"}
+			if err != nil {
+				status.Status = "down"
+				slog.WarnContext(ctx, "Dependency check failed", "dependency", name,
+					"duration", time.Since(start).String(), "error", err)
+			}
+			lock.Lock()
+			dependencies[name] = status
+			lock.Unlock()
+		}(name, check)
+	}
+	wait.Wait()
+
+	response := Status{Status: "ready", Dependencies: dependencies}
+	names := make([]string, 0, len(dependencies))
+	for name, status := range dependencies {
+		names = append(names, name)
+		if status.Status != "up" {
+			response.Status = "not-ready"
+		}
+	}
+	if response.Status != "ready" {
+		sort.Strings(names)
+		slog.WarnContext(ctx, "Service is not ready", "dependencies", names)
+	}
+	return response
+}
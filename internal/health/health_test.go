@@ -0,0 +1,47 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestReadinessHidesReasonOfFailure(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	checker := NewChecker(time.Second)
+	checker.Register("mongodb", func(ctx context.Context) error {
+		return fmt.Errorf("server selection error: mongo-0.internal:27017 unreachable")
+	})
+	engine := gin.New()
+	engine.GET("/readyz", checker.HandleReadiness)
+
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %v", recorder.Code)
+	}
+	expected := `{"status":"not-ready","dependencies":{"mongodb":{"status":"down"}}}`
+	if body := strings.TrimSpace(recorder.Body.String()); body != expected {
+		t.Errorf("expected %v, got %v", expected, body)
+	}
+}
+
+func TestShuttingDownIsNotReady(t *testing.T) {
+	checker := NewChecker(time.Second)
+	checker.Register("mongodb", func(ctx context.Context) error { return nil })
+
+	if status := checker.Ready(context.Background()); status.Status != "ready" {
+		t.Errorf("expected ready, got %v", status.Status)
+	}
+	checker.SetShuttingDown()
+	if status := checker.Ready(context.Background()); status.Status != "shutting-down" {
+		t.Errorf("expected shutting-down, got %v", status.Status)
+	}
+}
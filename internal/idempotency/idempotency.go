@@ -0,0 +1,216 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/problem"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	KeyHeader = "Idempotency-Key"
+	// marks the stored response sent again for a retried request
+	ReplayedHeader = "Idempotent-Replayed"
+)
+
+const (
+	codeInProgress = "idempotency-key-in-progress"
+	codeReused     = "idempotency-key-reused"
+)
+
+// headers of the stored response sent again with its body, the other
+// headers are set by the middlewares handling the retried request
+var replayedHeaders = []string{"Content-Type", "Location", "ETag"}
+
+// Record keeps the response of the request sent with the idempotency key,
+// expired records are removed by the TTL index on expiresAt created by migrations
+type Record struct {
+	// hash of the caller and the key, keys of different callers never collide
+	Id string `bson:"id"`
+	// hash of the method, URL and body of the request
+	RequestHash string `bson:"requestHash"`
+	// zero while the request is being processed
+	Status    int               `bson:"status"`
+	Header    map[string]string `bson:"header,omitempty"`
+	Body      []byte            `bson:"body,omitempty"`
+	CreatedAt time.Time         `bson:"createdAt"`
+	ExpiresAt time.Time         `bson:"expiresAt"`
+}
+
+type Config struct {
+	Store db_service.DbService[Record]
+	// how long the response is replayed for retries with the same key
+	Retention time.Duration
+	// request still being processed after this time is considered abandoned, e.g. by crashed replica,
+	// and its key can be used again
+	ProcessingTimeout time.Duration
+	// identity of the caller the keys belong to
+	Caller func(ctx *gin.Context) string
+}
+
+// Middleware makes POST requests with the Idempotency-Key header safe to retry - the first request
+// is processed and its response stored, retries with the same key and payload get the stored response
+// without repeating the operation, reuse of the key with different payload is rejected,
+// failures of the server are not stored so that the retry is processed again
+func Middleware(config Config) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader(KeyHeader)
+		if ctx.Request.Method != http.MethodPost || key == "" {
+			ctx.Next()
+			return
+		}
+
+		body, err := io.ReadAll(ctx.Request.Body)
+		if err != nil {
+			problem.Respond(ctx, problem.InvalidBody(err))
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		// the database keeps milliseconds, the stored time is compared when the record is replaced
+		now := time.Now().UTC().Truncate(time.Millisecond)
+		record := &Record{
+			Id:          hash(config.Caller(ctx), key),
+			RequestHash: hash(ctx.Request.Method, ctx.Request.URL.RequestURI(), string(body)),
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(config.Retention),
+		}
+
+		acquired, err := acquire(ctx, config, record)
+		if err != nil {
+			problem.Respond(ctx, err)
+			return
+		}
+		if !acquired {
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: ctx.Writer}
+		ctx.Writer = recorder
+		ctx.Next()
+		ctx.Writer = recorder.ResponseWriter
+
+		// the response is stored even if the client already gave up waiting for it
+		storeCtx := context.WithoutCancel(ctx)
+		// the record may have been taken over by the retry if the processing exceeded the timeout
+		processing := bson.M{"createdAt": record.CreatedAt, "status": 0}
+		if recorder.Status() >= http.StatusInternalServerError {
+			err := config.Store.DeleteDocumentIf(storeCtx, record.Id, processing)
+			if err != nil && !errors.Is(err, db_service.ErrNotFound) {
+				slog.WarnContext(ctx, "Failed to release idempotency key", "error", err)
+			}
+			return
+		}
+
+		record.Status = recorder.Status()
+		record.Header = map[string]string{}
+		for _, name := range replayedHeaders {
+			if value := recorder.Header().Get(name); value != "" {
+				record.Header[name] = value
+			}
+		}
+		record.Body = recorder.body.Bytes()
+		err = config.Store.UpdateDocumentIf(storeCtx, record.Id, processing, record)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to store response of idempotent request", "error", err)
+		}
+	}
+}
+
+// acquire records that the request is being processed, when the key is already known
+// it responds with the stored response or with the problem and returns false
+func acquire(ctx *gin.Context, config Config, record *Record) (bool, error) {
+	err := config.Store.CreateDocument(ctx, record.Id, record)
+	if err == nil {
+		return true, nil
+	}
+	if !errors.Is(err, db_service.ErrConflict) {
+		return false, problem.Database(err)
+	}
+
+	stored, err := config.Store.FindDocument(ctx, record.Id)
+	if errors.Is(err, db_service.ErrNotFound) {
+		// removed meanwhile, the client should retry
+		return false, inProgress()
+	}
+	if err != nil {
+		return false, problem.Database(err)
+	}
+
+	now := time.Now().UTC()
+	abandoned := stored.Status == 0 && stored.CreatedAt.Add(config.ProcessingTimeout).Before(now)
+	if stored.ExpiresAt.Before(now) || abandoned {
+		// the TTL monitor removes expired records only periodically, the record is replaced only
+		// if it was not changed meanwhile, so that only one of the concurrent retries takes it over
+		taken := bson.M{"createdAt": stored.CreatedAt, "status": stored.Status}
+		err := config.Store.UpdateDocumentIf(ctx, record.Id, taken, record)
+		switch {
+		case err == nil:
+			return true, nil
+		case errors.Is(err, db_service.ErrNotFound):
+			return false, inProgress()
+		default:
+			return false, problem.Database(err)
+		}
+	}
+
+	if stored.RequestHash != record.RequestHash {
+		return false, problem.New(http.StatusUnprocessableEntity, codeReused,
+			"Idempotency key was already used with different request.")
+	}
+	if stored.Status == 0 {
+		return false, inProgress()
+	}
+
+	slog.DebugContext(ctx, "Replaying stored response", "status", stored.Status)
+	for name, value := range stored.Header {
+		ctx.Header(name, value)
+	}
+	ctx.Header(ReplayedHeader, "true")
+	ctx.Status(stored.Status)
+	ctx.Writer.WriteHeaderNow()
+	ctx.Writer.Write(stored.Body)
+	ctx.Abort()
+	return false, nil
+}
+
+func inProgress() *problem.Problem {
+	return problem.New(http.StatusConflict, codeInProgress,
+		"Request with the same idempotency key is still being processed, retry later.")
+}
+
+func hash(parts ...string) string {
+	digest := sha256.New()
+	for _, part := range parts {
+		// the length separates the parts, so that their boundaries cannot be shifted
+		digest.Write([]byte{byte(len(part) >> 24), byte(len(part) >> 16), byte(len(part) >> 8), byte(len(part))})
+		digest.Write([]byte(part))
+	}
+	return hex.EncodeToString(digest.Sum(nil))
+}
+
+// responseRecorder keeps copy of the response body to be stored
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (this *responseRecorder) Write(data []byte) (int, error) {
+	this.body.Write(data)
+	return this.ResponseWriter.Write(data)
+}
+
+func (this *responseRecorder) WriteString(data string) (int, error) {
+	this.body.WriteString(data)
+	return this.ResponseWriter.WriteString(data)
+}
@@ -0,0 +1,259 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/db_service/dbtest"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/problem"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// testServer counts the calls of the handler protected by the middleware
+type testServer struct {
+	engine *gin.Engine
+	store  db_service.DbService[Record]
+	calls  int
+	// status of the response of the handler, 201 when zero
+	status int
+	// called by the handler before it responds
+	during func()
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	server := &testServer{
+		engine: gin.New(),
+		store:  dbtest.NewMemoryService[Record](dbtest.MemoryServiceConfig{Store: dbtest.NewMemoryStore()}),
+	}
+	server.engine.Use(Middleware(Config{
+		Store:             server.store,
+		Retention:         time.Hour,
+		ProcessingTimeout: time.Minute,
+		Caller:            func(ctx *gin.Context) string { return ctx.GetHeader("X-Forwarded-User") },
+	}))
+	server.engine.POST("/items", func(ctx *gin.Context) {
+		server.calls++
+		if server.during != nil {
+			server.during()
+		}
+		status := server.status
+		if status == 0 {
+			status = http.StatusCreated
+		}
+		ctx.Header("Location", fmt.Sprintf("/items/%v", server.calls))
+		ctx.JSON(status, gin.H{"call": server.calls})
+	})
+	return server
+}
+
+func (this *testServer) post(key string, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Forwarded-User", "nurse")
+	if key != "" {
+		request.Header.Set(KeyHeader, key)
+	}
+	recorder := httptest.NewRecorder()
+	this.engine.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func problemCode(t *testing.T, response *httptest.ResponseRecorder, status int) string {
+	t.Helper()
+	if response.Code != status {
+		t.Fatalf("expected status %v, got %v: %v", status, response.Code, response.Body.String())
+	}
+	failure := problem.Problem{}
+	if err := json.Unmarshal(response.Body.Bytes(), &failure); err != nil {
+		t.Fatal(err)
+	}
+	return failure.Code
+}
+
+func TestRetryIsReplayed(t *testing.T) {
+	server := newTestServer(t)
+
+	first := server.post("key-1", `{"name": "Monitor"}`)
+	retry := server.post("key-1", `{"name": "Monitor"}`)
+
+	if server.calls != 1 {
+		t.Errorf("handler should be called once, got %v calls", server.calls)
+	}
+	if retry.Code != first.Code || retry.Body.String() != first.Body.String() || retry.Header().Get("Location") != "/items/1" {
+		t.Errorf("expected replay of %v %v, got %v %v", first.Code, first.Body, retry.Code, retry.Body)
+	}
+	if first.Header().Get(ReplayedHeader) != "" || retry.Header().Get(ReplayedHeader) != "true" {
+		t.Errorf("only the retry should be marked as replayed")
+	}
+}
+
+func TestRequestsWithoutKeyAreProcessed(t *testing.T) {
+	server := newTestServer(t)
+
+	server.post("", `{"name": "Monitor"}`)
+	server.post("", `{"name": "Monitor"}`)
+
+	if server.calls != 2 {
+		t.Errorf("handler should be called for each request, got %v calls", server.calls)
+	}
+}
+
+func TestKeyReusedWithDifferentRequest(t *testing.T) {
+	server := newTestServer(t)
+
+	server.post("key-1", `{"name": "Monitor"}`)
+	response := server.post("key-1", `{"name": "Ventilator"}`)
+
+	if code := problemCode(t, response, http.StatusUnprocessableEntity); code != codeReused {
+		t.Errorf("unexpected problem %v", code)
+	}
+	if server.calls != 1 {
+		t.Errorf("handler should be called once, got %v calls", server.calls)
+	}
+}
+
+func TestRetryWhileInProgress(t *testing.T) {
+	server := newTestServer(t)
+	var retry *httptest.ResponseRecorder
+	server.during = func() {
+		// the retry arrives before the first request is processed
+		server.during = nil
+		retry = server.post("key-1", `{"name": "Monitor"}`)
+	}
+
+	server.post("key-1", `{"name": "Monitor"}`)
+
+	if code := problemCode(t, retry, http.StatusConflict); code != codeInProgress {
+		t.Errorf("unexpected problem %v", code)
+	}
+	if server.calls != 1 {
+		t.Errorf("handler should be called once, got %v calls", server.calls)
+	}
+}
+
+func TestKeyReleasedAfterServerFailure(t *testing.T) {
+	server := newTestServer(t)
+	server.status = http.StatusServiceUnavailable
+
+	first := server.post("key-1", `{"name": "Monitor"}`)
+	server.status = 0
+	retry := server.post("key-1", `{"name": "Monitor"}`)
+
+	if first.Code != http.StatusServiceUnavailable || retry.Code != http.StatusCreated || retry.Header().Get(ReplayedHeader) != "" {
+		t.Errorf("retry should be processed again, got %v and %v", first.Code, retry.Code)
+	}
+	if server.calls != 2 {
+		t.Errorf("handler should be called twice, got %v calls", server.calls)
+	}
+}
+
+func TestFailureKeepsKeyTakenOverMeanwhile(t *testing.T) {
+	server := newTestServer(t)
+	server.status = http.StatusServiceUnavailable
+	id := hash("nurse", "key-1")
+	takenAt := time.Now().UTC().Add(time.Second).Truncate(time.Millisecond)
+	server.during = func() {
+		// the processing exceeded the timeout and the retry took the key over
+		stored, err := server.store.FindDocument(context.Background(), id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		taken := *stored
+		taken.CreatedAt = takenAt
+		if err := server.store.UpdateDocument(context.Background(), id, &taken); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	server.post("key-1", `{"name": "Monitor"}`)
+
+	stored, err := server.store.FindDocument(context.Background(), id)
+	if err != nil || !stored.CreatedAt.Equal(takenAt) {
+		t.Errorf("record of the retry should be kept, got %v, %v", stored, err)
+	}
+}
+
+func TestAbandonedKeyIsTakenOver(t *testing.T) {
+	server := newTestServer(t)
+	abandonKey(t, server.store, "key-1", `{"name": "Monitor"}`)
+
+	response := server.post("key-1", `{"name": "Monitor"}`)
+
+	if response.Code != http.StatusCreated || server.calls != 1 {
+		t.Errorf("abandoned request should be processed again, got %v", response.Code)
+	}
+	if replay := server.post("key-1", `{"name": "Monitor"}`); replay.Header().Get(ReplayedHeader) != "true" {
+		t.Errorf("response of the takeover should be stored, got %v", replay.Code)
+	}
+}
+
+func TestAbandonedKeyIsTakenOverOnce(t *testing.T) {
+	server := newTestServer(t)
+	record := abandonKey(t, server.store, "key-1", `{"name": "Monitor"}`)
+	// another replica takes the key over between the read and the takeover of this one
+	server.store = racingService{server.store, record}
+	server.engine = gin.New()
+	server.engine.Use(Middleware(Config{
+		Store:             server.store,
+		Retention:         time.Hour,
+		ProcessingTimeout: time.Minute,
+		Caller:            func(ctx *gin.Context) string { return ctx.GetHeader("X-Forwarded-User") },
+	}))
+	server.engine.POST("/items", func(ctx *gin.Context) { server.calls++ })
+
+	response := server.post("key-1", `{"name": "Monitor"}`)
+
+	if code := problemCode(t, response, http.StatusConflict); code != codeInProgress {
+		t.Errorf("unexpected problem %v", code)
+	}
+	if server.calls != 0 {
+		t.Errorf("handler should not be called, got %v calls", server.calls)
+	}
+}
+
+// abandonKey stores the record of the request which started processing long ago and never finished
+func abandonKey(t *testing.T, store db_service.DbService[Record], key string, body string) *Record {
+	t.Helper()
+	createdAt := time.Now().UTC().Add(-time.Hour).Truncate(time.Millisecond)
+	record := &Record{
+		Id:          hash("nurse", key),
+		RequestHash: hash(http.MethodPost, "/items", body),
+		CreatedAt:   createdAt,
+		ExpiresAt:   createdAt.Add(24 * time.Hour),
+	}
+	if err := store.CreateDocument(context.Background(), record.Id, record); err != nil {
+		t.Fatal(err)
+	}
+	return record
+}
+
+// racingService takes over the record right after it is read, the reader gets the outdated record
+type racingService struct {
+	db_service.DbService[Record]
+	record *Record
+}
+
+func (this racingService) FindDocument(ctx context.Context, id string) (*Record, error) {
+	stored, err := this.DbService.FindDocument(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	taken := *this.record
+	taken.CreatedAt = time.Now().UTC().Truncate(time.Millisecond)
+	condition := bson.M{"createdAt": stored.CreatedAt, "status": stored.Status}
+	if err := this.DbService.UpdateDocumentIf(ctx, id, condition, &taken); err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
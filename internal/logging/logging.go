@@ -0,0 +1,128 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+type requestIDKey struct{}
+
+// WithRequestID returns context carrying the request ID, which is then added to all log records
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID returns ID of the request handled within the context
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// Setup installs JSON logger as the default one, the level is taken from AMBULANCE_API_LOG_LEVEL
+// and the format from AMBULANCE_API_LOG_FORMAT (json or text)
+func Setup() {
+	level := slog.LevelInfo
+	if value, ok := os.LookupEnv("AMBULANCE_API_LOG_LEVEL"); ok {
+		if err := level.UnmarshalText([]byte(value)); err != nil {
+			defer slog.Warn("Invalid log level, using info", "value", value)
+		}
+	}
+
+	options := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	if strings.EqualFold(os.Getenv("AMBULANCE_API_LOG_FORMAT"), "text") {
+		handler = slog.NewTextHandler(os.Stdout, options)
+	} else {
+		handler = slog.NewJSONHandler(os.Stdout, options)
+	}
+	slog.SetDefault(slog.New(&contextHandler{handler}))
+}
+
+// contextHandler enriches records with request and trace identifiers found in the context
+type contextHandler struct {
+	slog.Handler
+}
+
+func (this *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := RequestID(ctx); requestID != "" {
+		record.AddAttrs(slog.String("request_id", requestID))
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		record.AddAttrs(
+			slog.String("trace_id", spanContext.TraceID().String()),
+			slog.String("span_id", spanContext.SpanID().String()),
+		)
+	}
+	return this.Handler.Handle(ctx, record)
+}
+
+func (this *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{this.Handler.WithAttrs(attrs)}
+}
+
+func (this *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{this.Handler.WithGroup(name)}
+}
+
+// Middleware assigns ID to each request, accepting the one provided by the caller,
+// and writes access log record once the request is handled
+func Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+
+		requestID := ctx.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > 128 {
+			requestID = uuid.New().String()
+		}
+		ctx.Header(RequestIDHeader, requestID)
+		ctx.Request = ctx.Request.WithContext(WithRequestID(ctx.Request.Context(), requestID))
+
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+		attrs := []slog.Attr{
+			slog.String("method", ctx.Request.Method),
+			slog.String("path", ctx.Request.URL.Path),
+			slog.String("route", ctx.FullPath()),
+			slog.Int("status", status),
+			slog.Float64("duration_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.Int("size", ctx.Writer.Size()),
+			slog.String("client_ip", ctx.ClientIP()),
+		}
+		if len(ctx.Errors) > 0 {
+			attrs = append(attrs, slog.String("errors", ctx.Errors.String()))
+		}
+		slog.LogAttrs(ctx, level, "Request handled", attrs...)
+	}
+}
+
+// RedactURI hides password contained in the connection string
+func RedactURI(uri string) string {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return "<unparsable uri>"
+	}
+	if parsed.User != nil {
+		if _, hasPassword := parsed.User.Password(); hasPassword {
+			parsed.User = url.UserPassword(parsed.User.Username(), "xxxxx")
+		}
+	}
+	return parsed.String()
+}
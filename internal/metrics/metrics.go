@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "fpjp_http_requests_total",
+		Help: "Number of handled HTTP requests",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "fpjp_http_request_duration_seconds",
+		Help:    "Latency of handled HTTP requests",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+)
+
+// Middleware records count and latency of requests per route
+func Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+
+		// use route template instead of the actual path to keep the cardinality low
+		route := ctx.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		method := ctx.Request.Method
+		httpRequests.WithLabelValues(method, route, strconv.Itoa(ctx.Writer.Status())).Inc()
+		httpDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+	}
+}
+
+// Handler serves the collected metrics in Prometheus exposition format
+func Handler() gin.HandlerFunc {
+	return gin.WrapH(promhttp.Handler())
+}
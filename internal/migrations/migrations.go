@@ -0,0 +1,370 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// collections of the domain documents, all of them are looked up by id
+var documentCollections = []string{
+	"departments",
+	"rooms",
+	"equipment",
+	"requests",
+	"stock_movements",
+	"reservations",
+}
+
+// collections keeping snapshots of the documents
+var versionCollections = []string{
+	"equipment_versions",
+	"requests_versions",
+}
+
+// document collections with the collection of their snapshots
+var versionedCollections = map[string]string{
+	"equipment": "equipment_versions",
+	"requests":  "requests_versions",
+}
+
+// All lists migrations of the service database, new migrations are appended to the end
+// and already released migrations must never be changed
+var All = []Migration{
+	{
+		Id:          "0001_assign_missing_ids",
+		Description: "Assign id to documents inserted without it, so that id can be unique",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			missing := bson.M{"$or": bson.A{
+				bson.M{"id": bson.M{"$exists": false}},
+				bson.M{"id": nil},
+				bson.M{"id": ""},
+			}}
+			assign := mongo.Pipeline{{{Key: "$set", Value: bson.M{"id": bson.M{"$toString": "$_id"}}}}}
+			for _, collection := range documentCollections {
+				if _, err := db.Collection(collection).UpdateMany(ctx, missing, assign); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Id:          "0002_unique_ids",
+		Description: "Enforce uniqueness of document ids and version numbers",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			// the unique index cannot be built while duplicates exist, they are reported all at once
+			for _, collection := range documentCollections {
+				if err := checkUniqueIds(ctx, db, collection); err != nil {
+					return err
+				}
+			}
+			for _, collection := range documentCollections {
+				// the init script of the kustomize deployment creates non unique index on id
+				if err := dropIndexIfNotUnique(ctx, db, collection, "id_1"); err != nil {
+					return err
+				}
+				err := createIndexes(ctx, db, collection, mongo.IndexModel{
+					Keys:    bson.D{{Key: "id", Value: 1}},
+					Options: options.Index().SetUnique(true),
+				})
+				if err != nil {
+					return err
+				}
+			}
+			for _, collection := range versionCollections {
+				err := createIndexes(ctx, db, collection, mongo.IndexModel{
+					Keys:    bson.D{{Key: "id", Value: 1}, {Key: "version", Value: -1}},
+					Options: options.Index().SetUnique(true),
+				})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Id:          "0003_lookup_indexes",
+		Description: "Index fields used by department listings, ledgers, calendars and purge",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			indexes := map[string][]mongo.IndexModel{
+				"rooms": {
+					{Keys: bson.D{{Key: "department_id", Value: 1}}},
+				},
+				"equipment": {
+					{Keys: bson.D{{Key: "room", Value: 1}}},
+					{Keys: bson.D{{Key: "deletedAt", Value: 1}}, Options: options.Index().SetSparse(true)},
+				},
+				"requests": {
+					{Keys: bson.D{{Key: "room", Value: 1}}},
+					{Keys: bson.D{{Key: "deletedAt", Value: 1}}, Options: options.Index().SetSparse(true)},
+				},
+				"stock_movements": {
+					{Keys: bson.D{{Key: "equipmentId", Value: 1}, {Key: "recordedAt", Value: 1}}},
+				},
+				"reservations": {
+					{Keys: bson.D{{Key: "equipmentId", Value: 1}, {Key: "start", Value: 1}, {Key: "end", Value: 1}}},
+					{Keys: bson.D{{Key: "deletedAt", Value: 1}}, Options: options.Index().SetSparse(true)},
+				},
+			}
+			for _, collection := range []string{"rooms", "equipment", "requests", "stock_movements", "reservations"} {
+				if err := createIndexes(ctx, db, collection, indexes[collection]...); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Id:          "0004_idempotency_keys",
+		Description: "Index idempotency keys and remove their records after the retention period",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			// each record carries its own expiration, so the retention can change without migration
+			return createIndexes(ctx, db, "idempotency_keys",
+				mongo.IndexModel{
+					Keys:    bson.D{{Key: "id", Value: 1}},
+					Options: options.Index().SetUnique(true),
+				},
+				mongo.IndexModel{
+					Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+					Options: options.Index().SetExpireAfterSeconds(0),
+				},
+			)
+		},
+	},
+	{
+		Id:          "0005_backfill_versions",
+		Description: "Record initial snapshots of documents created before versioning, so that they appear in history and as-of listings",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			for collection, versions := range versionedCollections {
+				if err := backfillVersions(ctx, db, collection, versions); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Id:          "0006_unique_lock_ids",
+		Description: "Enforce uniqueness of the locks serializing reservations of the same equipment",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			return createIndexes(ctx, db, "locks", mongo.IndexModel{
+				Keys:    bson.D{{Key: "id", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			})
+		},
+	},
+	{
+		Id:          "0007_version_counters",
+		Description: "Allocate version numbers from per-document counters initialized by the recorded snapshots",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			for collection, versions := range versionedCollections {
+				if err := initVersionCounters(ctx, db, versions, collection+"_version_counters"); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Id:          "0008_as_of_indexes",
+		Description: "Index snapshots by the room and time used to find candidates of as-of listings",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			for _, collection := range versionCollections {
+				err := createIndexes(ctx, db, collection, mongo.IndexModel{
+					Keys: bson.D{{Key: "document.room", Value: 1}, {Key: "recordedAt", Value: 1}},
+				})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+}
+
+// initVersionCounters sets the counter of each document to its latest recorded version,
+// counters already advanced by the running service are kept
+func initVersionCounters(ctx context.Context, db *mongo.Database, versions string, counters string) error {
+	err := createIndexes(ctx, db, counters, mongo.IndexModel{
+		Keys:    bson.D{{Key: "id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	cursor, err := db.Collection(versions).Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{"_id": "$id", "version": bson.M{"$max": "$version"}}}},
+		{{Key: "$project", Value: bson.M{"_id": 0, "id": "$_id", "version": 1}}},
+		{{Key: "$merge", Value: bson.M{
+			"into": counters,
+			"on":   "id",
+			"whenMatched": bson.A{bson.M{"$set": bson.M{
+				"version": bson.M{"$max": bson.A{"$version", "$$new.version"}},
+			}}},
+			"whenNotMatched": "insert",
+		}}},
+	})
+	if err != nil {
+		return err
+	}
+	return cursor.Close(ctx)
+}
+
+// backfillVersions records the create snapshot of each document without versions, the documents
+// soft deleted meanwhile get also the snapshot of their deletion, the time of creation is taken from _id
+func backfillVersions(ctx context.Context, db *mongo.Database, collection string, versions string) error {
+	cursor, err := db.Collection(collection).Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$lookup", Value: bson.M{
+			"from":         versions,
+			"localField":   "id",
+			"foreignField": "id",
+			"pipeline":     bson.A{bson.M{"$limit": 1}, bson.M{"$project": bson.M{"_id": 1}}},
+			"as":           "versions",
+		}}},
+		{{Key: "$match", Value: bson.M{"versions": bson.M{"$size": 0}}}},
+		{{Key: "$unset", Value: "versions"}},
+	})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	snapshots := []interface{}{}
+	for cursor.Next(ctx) {
+		document := bson.M{}
+		if err := cursor.Decode(&document); err != nil {
+			return err
+		}
+		snapshots = append(snapshots, initialSnapshots(document)...)
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+	if len(snapshots) == 0 {
+		return nil
+	}
+
+	// the service may record versions of the same documents meanwhile, their snapshots are kept
+	_, err = db.Collection(versions).InsertMany(ctx, snapshots, options.InsertMany().SetOrdered(false))
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	return err
+}
+
+// initialSnapshots reconstructs the history of the document, it is stored as it is now, except the deletion
+func initialSnapshots(document bson.M) []interface{} {
+	createdAt := time.Unix(0, 0).UTC()
+	if id, ok := document["_id"].(primitive.ObjectID); ok {
+		createdAt = id.Timestamp().UTC()
+	}
+	delete(document, "_id")
+
+	deletedAt, deleted := document["deletedAt"].(primitive.DateTime)
+	if !deleted {
+		return []interface{}{snapshot(document["id"], 1, "create", createdAt, document)}
+	}
+
+	created := bson.M{}
+	for key, value := range document {
+		if key != "deletedAt" && key != "deletedBy" {
+			created[key] = value
+		}
+	}
+	return []interface{}{
+		snapshot(document["id"], 1, "create", createdAt, created),
+		snapshot(document["id"], 2, "soft-delete", deletedAt.Time().UTC(), document),
+	}
+}
+
+// snapshot has the layout of db_service.DocumentVersion
+func snapshot(id interface{}, version int64, operation string, recordedAt time.Time, document bson.M) bson.M {
+	return bson.M{
+		"id":         id,
+		"version":    version,
+		"operation":  operation,
+		"recordedAt": recordedAt,
+		"document":   document,
+	}
+}
+
+// DuplicateIdsError reports ids shared by multiple documents of the collection
+type DuplicateIdsError struct {
+	Collection string
+	Ids        []string
+}
+
+func (this *DuplicateIdsError) Error() string {
+	return fmt.Sprintf(
+		"collection %v contains %d ids shared by multiple documents (%v), "+
+			"list them by fpjp-admin verify and remove or re-identify the duplicates before migrating",
+		this.Collection, len(this.Ids), strings.Join(this.Ids, ", "))
+}
+
+// DuplicateIds finds ids shared by multiple documents of the collection,
+// they were possible before the ids were enforced unique
+func DuplicateIds(ctx context.Context, db *mongo.Database, collection string) ([]string, error) {
+	cursor, err := db.Collection(collection).Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{"_id": "$id", "count": bson.M{"$sum": 1}}}},
+		{{Key: "$match", Value: bson.M{"count": bson.M{"$gt": 1}}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	ids := []string{}
+	for cursor.Next(ctx) {
+		group := struct {
+			Id interface{} `bson:"_id"`
+		}{}
+		if err := cursor.Decode(&group); err != nil {
+			return nil, err
+		}
+		ids = append(ids, fmt.Sprint(group.Id))
+	}
+	return ids, cursor.Err()
+}
+
+func checkUniqueIds(ctx context.Context, db *mongo.Database, collection string) error {
+	ids, err := DuplicateIds(ctx, db, collection)
+	if err != nil {
+		return err
+	}
+	if len(ids) > 0 {
+		return &DuplicateIdsError{Collection: collection, Ids: ids}
+	}
+	return nil
+}
+
+// createIndexes creates the indexes, existing indexes with the same keys and options are left as they are
+func createIndexes(ctx context.Context, db *mongo.Database, collection string, indexes ...mongo.IndexModel) error {
+	_, err := db.Collection(collection).Indexes().CreateMany(ctx, indexes)
+	return err
+}
+
+// dropIndexIfNotUnique removes the index, so that it can be replaced by unique one with the same keys
+func dropIndexIfNotUnique(ctx context.Context, db *mongo.Database, collection string, name string) error {
+	specifications, err := db.Collection(collection).Indexes().ListSpecifications(ctx)
+	if err != nil {
+		return err
+	}
+	for _, specification := range specifications {
+		if specification.Name != name || (specification.Unique != nil && *specification.Unique) {
+			continue
+		}
+		_, err := db.Collection(collection).Indexes().DropOne(ctx, name)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestInitialSnapshotsOfExistingDocument(t *testing.T) {
+	createdAt := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	document := bson.M{"_id": primitive.NewObjectIDFromTimestamp(createdAt), "id": "eq-1", "name": "Ventilator"}
+
+	snapshots := initialSnapshots(document)
+	if len(snapshots) != 1 {
+		t.Fatalf("expected single snapshot, got %v", len(snapshots))
+	}
+	first := snapshots[0].(bson.M)
+	if first["operation"] != "create" || first["version"] != int64(1) || !first["recordedAt"].(time.Time).Equal(createdAt) {
+		t.Errorf("unexpected snapshot %v", first)
+	}
+	if _, ok := first["document"].(bson.M)["_id"]; ok {
+		t.Errorf("snapshot must not keep _id of the document")
+	}
+}
+
+func TestInitialSnapshotsOfSoftDeletedDocument(t *testing.T) {
+	deletedAt := time.Date(2024, 4, 1, 8, 0, 0, 0, time.UTC)
+	document := bson.M{
+		"_id":       primitive.NewObjectIDFromTimestamp(deletedAt.AddDate(0, -1, 0)),
+		"id":        "eq-1",
+		"deletedAt": primitive.NewDateTimeFromTime(deletedAt),
+		"deletedBy": "admin",
+	}
+
+	snapshots := initialSnapshots(document)
+	if len(snapshots) != 2 {
+		t.Fatalf("expected create and soft-delete snapshots, got %v", len(snapshots))
+	}
+	created := snapshots[0].(bson.M)
+	if _, ok := created["document"].(bson.M)["deletedAt"]; ok {
+		t.Errorf("created snapshot must not be deleted")
+	}
+	deleted := snapshots[1].(bson.M)
+	if deleted["operation"] != "soft-delete" || deleted["version"] != int64(2) || !deleted["recordedAt"].(time.Time).Equal(deletedAt) {
+		t.Errorf("unexpected snapshot %v", deleted)
+	}
+}
+
+func TestUniqueIdsReportsDuplicates(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("duplicates", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.departments", mtest.FirstBatch,
+			bson.D{{Key: "_id", Value: "dep-1"}, {Key: "count", Value: 2}},
+			bson.D{{Key: "_id", Value: "dep-2"}, {Key: "count", Value: 3}},
+		))
+		err := All[1].Up(context.Background(), mt.DB)
+
+		duplicates := &DuplicateIdsError{}
+		if !errors.As(err, &duplicates) {
+			t.Fatalf("expected DuplicateIdsError, got %v", err)
+		}
+		if duplicates.Collection != "departments" || strings.Join(duplicates.Ids, ",") != "dep-1,dep-2" {
+			t.Errorf("unexpected duplicates %v", duplicates)
+		}
+		if !strings.Contains(err.Error(), "fpjp-admin verify") {
+			t.Errorf("error should point to the verify command, got %v", err)
+		}
+		if started := mt.GetAllStartedEvents(); len(started) != 1 || started[0].CommandName != "aggregate" {
+			t.Errorf("indexes must not be touched while duplicates exist, commands %v", len(started))
+		}
+	})
+
+	mt.Run("no duplicates", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.departments", mtest.FirstBatch))
+		ids, err := DuplicateIds(context.Background(), mt.DB, "departments")
+		if err != nil || len(ids) != 0 {
+			t.Errorf("expected no duplicates, got %v, %v", ids, err)
+		}
+	})
+}
@@ -0,0 +1,165 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// collection recording applied migrations
+const MigrationsCollection = "schema_migrations"
+
+const (
+	lockCollection = "schema_migrations_lock"
+	lockId         = "lock"
+	// lock of an instance which crashed during migration is taken over after this period
+	lockExpiration = 10 * time.Minute
+	lockRetry      = time.Second
+)
+
+// Migration changes schema or data of the database, it must be safe to run
+// again if it fails half way, because it is recorded only after it succeeds
+type Migration struct {
+	// migrations are applied in lexical order of their identifiers
+	Id          string
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database) error
+}
+
+// AppliedMigration is a record of the migration stored in the database
+type AppliedMigration struct {
+	Id          string    `json:"id" bson:"id"`
+	Description string    `json:"description" bson:"description"`
+	AppliedAt   time.Time `json:"appliedAt" bson:"appliedAt"`
+	DurationMs  float64   `json:"durationMs" bson:"durationMs"`
+}
+
+type migrationLock struct {
+	Id       string    `bson:"_id"`
+	Owner    string    `bson:"owner"`
+	LockedAt time.Time `bson:"lockedAt"`
+}
+
+// Applied returns the migrations recorded in the database
+func Applied(ctx context.Context, db *mongo.Database) (map[string]AppliedMigration, error) {
+	cursor, err := db.Collection(MigrationsCollection).Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	applied := map[string]AppliedMigration{}
+	for cursor.Next(ctx) {
+		migration := AppliedMigration{}
+		if err := cursor.Decode(&migration); err != nil {
+			return nil, err
+		}
+		applied[migration.Id] = migration
+	}
+	return applied, cursor.Err()
+}
+
+// Run applies the migrations which were not applied yet and returns their identifiers,
+// concurrently starting instances wait until the first one finishes
+func Run(ctx context.Context, db *mongo.Database, migrations []Migration) ([]string, error) {
+	release, err := acquireLock(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	_, err = db.Collection(MigrationsCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := Applied(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make([]Migration, 0, len(migrations))
+	for _, migration := range migrations {
+		if _, ok := applied[migration.Id]; !ok {
+			pending = append(pending, migration)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].Id < pending[j].Id
+	})
+
+	done := []string{}
+	for _, migration := range pending {
+		slog.InfoContext(ctx, "Applying migration", "migration", migration.Id, "description", migration.Description)
+		start := time.Now()
+		if err := migration.Up(ctx, db); err != nil {
+			return done, fmt.Errorf("migration %v failed: %w", migration.Id, err)
+		}
+
+		_, err := db.Collection(MigrationsCollection).InsertOne(ctx, AppliedMigration{
+			Id:          migration.Id,
+			Description: migration.Description,
+			AppliedAt:   time.Now().UTC(),
+			DurationMs:  float64(time.Since(start).Microseconds()) / 1000,
+		})
+		if err != nil {
+			return done, fmt.Errorf("failed to record migration %v: %w", migration.Id, err)
+		}
+		done = append(done, migration.Id)
+	}
+	return done, nil
+}
+
+// acquireLock waits until no other instance runs the migrations
+func acquireLock(ctx context.Context, db *mongo.Database) (func(), error) {
+	collection := db.Collection(lockCollection)
+	hostname, _ := os.Hostname()
+	owner := fmt.Sprintf("%v/%v", hostname, uuid.New().String())
+
+	for {
+		now := time.Now().UTC()
+		_, err := collection.InsertOne(ctx, migrationLock{Id: lockId, Owner: owner, LockedAt: now})
+		if err == nil {
+			release := func() {
+				// release even if the migration context is already cancelled
+				releaseCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				defer cancel()
+				if _, err := collection.DeleteOne(releaseCtx, bson.M{"_id": lockId, "owner": owner}); err != nil {
+					slog.Warn("Failed to release migration lock", "error", err)
+				}
+			}
+			return release, nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return nil, err
+		}
+
+		// take over lock left behind by crashed instance
+		result, err := collection.DeleteOne(ctx, bson.M{"_id": lockId, "lockedAt": bson.M{"$lt": now.Add(-lockExpiration)}})
+		if err != nil {
+			return nil, err
+		}
+		if result.DeletedCount > 0 {
+			slog.WarnContext(ctx, "Expired migration lock removed")
+			continue
+		}
+
+		slog.InfoContext(ctx, "Waiting for migrations run by another instance")
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(lockRetry):
+		}
+	}
+}
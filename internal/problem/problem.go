@@ -0,0 +1,173 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/logging"
+)
+
+// ContentType of the error responses, see RFC 7807
+const ContentType = "application/problem+json"
+
+// machine readable codes shared by all endpoints, codes of missing resources
+// are composed by NotFound as <resource>-not-found
+const (
+	CodeInvalidBody          = "invalid-body"
+	CodeInvalidParameter     = "invalid-parameter"
+	CodeIdMismatch           = "id-mismatch"
+	CodeForbidden            = "forbidden"
+	CodeAlreadyExists        = "already-exists"
+	CodeUnsupportedMediaType = "unsupported-media-type"
+	CodeDatabaseError        = "database-error"
+	CodeInternalError        = "internal-error"
+)
+
+// Problem is the body of the error response, the code is stable
+// and clients should rely on it instead of the human readable detail
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Code      string       `json:"code"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	RequestId string       `json:"requestId,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+	// additional members specific to the problem
+	Extensions map[string]interface{} `json:"-"`
+
+	// internal error which caused the problem, it is logged but never sent to the client
+	cause error
+}
+
+// FieldError describes invalid field of the body or invalid parameter
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func New(status int, code string, detail string) *Problem {
+	return &Problem{
+		// the problems are distinguished by the code, so the type carries no additional semantics
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Code:   code,
+		Detail: detail,
+	}
+}
+
+func (this *Problem) Error() string {
+	if this.cause != nil {
+		return fmt.Sprintf("%v: %v", this.Code, this.cause)
+	}
+	return fmt.Sprintf("%v: %v", this.Code, this.Detail)
+}
+
+func (this *Problem) Unwrap() error {
+	return this.cause
+}
+
+// WithCause attaches the internal error to be logged
+func (this *Problem) WithCause(err error) *Problem {
+	this.cause = err
+	return this
+}
+
+// WithErrors attaches details about the invalid fields
+func (this *Problem) WithErrors(errors ...FieldError) *Problem {
+	this.Errors = append(this.Errors, errors...)
+	return this
+}
+
+// With adds problem specific member to the response
+func (this *Problem) With(member string, value interface{}) *Problem {
+	if this.Extensions == nil {
+		this.Extensions = map[string]interface{}{}
+	}
+	this.Extensions[member] = value
+	return this
+}
+
+// MarshalJSON puts the extension members next to the standard ones
+func (this Problem) MarshalJSON() ([]byte, error) {
+	type standard Problem
+	data, err := json.Marshal(standard(this))
+	if err != nil || len(this.Extensions) == 0 {
+		return data, err
+	}
+	members := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+	for member, value := range this.Extensions {
+		if _, reserved := members[member]; reserved {
+			continue
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		members[member] = encoded
+	}
+	return json.Marshal(members)
+}
+
+// NotFound reports missing resource, e.g. code equipment-not-found for resource equipment
+func NotFound(resource string, detail string) *Problem {
+	return New(http.StatusNotFound, resource+"-not-found", detail)
+}
+
+// InvalidParameter reports invalid path or query parameter
+func InvalidParameter(parameter string, detail string) *Problem {
+	return New(http.StatusBadRequest, CodeInvalidParameter, detail).
+		WithErrors(FieldError{Field: parameter, Code: "invalid", Message: detail})
+}
+
+// Database reports failure of the database, the details are only logged
+func Database(err error) *Problem {
+	return New(http.StatusBadGateway, CodeDatabaseError, "The database could not process the request.").WithCause(err)
+}
+
+// Internal reports unexpected failure of the service, the details are only logged
+func Internal(err error) *Problem {
+	return New(http.StatusInternalServerError, CodeInternalError, "Unexpected error occurred while handling the request.").WithCause(err)
+}
+
+// From returns the problem carried by the error, other errors are considered internal
+func From(err error) *Problem {
+	var problem *Problem
+	if errors.As(err, &problem) {
+		return problem
+	}
+	return Internal(err)
+}
+
+// Respond writes the problem carried by the error and aborts the request,
+// server side failures are logged together with their cause
+func Respond(ctx *gin.Context, err error) {
+	problem := *From(err)
+	if problem.Status >= http.StatusInternalServerError {
+		slog.ErrorContext(ctx, "Request failed", "code", problem.Code, "error", problem.cause)
+	}
+	problem.Instance = ctx.Request.URL.Path
+	problem.RequestId = logging.RequestID(ctx)
+
+	ctx.Header("Content-Type", ContentType)
+	ctx.AbortWithStatusJSON(problem.Status, problem)
+}
+
+// Recovery logs panics of the handlers and responds with internal error problem
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(ctx *gin.Context, err any) {
+		slog.ErrorContext(ctx, "Panic recovered", "error", err)
+		Respond(ctx, New(http.StatusInternalServerError, CodeInternalError, "Unexpected error occurred while handling the request."))
+	})
+}
@@ -0,0 +1,89 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+func init() {
+	// report fields of the body by their json names instead of the go names
+	if validate, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				return ""
+			}
+			if name == "" {
+				return field.Name
+			}
+			return name
+		})
+	}
+}
+
+// InvalidBody reports request body which could not be decoded or validated,
+// with details about each invalid field when they are known
+func InvalidBody(err error) *Problem {
+	problem := New(http.StatusBadRequest, CodeInvalidBody, "Request body is not valid.").WithCause(err)
+
+	var validationErrors validator.ValidationErrors
+	var typeError *json.UnmarshalTypeError
+	var syntaxError *json.SyntaxError
+	switch {
+	case errors.As(err, &validationErrors):
+		for _, fieldError := range validationErrors {
+			problem.WithErrors(FieldError{
+				Field:   fieldPath(fieldError.Namespace()),
+				Code:    fieldError.Tag(),
+				Message: validationMessage(fieldError),
+			})
+		}
+	case errors.As(err, &typeError):
+		problem.WithErrors(FieldError{
+			Field:   typeError.Field,
+			Code:    "type",
+			Message: fmt.Sprintf("must be of type %v", typeError.Type.Kind()),
+		})
+	case errors.As(err, &syntaxError), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		problem.Detail = "Request body is not well-formed JSON."
+	default:
+		problem.Detail = err.Error()
+	}
+	return problem
+}
+
+// fieldPath strips name of the top level struct from the validator namespace
+func fieldPath(namespace string) string {
+	_, path, found := strings.Cut(namespace, ".")
+	if !found {
+		return namespace
+	}
+	return path
+}
+
+func validationMessage(fieldError validator.FieldError) string {
+	switch fieldError.Tag() {
+	case "required":
+		return "is required"
+	case "oneof":
+		return fmt.Sprintf("must be one of: %v", strings.Join(strings.Fields(fieldError.Param()), ", "))
+	case "min":
+		return fmt.Sprintf("must be at least %v", fieldError.Param())
+	case "max":
+		return fmt.Sprintf("must be at most %v", fieldError.Param())
+	case "gtfield":
+		// the parameter is name of the go field, json names of the fields start with lower case
+		other := fieldError.Param()
+		return fmt.Sprintf("must be after %v", strings.ToLower(other[:1])+other[1:])
+	default:
+		return fmt.Sprintf("does not satisfy %v %v", fieldError.Tag(), fieldError.Param())
+	}
+}
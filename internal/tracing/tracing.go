@@ -0,0 +1,133 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/ns-super-team/fpjp-ambulance-webapi"
+
+// Setup configures the global tracer provider according to AMBULANCE_API_TRACING_EXPORTER:
+//   - none (default) - spans are not exported
+//   - otlp - spans are sent to OTEL_EXPORTER_OTLP_ENDPOINT over HTTP
+//   - stdout - spans are written to the standard output
+//   - file - spans are appended to AMBULANCE_API_TRACING_FILE
+//
+// The returned function flushes pending spans and must be called before exit.
+func Setup(ctx context.Context, serviceName string) (func(context.Context) error, error) {
+	// W3C trace context is always propagated, even if spans are not exported
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	exporterName := strings.ToLower(os.Getenv("AMBULANCE_API_TRACING_EXPORTER"))
+	var exporter sdktrace.SpanExporter
+	var closer io.Closer
+	var err error
+	switch exporterName {
+	case "", "none":
+		return func(context.Context) error { return nil }, nil
+	case "otlp":
+		exporter, err = otlptracehttp.New(ctx)
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case "file":
+		path := os.Getenv("AMBULANCE_API_TRACING_FILE")
+		if path == "" {
+			path = "traces.json"
+		}
+		var file *os.File
+		file, err = os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err == nil {
+			closer = file
+			exporter, err = stdouttrace.New(stdouttrace.WithWriter(file))
+		}
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter: %v", exporterName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	slog.Info("Tracing enabled", "exporter", exporterName)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			if closeErr := closer.Close(); err == nil {
+				err = closeErr
+			}
+		}
+		return err
+	}, nil
+}
+
+// Middleware starts server span for each request, continuing the trace provided by the caller
+func Middleware() gin.HandlerFunc {
+	tracer := otel.Tracer(instrumentationName)
+	propagator := otel.GetTextMapPropagator()
+
+	return func(ctx *gin.Context) {
+		parent := propagator.Extract(ctx.Request.Context(), propagation.HeaderCarrier(ctx.Request.Header))
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		spanCtx, span := tracer.Start(
+			parent,
+			ctx.Request.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(ctx.Request.Method),
+				semconv.HTTPRoute(route),
+				semconv.URLPath(ctx.Request.URL.Path),
+				attribute.String("client.address", ctx.ClientIP()),
+			),
+		)
+		defer span.End()
+
+		// handlers pass the gin context to the services, which falls back to the request context
+		ctx.Request = ctx.Request.WithContext(spanCtx)
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if status >= 500 {
+			span.SetStatus(codes.Error, fmt.Sprintf("HTTP %d", status))
+		}
+		for _, err := range ctx.Errors {
+			span.RecordError(err.Err)
+		}
+	}
+}
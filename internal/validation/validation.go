@@ -0,0 +1,295 @@
+package validation
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/gin-gonic/gin"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/problem"
+)
+
+func init() {
+	// content types used by the spec which the validator does not know by default
+	openapi3filter.RegisterBodyDecoder("application/merge-patch+json", openapi3filter.JSONBodyDecoder)
+	openapi3filter.RegisterBodyDecoder("text/calendar", textBodyDecoder)
+}
+
+type Options struct {
+	// validate also the responses of the handlers, violations are only logged
+	// because the response is already sent to the client
+	ValidateResponses bool
+}
+
+// Middleware validates requests of the operations described by the OpenAPI spec,
+// requests of other routes are passed to the handlers unchanged
+func Middleware(spec []byte, options Options) (gin.HandlerFunc, error) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(spec)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load OpenAPI spec: %w", err)
+	}
+	// examples are documentation only, some of them are shared by a list and a single item
+	if err := doc.Validate(context.Background(), openapi3.DisableExamplesValidation()); err != nil {
+		return nil, fmt.Errorf("OpenAPI spec is not valid: %w", err)
+	}
+	routes, err := operationRoutes(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	filterOptions := &openapi3filter.Options{
+		MultiError: true,
+		// the request is validated, not changed
+		SkipSettingDefaults: true,
+		AuthenticationFunc:  openapi3filter.NoopAuthenticationFunc,
+	}
+
+	return func(ctx *gin.Context) {
+		route, exists := routes[routeKey(ctx.Request.Method, ctx.FullPath())]
+		if !exists {
+			ctx.Next()
+			return
+		}
+
+		pathParams := map[string]string{}
+		for _, param := range ctx.Params {
+			pathParams[param.Key] = param.Value
+		}
+		input := &openapi3filter.RequestValidationInput{
+			Request:    ctx.Request,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    filterOptions,
+		}
+		if err := openapi3filter.ValidateRequest(ctx, input); err != nil {
+			problem.Respond(ctx, requestProblem(err))
+			return
+		}
+
+		if !options.ValidateResponses {
+			ctx.Next()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: ctx.Writer}
+		ctx.Writer = recorder
+		ctx.Next()
+		ctx.Writer = recorder.ResponseWriter
+
+		responseInput := &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: input,
+			Status:                 recorder.Status(),
+			Header:                 recorder.Header(),
+			Body:                   io.NopCloser(bytes.NewReader(recorder.body.Bytes())),
+			Options:                filterOptions,
+		}
+		if err := openapi3filter.ValidateResponse(ctx, responseInput); err != nil {
+			slog.WarnContext(ctx, "Response does not match OpenAPI spec",
+				"operation", route.Operation.OperationID,
+				"status", recorder.Status(),
+				"error", err)
+		}
+	}, nil
+}
+
+// operationRoutes maps gin routes to the operations of the spec, paths of the spec
+// are relative to the URL of each server, e.g. of the version and of its alias
+func operationRoutes(doc *openapi3.T) (map[string]*routers.Route, error) {
+	servers := doc.Servers
+	if len(servers) == 0 {
+		servers = openapi3.Servers{nil}
+	}
+
+	routes := map[string]*routers.Route{}
+	for _, server := range servers {
+		basePath := ""
+		if server != nil {
+			serverURL, err := url.Parse(server.URL)
+			if err != nil {
+				return nil, fmt.Errorf("cannot parse server URL %v: %w", server.URL, err)
+			}
+			basePath = strings.TrimSuffix(serverURL.Path, "/")
+		}
+
+		for path, pathItem := range doc.Paths.Map() {
+			// gin uses :name instead of {name} for path parameters
+			ginPath := basePath + strings.NewReplacer("{", ":", "}", "").Replace(path)
+			for method, operation := range pathItem.Operations() {
+				routes[routeKey(method, ginPath)] = &routers.Route{
+					Spec:      doc,
+					Server:    server,
+					Path:      path,
+					PathItem:  pathItem,
+					Method:    method,
+					Operation: operation,
+				}
+			}
+		}
+	}
+	return routes, nil
+}
+
+func routeKey(method string, path string) string {
+	return method + " " + path
+}
+
+// requestProblem reports all violations of the spec found in the request
+func requestProblem(err error) *problem.Problem {
+	var fieldErrors []problem.FieldError
+	invalidBody := false
+	for _, violation := range violations(err) {
+		var requestError *openapi3filter.RequestError
+		if !errors.As(violation, &requestError) {
+			return problem.Internal(violation)
+		}
+
+		switch {
+		case requestError.Parameter != nil:
+			fieldErrors = append(fieldErrors, parameterErrors(requestError)...)
+		case requestError.RequestBody != nil:
+			if strings.HasPrefix(requestError.Reason, "header Content-Type has unexpected value") {
+				return problem.New(
+					http.StatusUnsupportedMediaType,
+					problem.CodeUnsupportedMediaType,
+					"Content type of the request body is not supported by the operation.",
+				).WithCause(err)
+			}
+			invalidBody = true
+			fieldErrors = append(fieldErrors, bodyErrors(requestError)...)
+		default:
+			return problem.Internal(requestError)
+		}
+	}
+
+	if invalidBody {
+		return problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Request body is not valid.").
+			WithErrors(fieldErrors...).
+			WithCause(err)
+	}
+	return problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Request parameters are not valid.").
+		WithErrors(fieldErrors...).
+		WithCause(err)
+}
+
+func parameterErrors(requestError *openapi3filter.RequestError) []problem.FieldError {
+	name := requestError.Parameter.Name
+	if errors.Is(requestError.Err, openapi3filter.ErrInvalidRequired) {
+		return []problem.FieldError{{Field: name, Code: "required", Message: "is required"}}
+	}
+
+	schemaErrors := schemaViolations(requestError.Err)
+	if len(schemaErrors) == 0 {
+		// the value could not be parsed
+		message := requestError.Reason
+		if requestError.Err != nil {
+			message = requestError.Err.Error()
+		}
+		return []problem.FieldError{{Field: name, Code: "invalid", Message: message}}
+	}
+	fieldErrors := make([]problem.FieldError, len(schemaErrors))
+	for i, schemaError := range schemaErrors {
+		fieldErrors[i] = schemaFieldError(name, schemaError)
+	}
+	return fieldErrors
+}
+
+func bodyErrors(requestError *openapi3filter.RequestError) []problem.FieldError {
+	if errors.Is(requestError.Err, openapi3filter.ErrInvalidRequired) {
+		return []problem.FieldError{{Field: "", Code: "required", Message: "request body is required"}}
+	}
+
+	schemaErrors := schemaViolations(requestError.Err)
+	if len(schemaErrors) == 0 {
+		// the body could not be read or decoded
+		return []problem.FieldError{{Field: "", Code: "malformed", Message: "request body is not well-formed"}}
+	}
+	fieldErrors := make([]problem.FieldError, len(schemaErrors))
+	for i, schemaError := range schemaErrors {
+		fieldErrors[i] = schemaFieldError("", schemaError)
+	}
+	return fieldErrors
+}
+
+// schemaFieldError describes the value violating the schema, the field is path of the value
+// within the body or name of the parameter followed by the path within the parameter
+func schemaFieldError(prefix string, schemaError *openapi3.SchemaError) problem.FieldError {
+	path := append([]string{}, schemaError.JSONPointer()...)
+	if prefix != "" {
+		path = append([]string{prefix}, path...)
+	}
+
+	message := schemaError.Reason
+	switch schemaError.SchemaField {
+	case "required":
+		message = "is required"
+	case "format":
+		// the reason contains also the regular expression of the format
+		message = fmt.Sprintf("must be %v string", schemaError.Schema.Format)
+	}
+	return problem.FieldError{
+		Field:   strings.Join(path, "."),
+		Code:    schemaError.SchemaField,
+		Message: message,
+	}
+}
+
+// violations flattens multi errors reported by the validator
+func violations(err error) []error {
+	// errors.As would unwrap also the request error carrying the multi error
+	multiError, ok := err.(openapi3.MultiError)
+	if !ok {
+		return []error{err}
+	}
+	var all []error
+	for _, nested := range multiError {
+		all = append(all, violations(nested)...)
+	}
+	return all
+}
+
+// schemaViolations returns the schema errors carried by the error
+func schemaViolations(err error) []*openapi3.SchemaError {
+	var schemaErrors []*openapi3.SchemaError
+	for _, violation := range violations(err) {
+		var schemaError *openapi3.SchemaError
+		if errors.As(violation, &schemaError) {
+			schemaErrors = append(schemaErrors, schemaError)
+		}
+	}
+	return schemaErrors
+}
+
+// textBodyDecoder decodes the body as single string
+func textBodyDecoder(body io.Reader, _ http.Header, _ *openapi3.SchemaRef, _ openapi3filter.EncodingFn) (any, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// responseRecorder keeps copy of the response body for validation
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (this *responseRecorder) Write(data []byte) (int, error) {
+	this.body.Write(data)
+	return this.ResponseWriter.Write(data)
+}
+
+func (this *responseRecorder) WriteString(data string) (int, error) {
+	this.body.WriteString(data)
+	return this.ResponseWriter.WriteString(data)
+}
@@ -0,0 +1,109 @@
+package validation_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/api"
+	"github.com/ns-super-team/fpjp-ambulance-webapi/internal/validation"
+)
+
+// newEngine serves the operations of the version by handlers which only acknowledge the request
+func newEngine(t *testing.T, version *api.Version) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	middleware, err := validation.Middleware(version.Specification(), validation.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	engine := gin.New()
+	group := engine.Group("/api/"+version.Name, middleware)
+	accept := func(ctx *gin.Context) { ctx.Status(http.StatusNoContent) }
+	group.POST("/rooms/:roomId/equipment", accept)
+	group.POST("/rooms/:roomId/requests", accept)
+	group.PUT("/equipment/:equipmentId", accept)
+	group.PATCH("/equipment/:equipmentId", accept)
+	group.PATCH("/requests/:requestId", accept)
+	group.GET("/unknown", accept)
+	return engine
+}
+
+func TestRequestValidation(t *testing.T) {
+	tests := map[string]struct {
+		method      string
+		path        string
+		contentType string
+		body        string
+		status      int
+		code        string
+	}{
+		"create without id": {
+			http.MethodPost, "/rooms/room-1/equipment", "application/json",
+			`{"room": "room-1", "type": "monitor", "name": "Monitor", "count": 1}`, http.StatusNoContent, "",
+		},
+		"create without required field": {
+			http.MethodPost, "/rooms/room-1/equipment", "application/json",
+			`{"room": "room-1", "type": "monitor", "count": 1}`, http.StatusBadRequest, "invalid-body",
+		},
+		"create with wrong type of field": {
+			http.MethodPost, "/rooms/room-1/equipment", "application/json",
+			`{"room": "room-1", "type": "monitor", "name": "Monitor", "count": "many"}`, http.StatusBadRequest, "invalid-body",
+		},
+		"request with unknown type": {
+			http.MethodPost, "/rooms/room-1/requests", "application/json",
+			`{"room": "room-1", "type": "wish", "name": "Monitor", "description": "New monitor"}`, http.StatusBadRequest, "invalid-body",
+		},
+		"update with id": {
+			http.MethodPut, "/equipment/eq-1", "application/json",
+			`{"id": "eq-1", "room": "room-1", "type": "monitor", "name": "Monitor", "count": 1}`, http.StatusNoContent, "",
+		},
+		"merge patch": {
+			http.MethodPatch, "/equipment/eq-1", "application/merge-patch+json", `{"count": 3}`, http.StatusNoContent, "",
+		},
+		"plain JSON patch": {
+			http.MethodPatch, "/requests/req-1", "application/json", `{"count": 3}`, http.StatusNoContent, "",
+		},
+		"JSON patch": {
+			http.MethodPatch, "/equipment/eq-1", "application/json-patch+json",
+			`[{"op": "replace", "path": "/count", "value": 3}]`, http.StatusNoContent, "",
+		},
+		"patch of unsupported media type": {
+			http.MethodPatch, "/equipment/eq-1", "text/plain", `count=3`, http.StatusUnsupportedMediaType, "unsupported-media-type",
+		},
+		"route outside of the spec": {
+			http.MethodGet, "/unknown", "", "", http.StatusNoContent, "",
+		},
+	}
+	for _, version := range api.Versions {
+		engine := newEngine(t, version)
+		for name, test := range tests {
+			t.Run(version.Name+"/"+name, func(t *testing.T) {
+				request := httptest.NewRequest(test.method, "/api/"+version.Name+test.path, strings.NewReader(test.body))
+				if test.contentType != "" {
+					request.Header.Set("Content-Type", test.contentType)
+				}
+				response := httptest.NewRecorder()
+				engine.ServeHTTP(response, request)
+
+				if response.Code != test.status {
+					t.Fatalf("expected status %v, got %v: %v", test.status, response.Code, response.Body.String())
+				}
+				if test.code == "" {
+					return
+				}
+				problem := map[string]interface{}{}
+				if err := json.Unmarshal(response.Body.Bytes(), &problem); err != nil {
+					t.Fatal(err)
+				}
+				if problem["code"] != test.code {
+					t.Errorf("expected problem %v, got %v", test.code, problem["code"])
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package versioning
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Deprecation struct {
+	// time since which the path is deprecated, zero means the deprecation is only announced
+	DeprecatedAt time.Time
+	// time after which the path may stop responding, zero omits the Sunset header
+	Sunset time.Time
+	// deprecated base path and the base path of the version replacing it, e.g. /api and /api/v1
+	BasePath      string
+	SuccessorPath string
+}
+
+// Deprecated marks responses of the deprecated base path by the Deprecation (RFC 9745)
+// and Sunset (RFC 8594) headers, the Link header points to the same resource of the successor
+func Deprecated(deprecation Deprecation) gin.HandlerFunc {
+	deprecated := "true"
+	if !deprecation.DeprecatedAt.IsZero() {
+		deprecated = fmt.Sprintf("@%d", deprecation.DeprecatedAt.Unix())
+	}
+	sunset := ""
+	if !deprecation.Sunset.IsZero() {
+		sunset = deprecation.Sunset.UTC().Format(http.TimeFormat)
+	}
+	basePath := strings.TrimSuffix(deprecation.BasePath, "/")
+	successorPath := strings.TrimSuffix(deprecation.SuccessorPath, "/")
+
+	return func(ctx *gin.Context) {
+		header := ctx.Writer.Header()
+		header.Set("Deprecation", deprecated)
+		if sunset != "" {
+			header.Set("Sunset", sunset)
+		}
+		if path, found := strings.CutPrefix(ctx.Request.URL.Path, basePath); found {
+			header.Add("Link", fmt.Sprintf(`<%v%v>; rel="successor-version"`, successorPath, path))
+		}
+		ctx.Next()
+	}
+}
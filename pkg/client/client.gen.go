@@ -0,0 +1,4561 @@
+// Package client provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/oapi-codegen/runtime"
+)
+
+// Defines values for BatchOperationAction.
+const (
+	BatchOperationActionCreate BatchOperationAction = "create"
+	BatchOperationActionDelete BatchOperationAction = "delete"
+	BatchOperationActionUpdate BatchOperationAction = "update"
+)
+
+// Defines values for BatchOperationResource.
+const (
+	BatchOperationResourceEquipment BatchOperationResource = "equipment"
+	BatchOperationResourceRequest   BatchOperationResource = "request"
+)
+
+// Defines values for BatchRequestMode.
+const (
+	BatchRequestModeBestEffort    BatchRequestMode = "best-effort"
+	BatchRequestModeTransactional BatchRequestMode = "transactional"
+)
+
+// Defines values for BatchResponseMode.
+const (
+	BatchResponseModeBestEffort    BatchResponseMode = "best-effort"
+	BatchResponseModeTransactional BatchResponseMode = "transactional"
+)
+
+// Defines values for EquipmentVersionOperation.
+const (
+	EquipmentVersionOperationCreate     EquipmentVersionOperation = "create"
+	EquipmentVersionOperationDelete     EquipmentVersionOperation = "delete"
+	EquipmentVersionOperationRestore    EquipmentVersionOperation = "restore"
+	EquipmentVersionOperationSoftDelete EquipmentVersionOperation = "soft-delete"
+	EquipmentVersionOperationUpdate     EquipmentVersionOperation = "update"
+)
+
+// Defines values for RequestType.
+const (
+	MissingEquipment RequestType = "missing-equipment"
+	Repair           RequestType = "repair"
+)
+
+// Defines values for RequestVersionOperation.
+const (
+	Create     RequestVersionOperation = "create"
+	Delete     RequestVersionOperation = "delete"
+	Restore    RequestVersionOperation = "restore"
+	SoftDelete RequestVersionOperation = "soft-delete"
+	Update     RequestVersionOperation = "update"
+)
+
+// Defines values for StockAdjustmentReason.
+const (
+	StockAdjustmentReasonConsumed  StockAdjustmentReason = "consumed"
+	StockAdjustmentReasonFound     StockAdjustmentReason = "found"
+	StockAdjustmentReasonLost      StockAdjustmentReason = "lost"
+	StockAdjustmentReasonRestocked StockAdjustmentReason = "restocked"
+)
+
+// Defines values for StockMovementReason.
+const (
+	StockMovementReasonConsumed  StockMovementReason = "consumed"
+	StockMovementReasonFound     StockMovementReason = "found"
+	StockMovementReasonLost      StockMovementReason = "lost"
+	StockMovementReasonRestocked StockMovementReason = "restocked"
+)
+
+// Defines values for GetDepartmentReservationsParamsFormat.
+const (
+	GetDepartmentReservationsParamsFormatIcs  GetDepartmentReservationsParamsFormat = "ics"
+	GetDepartmentReservationsParamsFormatJson GetDepartmentReservationsParamsFormat = "json"
+)
+
+// Defines values for GetEquipmentAdjustmentsParamsReason.
+const (
+	Consumed  GetEquipmentAdjustmentsParamsReason = "consumed"
+	Found     GetEquipmentAdjustmentsParamsReason = "found"
+	Lost      GetEquipmentAdjustmentsParamsReason = "lost"
+	Restocked GetEquipmentAdjustmentsParamsReason = "restocked"
+)
+
+// Defines values for GetEquipmentReservationsParamsFormat.
+const (
+	GetEquipmentReservationsParamsFormatIcs  GetEquipmentReservationsParamsFormat = "ics"
+	GetEquipmentReservationsParamsFormatJson GetEquipmentReservationsParamsFormat = "json"
+)
+
+// BatchOperation defines model for BatchOperation.
+type BatchOperation struct {
+	// Action Operation applied to the resource
+	Action    BatchOperationAction `json:"action"`
+	Equipment *Equipment           `json:"equipment,omitempty"`
+
+	// Id Identifier of the updated or deleted resource, the update defaults to id of the body
+	Id      *string  `json:"id,omitempty"`
+	Request *Request `json:"request,omitempty"`
+
+	// Resource Type of the resource
+	Resource BatchOperationResource `json:"resource"`
+}
+
+// BatchOperationAction Operation applied to the resource
+type BatchOperationAction string
+
+// BatchOperationResource Type of the resource
+type BatchOperationResource string
+
+// BatchRequest defines model for BatchRequest.
+type BatchRequest struct {
+	// Mode All-or-nothing or independent execution of the operations
+	Mode *BatchRequestMode `json:"mode,omitempty"`
+
+	// Operations Operations executed in the given order
+	Operations []BatchOperation `json:"operations"`
+}
+
+// BatchRequestMode All-or-nothing or independent execution of the operations
+type BatchRequestMode string
+
+// BatchResponse defines model for BatchResponse.
+type BatchResponse struct {
+	// Mode Mode in which the operations were executed
+	Mode BatchResponseMode `json:"mode"`
+
+	// Results Results in the order of the operations
+	Results []BatchResult `json:"results"`
+}
+
+// BatchResponseMode Mode in which the operations were executed
+type BatchResponseMode string
+
+// BatchResult defines model for BatchResult.
+type BatchResult struct {
+	// Body Created or updated resource, or the problem of the failed operation
+	Body *BatchResult_Body `json:"body,omitempty"`
+
+	// Id Identifier of the affected resource
+	Id *string `json:"id,omitempty"`
+
+	// Index Position of the operation in the batch
+	Index int32 `json:"index"`
+
+	// Status HTTP status code the operation would have as a separate request
+	Status int32 `json:"status"`
+}
+
+// BatchResult_Body Created or updated resource, or the problem of the failed operation
+type BatchResult_Body struct {
+	union json.RawMessage
+}
+
+// Department defines model for Department.
+type Department struct {
+	// Id Unique identifier of the department
+	Id string `json:"id"`
+
+	// Name Name of the department
+	Name string `json:"name"`
+}
+
+// Equipment defines model for Equipment.
+type Equipment struct {
+	// Count Number of equipment items available
+	Count int `json:"count"`
+
+	// DeletedAt Time when the equipment was deleted, present only for deleted equipment
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+
+	// DeletedBy Identity of the user who deleted the equipment
+	DeletedBy *string `json:"deletedBy,omitempty"`
+
+	// Id Unique identifier of the equipment
+	Id *string `json:"id,omitempty"`
+
+	// Name Name of the equipment
+	Name string `json:"name"`
+
+	// Room Identifier of the room the equipment belongs to
+	Room string `json:"room"`
+
+	// Type Type of the equipment
+	Type string `json:"type"`
+}
+
+// EquipmentAvailability defines model for EquipmentAvailability.
+type EquipmentAvailability struct {
+	// Available Number of items available for the whole calendar window
+	Available int32     `json:"available"`
+	Equipment Equipment `json:"equipment"`
+
+	// PeakReserved Highest number of items reserved at the same time within the calendar window
+	PeakReserved int32 `json:"peakReserved"`
+
+	// Reservations Reservations of the equipment overlapping the calendar window
+	Reservations []Reservation `json:"reservations"`
+}
+
+// EquipmentVersion defines model for EquipmentVersion.
+type EquipmentVersion struct {
+	Document Equipment `json:"document"`
+
+	// Id Unique identifier of the equipment
+	Id string `json:"id"`
+
+	// Operation Operation which produced the version
+	Operation EquipmentVersionOperation `json:"operation"`
+
+	// RecordedAt Time when the version was recorded
+	RecordedAt time.Time `json:"recordedAt"`
+
+	// Version Sequence number of the version, starting with 1
+	Version int64 `json:"version"`
+}
+
+// EquipmentVersionOperation Operation which produced the version
+type EquipmentVersionOperation string
+
+// Problem Error response as defined by RFC 7807
+type Problem struct {
+	// Code Stable machine readable code of the problem, e.g. invalid-body, invalid-parameter, id-mismatch, forbidden, already-exists, unsupported-media-type, database-error, internal-error, insufficient-stock, equipment-not-available, reason-mismatch, not-versioned, transactions-not-supported, not-applied, idempotency-key-in-progress, idempotency-key-reused or <resource>-not-found
+	Code string `json:"code"`
+
+	// Detail Human readable explanation of the problem
+	Detail *string `json:"detail,omitempty"`
+
+	// Errors Details about the invalid fields of the body or parameters
+	Errors *[]ProblemFieldError `json:"errors,omitempty"`
+
+	// Instance Path of the request which caused the problem
+	Instance *string `json:"instance,omitempty"`
+
+	// RequestId Identifier of the request for correlation with the service logs
+	RequestId *string `json:"requestId,omitempty"`
+
+	// Status HTTP status code of the response
+	Status int `json:"status"`
+
+	// Title Short summary of the problem type
+	Title string `json:"title"`
+
+	// Type URI reference identifying the problem type
+	Type                 string                 `json:"type"`
+	AdditionalProperties map[string]interface{} `json:"-"`
+}
+
+// ProblemFieldError defines model for ProblemFieldError.
+type ProblemFieldError struct {
+	// Code Validation rule which the field does not satisfy
+	Code string `json:"code"`
+
+	// Field Path of the invalid field or name of the invalid parameter
+	Field string `json:"field"`
+
+	// Message Human readable description of the validation failure
+	Message string `json:"message"`
+}
+
+// Request defines model for Request.
+type Request struct {
+	// Count Number of items requested (only applicable for missing-equipment requests)
+	Count *int `json:"count"`
+
+	// DeletedAt Time when the request was deleted, present only for deleted requests
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+
+	// DeletedBy Identity of the user who deleted the request
+	DeletedBy *string `json:"deletedBy,omitempty"`
+
+	// Description Detailed description of the request
+	Description string `json:"description"`
+
+	// Id Unique identifier of the request
+	Id *string `json:"id,omitempty"`
+
+	// Name Name of the equipment requested or to be repaired
+	Name string `json:"name"`
+
+	// Room Identifier of the room the request is associated with
+	Room string `json:"room"`
+
+	// Type Type of the request
+	Type RequestType `json:"type"`
+}
+
+// RequestType Type of the request
+type RequestType string
+
+// RequestVersion defines model for RequestVersion.
+type RequestVersion struct {
+	Document Request `json:"document"`
+
+	// Id Unique identifier of the request
+	Id string `json:"id"`
+
+	// Operation Operation which produced the version
+	Operation RequestVersionOperation `json:"operation"`
+
+	// RecordedAt Time when the version was recorded
+	RecordedAt time.Time `json:"recordedAt"`
+
+	// Version Sequence number of the version, starting with 1
+	Version int64 `json:"version"`
+}
+
+// RequestVersionOperation Operation which produced the version
+type RequestVersionOperation string
+
+// Reservation defines model for Reservation.
+type Reservation struct {
+	// Count Number of reserved equipment items, defaults to 1
+	Count *int32 `json:"count,omitempty"`
+
+	// CreatedAt Time when the reservation was made
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+
+	// End End of the reservation
+	End time.Time `json:"end"`
+
+	// EquipmentId Identifier of the reserved equipment
+	EquipmentId *string `json:"equipmentId,omitempty"`
+
+	// Id Unique identifier of the reservation
+	Id *string `json:"id,omitempty"`
+
+	// Purpose Purpose of the reservation
+	Purpose string `json:"purpose"`
+
+	// ReservedBy Identity of the user who made the reservation
+	ReservedBy *string `json:"reservedBy,omitempty"`
+
+	// Room Identifier of the room requesting the equipment
+	Room string `json:"room"`
+
+	// Start Beginning of the reservation
+	Start time.Time `json:"start"`
+}
+
+// ReservationCalendar defines model for ReservationCalendar.
+type ReservationCalendar struct {
+	// DepartmentId Identifier of the department
+	DepartmentId string `json:"departmentId"`
+
+	// Equipment Availability of the equipment located in the department rooms
+	Equipment []EquipmentAvailability `json:"equipment"`
+
+	// From Beginning of the calendar window
+	From time.Time `json:"from"`
+
+	// To End of the calendar window
+	To time.Time `json:"to"`
+}
+
+// Room defines model for Room.
+type Room struct {
+	// DepartmentId Identifier of the department the room belongs to
+	DepartmentId string `json:"department_id"`
+
+	// Id Unique identifier of the room
+	Id string `json:"id"`
+
+	// Name Name of the room
+	Name string `json:"name"`
+}
+
+// StockAdjustment defines model for StockAdjustment.
+type StockAdjustment struct {
+	// Delta Signed change of the equipment count, negative for consumed or lost items
+	Delta int32 `json:"delta"`
+
+	// Note Optional note describing the change
+	Note *string `json:"note,omitempty"`
+
+	// Reason Reason of the change
+	Reason StockAdjustmentReason `json:"reason"`
+}
+
+// StockAdjustmentReason Reason of the change
+type StockAdjustmentReason string
+
+// StockLedger defines model for StockLedger.
+type StockLedger struct {
+	// EquipmentId Identifier of the equipment
+	EquipmentId string `json:"equipmentId"`
+
+	// Movements Movements matching the query, oldest first
+	Movements []StockMovement `json:"movements"`
+
+	// Total Sum of all listed changes
+	Total int32 `json:"total"`
+
+	// TotalsByReason Sum of listed changes per reason
+	TotalsByReason map[string]int32 `json:"totalsByReason"`
+}
+
+// StockMovement defines model for StockMovement.
+type StockMovement struct {
+	// CountAfter Equipment count after the change was applied
+	CountAfter int32 `json:"countAfter"`
+
+	// Delta Signed change of the equipment count
+	Delta int32 `json:"delta"`
+
+	// EquipmentId Identifier of the adjusted equipment
+	EquipmentId string `json:"equipmentId"`
+
+	// Id Unique identifier of the movement
+	Id string `json:"id"`
+
+	// Note Optional note describing the change
+	Note *string `json:"note,omitempty"`
+
+	// Reason Reason of the change
+	Reason StockMovementReason `json:"reason"`
+
+	// RecordedAt Time when the change was applied
+	RecordedAt time.Time `json:"recordedAt"`
+
+	// RecordedBy Identity of the user who applied the change
+	RecordedBy string `json:"recordedBy"`
+}
+
+// StockMovementReason Reason of the change
+type StockMovementReason string
+
+// IdempotencyKey defines model for IdempotencyKey.
+type IdempotencyKey = string
+
+// IdempotencyKeyInProgress Error response as defined by RFC 7807
+type IdempotencyKeyInProgress = Problem
+
+// IdempotencyKeyReused Error response as defined by RFC 7807
+type IdempotencyKeyReused = Problem
+
+// ExecuteBatchParams defines parameters for ExecuteBatch.
+type ExecuteBatchParams struct {
+	// IdempotencyKey Unique key of the operation generated by the client, e.g. UUID. Retry of the request with the same key and the same payload within the retention period returns the stored response marked by the Idempotent-Replayed header instead of repeating the operation.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetDepartmentEquipmentParams defines parameters for GetDepartmentEquipment.
+type GetDepartmentEquipmentParams struct {
+	// IncludeDeleted Include soft deleted equipment in the listing, allowed only for administrators
+	IncludeDeleted *bool `form:"include_deleted,omitempty" json:"include_deleted,omitempty"`
+
+	// AsOf Reconstruct the inventory as it existed at the given time
+	AsOf *time.Time `form:"as_of,omitempty" json:"as_of,omitempty"`
+}
+
+// GetDepartmentRequestsParams defines parameters for GetDepartmentRequests.
+type GetDepartmentRequestsParams struct {
+	// IncludeDeleted Include soft deleted requests in the listing, allowed only for administrators
+	IncludeDeleted *bool `form:"include_deleted,omitempty" json:"include_deleted,omitempty"`
+}
+
+// GetDepartmentReservationsParams defines parameters for GetDepartmentReservations.
+type GetDepartmentReservationsParams struct {
+	// From Beginning of the calendar window, defaults to the current time
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To End of the calendar window, defaults to one week after its beginning
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Format Use ics to export the reservations as iCalendar, same as Accept text/calendar
+	Format *GetDepartmentReservationsParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetDepartmentReservationsParamsFormat defines parameters for GetDepartmentReservations.
+type GetDepartmentReservationsParamsFormat string
+
+// PatchEquipmentJSONBody defines parameters for PatchEquipment.
+type PatchEquipmentJSONBody map[string]interface{}
+
+// PatchEquipmentApplicationJSONPatchPlusJSONBody defines parameters for PatchEquipment.
+type PatchEquipmentApplicationJSONPatchPlusJSONBody = []map[string]interface{}
+
+// PatchEquipmentApplicationMergePatchPlusJSONBody defines parameters for PatchEquipment.
+type PatchEquipmentApplicationMergePatchPlusJSONBody map[string]interface{}
+
+// GetEquipmentAdjustmentsParams defines parameters for GetEquipmentAdjustments.
+type GetEquipmentAdjustmentsParams struct {
+	// Reason List only movements with the given reason
+	Reason *GetEquipmentAdjustmentsParamsReason `form:"reason,omitempty" json:"reason,omitempty"`
+
+	// Since List only movements recorded at or after the given time
+	Since *time.Time `form:"since,omitempty" json:"since,omitempty"`
+
+	// Until List only movements recorded before the given time
+	Until *time.Time `form:"until,omitempty" json:"until,omitempty"`
+}
+
+// GetEquipmentAdjustmentsParamsReason defines parameters for GetEquipmentAdjustments.
+type GetEquipmentAdjustmentsParamsReason string
+
+// AddEquipmentAdjustmentParams defines parameters for AddEquipmentAdjustment.
+type AddEquipmentAdjustmentParams struct {
+	// IdempotencyKey Unique key of the operation generated by the client, e.g. UUID. Retry of the request with the same key and the same payload within the retention period returns the stored response marked by the Idempotent-Replayed header instead of repeating the operation.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetEquipmentReservationsParams defines parameters for GetEquipmentReservations.
+type GetEquipmentReservationsParams struct {
+	// From Beginning of the calendar window, defaults to the current time
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To End of the calendar window, defaults to one week after its beginning
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Format Use ics to export the reservations as iCalendar, same as Accept text/calendar
+	Format *GetEquipmentReservationsParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetEquipmentReservationsParamsFormat defines parameters for GetEquipmentReservations.
+type GetEquipmentReservationsParamsFormat string
+
+// CreateEquipmentReservationParams defines parameters for CreateEquipmentReservation.
+type CreateEquipmentReservationParams struct {
+	// IdempotencyKey Unique key of the operation generated by the client, e.g. UUID. Retry of the request with the same key and the same payload within the retention period returns the stored response marked by the Idempotent-Replayed header instead of repeating the operation.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// RestoreEquipmentParams defines parameters for RestoreEquipment.
+type RestoreEquipmentParams struct {
+	// IdempotencyKey Unique key of the operation generated by the client, e.g. UUID. Retry of the request with the same key and the same payload within the retention period returns the stored response marked by the Idempotent-Replayed header instead of repeating the operation.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// PatchRequestJSONBody defines parameters for PatchRequest.
+type PatchRequestJSONBody map[string]interface{}
+
+// PatchRequestApplicationJSONPatchPlusJSONBody defines parameters for PatchRequest.
+type PatchRequestApplicationJSONPatchPlusJSONBody = []map[string]interface{}
+
+// PatchRequestApplicationMergePatchPlusJSONBody defines parameters for PatchRequest.
+type PatchRequestApplicationMergePatchPlusJSONBody map[string]interface{}
+
+// RestoreRequestParams defines parameters for RestoreRequest.
+type RestoreRequestParams struct {
+	// IdempotencyKey Unique key of the operation generated by the client, e.g. UUID. Retry of the request with the same key and the same payload within the retention period returns the stored response marked by the Idempotent-Replayed header instead of repeating the operation.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// AddRoomEquipmentParams defines parameters for AddRoomEquipment.
+type AddRoomEquipmentParams struct {
+	// IdempotencyKey Unique key of the operation generated by the client, e.g. UUID. Retry of the request with the same key and the same payload within the retention period returns the stored response marked by the Idempotent-Replayed header instead of repeating the operation.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// AddRoomRequestParams defines parameters for AddRoomRequest.
+type AddRoomRequestParams struct {
+	// IdempotencyKey Unique key of the operation generated by the client, e.g. UUID. Retry of the request with the same key and the same payload within the retention period returns the stored response marked by the Idempotent-Replayed header instead of repeating the operation.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// ExecuteBatchJSONRequestBody defines body for ExecuteBatch for application/json ContentType.
+type ExecuteBatchJSONRequestBody = BatchRequest
+
+// PatchEquipmentJSONRequestBody defines body for PatchEquipment for application/json ContentType.
+type PatchEquipmentJSONRequestBody PatchEquipmentJSONBody
+
+// PatchEquipmentApplicationJSONPatchPlusJSONRequestBody defines body for PatchEquipment for application/json-patch+json ContentType.
+type PatchEquipmentApplicationJSONPatchPlusJSONRequestBody = PatchEquipmentApplicationJSONPatchPlusJSONBody
+
+// PatchEquipmentApplicationMergePatchPlusJSONRequestBody defines body for PatchEquipment for application/merge-patch+json ContentType.
+type PatchEquipmentApplicationMergePatchPlusJSONRequestBody PatchEquipmentApplicationMergePatchPlusJSONBody
+
+// UpdateEquipmentJSONRequestBody defines body for UpdateEquipment for application/json ContentType.
+type UpdateEquipmentJSONRequestBody = Equipment
+
+// AddEquipmentAdjustmentJSONRequestBody defines body for AddEquipmentAdjustment for application/json ContentType.
+type AddEquipmentAdjustmentJSONRequestBody = StockAdjustment
+
+// CreateEquipmentReservationJSONRequestBody defines body for CreateEquipmentReservation for application/json ContentType.
+type CreateEquipmentReservationJSONRequestBody = Reservation
+
+// PatchRequestJSONRequestBody defines body for PatchRequest for application/json ContentType.
+type PatchRequestJSONRequestBody PatchRequestJSONBody
+
+// PatchRequestApplicationJSONPatchPlusJSONRequestBody defines body for PatchRequest for application/json-patch+json ContentType.
+type PatchRequestApplicationJSONPatchPlusJSONRequestBody = PatchRequestApplicationJSONPatchPlusJSONBody
+
+// PatchRequestApplicationMergePatchPlusJSONRequestBody defines body for PatchRequest for application/merge-patch+json ContentType.
+type PatchRequestApplicationMergePatchPlusJSONRequestBody PatchRequestApplicationMergePatchPlusJSONBody
+
+// UpdateRequestJSONRequestBody defines body for UpdateRequest for application/json ContentType.
+type UpdateRequestJSONRequestBody = Request
+
+// AddRoomEquipmentJSONRequestBody defines body for AddRoomEquipment for application/json ContentType.
+type AddRoomEquipmentJSONRequestBody = Equipment
+
+// AddRoomRequestJSONRequestBody defines body for AddRoomRequest for application/json ContentType.
+type AddRoomRequestJSONRequestBody = Request
+
+// Getter for additional properties for Problem. Returns the specified
+// element and whether it was found
+func (a Problem) Get(fieldName string) (value interface{}, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for Problem
+func (a *Problem) Set(fieldName string, value interface{}) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]interface{})
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for Problem to handle AdditionalProperties
+func (a *Problem) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if raw, found := object["code"]; found {
+		err = json.Unmarshal(raw, &a.Code)
+		if err != nil {
+			return fmt.Errorf("error reading 'code': %w", err)
+		}
+		delete(object, "code")
+	}
+
+	if raw, found := object["detail"]; found {
+		err = json.Unmarshal(raw, &a.Detail)
+		if err != nil {
+			return fmt.Errorf("error reading 'detail': %w", err)
+		}
+		delete(object, "detail")
+	}
+
+	if raw, found := object["errors"]; found {
+		err = json.Unmarshal(raw, &a.Errors)
+		if err != nil {
+			return fmt.Errorf("error reading 'errors': %w", err)
+		}
+		delete(object, "errors")
+	}
+
+	if raw, found := object["instance"]; found {
+		err = json.Unmarshal(raw, &a.Instance)
+		if err != nil {
+			return fmt.Errorf("error reading 'instance': %w", err)
+		}
+		delete(object, "instance")
+	}
+
+	if raw, found := object["requestId"]; found {
+		err = json.Unmarshal(raw, &a.RequestId)
+		if err != nil {
+			return fmt.Errorf("error reading 'requestId': %w", err)
+		}
+		delete(object, "requestId")
+	}
+
+	if raw, found := object["status"]; found {
+		err = json.Unmarshal(raw, &a.Status)
+		if err != nil {
+			return fmt.Errorf("error reading 'status': %w", err)
+		}
+		delete(object, "status")
+	}
+
+	if raw, found := object["title"]; found {
+		err = json.Unmarshal(raw, &a.Title)
+		if err != nil {
+			return fmt.Errorf("error reading 'title': %w", err)
+		}
+		delete(object, "title")
+	}
+
+	if raw, found := object["type"]; found {
+		err = json.Unmarshal(raw, &a.Type)
+		if err != nil {
+			return fmt.Errorf("error reading 'type': %w", err)
+		}
+		delete(object, "type")
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]interface{})
+		for fieldName, fieldBuf := range object {
+			var fieldVal interface{}
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for Problem to handle AdditionalProperties
+func (a Problem) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	object["code"], err = json.Marshal(a.Code)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling 'code': %w", err)
+	}
+
+	if a.Detail != nil {
+		object["detail"], err = json.Marshal(a.Detail)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'detail': %w", err)
+		}
+	}
+
+	if a.Errors != nil {
+		object["errors"], err = json.Marshal(a.Errors)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'errors': %w", err)
+		}
+	}
+
+	if a.Instance != nil {
+		object["instance"], err = json.Marshal(a.Instance)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'instance': %w", err)
+		}
+	}
+
+	if a.RequestId != nil {
+		object["requestId"], err = json.Marshal(a.RequestId)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'requestId': %w", err)
+		}
+	}
+
+	object["status"], err = json.Marshal(a.Status)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling 'status': %w", err)
+	}
+
+	object["title"], err = json.Marshal(a.Title)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling 'title': %w", err)
+	}
+
+	object["type"], err = json.Marshal(a.Type)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling 'type': %w", err)
+	}
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
+
+// AsEquipment returns the union data inside the BatchResult_Body as a Equipment
+func (t BatchResult_Body) AsEquipment() (Equipment, error) {
+	var body Equipment
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromEquipment overwrites any union data inside the BatchResult_Body as the provided Equipment
+func (t *BatchResult_Body) FromEquipment(v Equipment) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeEquipment performs a merge with any union data inside the BatchResult_Body, using the provided Equipment
+func (t *BatchResult_Body) MergeEquipment(v Equipment) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsRequest returns the union data inside the BatchResult_Body as a Request
+func (t BatchResult_Body) AsRequest() (Request, error) {
+	var body Request
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromRequest overwrites any union data inside the BatchResult_Body as the provided Request
+func (t *BatchResult_Body) FromRequest(v Request) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeRequest performs a merge with any union data inside the BatchResult_Body, using the provided Request
+func (t *BatchResult_Body) MergeRequest(v Request) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsProblem returns the union data inside the BatchResult_Body as a Problem
+func (t BatchResult_Body) AsProblem() (Problem, error) {
+	var body Problem
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromProblem overwrites any union data inside the BatchResult_Body as the provided Problem
+func (t *BatchResult_Body) FromProblem(v Problem) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeProblem performs a merge with any union data inside the BatchResult_Body, using the provided Problem
+func (t *BatchResult_Body) MergeProblem(v Problem) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+func (t BatchResult_Body) MarshalJSON() ([]byte, error) {
+	b, err := t.union.MarshalJSON()
+	return b, err
+}
+
+func (t *BatchResult_Body) UnmarshalJSON(b []byte) error {
+	err := t.union.UnmarshalJSON(b)
+	return err
+}
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// ExecuteBatchWithBody request with any body
+	ExecuteBatchWithBody(ctx context.Context, params *ExecuteBatchParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ExecuteBatch(ctx context.Context, params *ExecuteBatchParams, body ExecuteBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetDepartments request
+	GetDepartments(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetDepartmentEquipment request
+	GetDepartmentEquipment(ctx context.Context, departmentId string, params *GetDepartmentEquipmentParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetDepartmentRequests request
+	GetDepartmentRequests(ctx context.Context, departmentId string, params *GetDepartmentRequestsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetDepartmentReservations request
+	GetDepartmentReservations(ctx context.Context, departmentId string, params *GetDepartmentReservationsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteEquipment request
+	DeleteEquipment(ctx context.Context, equipmentId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PatchEquipmentWithBody request with any body
+	PatchEquipmentWithBody(ctx context.Context, equipmentId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchEquipment(ctx context.Context, equipmentId string, body PatchEquipmentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchEquipmentWithApplicationJSONPatchPlusJSONBody(ctx context.Context, equipmentId string, body PatchEquipmentApplicationJSONPatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchEquipmentWithApplicationMergePatchPlusJSONBody(ctx context.Context, equipmentId string, body PatchEquipmentApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateEquipmentWithBody request with any body
+	UpdateEquipmentWithBody(ctx context.Context, equipmentId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateEquipment(ctx context.Context, equipmentId string, body UpdateEquipmentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetEquipmentAdjustments request
+	GetEquipmentAdjustments(ctx context.Context, equipmentId string, params *GetEquipmentAdjustmentsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddEquipmentAdjustmentWithBody request with any body
+	AddEquipmentAdjustmentWithBody(ctx context.Context, equipmentId string, params *AddEquipmentAdjustmentParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AddEquipmentAdjustment(ctx context.Context, equipmentId string, params *AddEquipmentAdjustmentParams, body AddEquipmentAdjustmentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetEquipmentReservations request
+	GetEquipmentReservations(ctx context.Context, equipmentId string, params *GetEquipmentReservationsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateEquipmentReservationWithBody request with any body
+	CreateEquipmentReservationWithBody(ctx context.Context, equipmentId string, params *CreateEquipmentReservationParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateEquipmentReservation(ctx context.Context, equipmentId string, params *CreateEquipmentReservationParams, body CreateEquipmentReservationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RestoreEquipment request
+	RestoreEquipment(ctx context.Context, equipmentId string, params *RestoreEquipmentParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetEquipmentVersions request
+	GetEquipmentVersions(ctx context.Context, equipmentId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteRequest request
+	DeleteRequest(ctx context.Context, requestId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PatchRequestWithBody request with any body
+	PatchRequestWithBody(ctx context.Context, requestId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchRequest(ctx context.Context, requestId string, body PatchRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchRequestWithApplicationJSONPatchPlusJSONBody(ctx context.Context, requestId string, body PatchRequestApplicationJSONPatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchRequestWithApplicationMergePatchPlusJSONBody(ctx context.Context, requestId string, body PatchRequestApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateRequestWithBody request with any body
+	UpdateRequestWithBody(ctx context.Context, requestId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateRequest(ctx context.Context, requestId string, body UpdateRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RestoreRequest request
+	RestoreRequest(ctx context.Context, requestId string, params *RestoreRequestParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRequestVersions request
+	GetRequestVersions(ctx context.Context, requestId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CancelReservation request
+	CancelReservation(ctx context.Context, reservationId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddRoomEquipmentWithBody request with any body
+	AddRoomEquipmentWithBody(ctx context.Context, roomId string, params *AddRoomEquipmentParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AddRoomEquipment(ctx context.Context, roomId string, params *AddRoomEquipmentParams, body AddRoomEquipmentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddRoomRequestWithBody request with any body
+	AddRoomRequestWithBody(ctx context.Context, roomId string, params *AddRoomRequestParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AddRoomRequest(ctx context.Context, roomId string, params *AddRoomRequestParams, body AddRoomRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) ExecuteBatchWithBody(ctx context.Context, params *ExecuteBatchParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewExecuteBatchRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ExecuteBatch(ctx context.Context, params *ExecuteBatchParams, body ExecuteBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewExecuteBatchRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetDepartments(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetDepartmentsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetDepartmentEquipment(ctx context.Context, departmentId string, params *GetDepartmentEquipmentParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetDepartmentEquipmentRequest(c.Server, departmentId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetDepartmentRequests(ctx context.Context, departmentId string, params *GetDepartmentRequestsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetDepartmentRequestsRequest(c.Server, departmentId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetDepartmentReservations(ctx context.Context, departmentId string, params *GetDepartmentReservationsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetDepartmentReservationsRequest(c.Server, departmentId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteEquipment(ctx context.Context, equipmentId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteEquipmentRequest(c.Server, equipmentId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchEquipmentWithBody(ctx context.Context, equipmentId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchEquipmentRequestWithBody(c.Server, equipmentId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchEquipment(ctx context.Context, equipmentId string, body PatchEquipmentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchEquipmentRequest(c.Server, equipmentId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchEquipmentWithApplicationJSONPatchPlusJSONBody(ctx context.Context, equipmentId string, body PatchEquipmentApplicationJSONPatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchEquipmentRequestWithApplicationJSONPatchPlusJSONBody(c.Server, equipmentId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchEquipmentWithApplicationMergePatchPlusJSONBody(ctx context.Context, equipmentId string, body PatchEquipmentApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchEquipmentRequestWithApplicationMergePatchPlusJSONBody(c.Server, equipmentId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateEquipmentWithBody(ctx context.Context, equipmentId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateEquipmentRequestWithBody(c.Server, equipmentId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateEquipment(ctx context.Context, equipmentId string, body UpdateEquipmentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateEquipmentRequest(c.Server, equipmentId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetEquipmentAdjustments(ctx context.Context, equipmentId string, params *GetEquipmentAdjustmentsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetEquipmentAdjustmentsRequest(c.Server, equipmentId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddEquipmentAdjustmentWithBody(ctx context.Context, equipmentId string, params *AddEquipmentAdjustmentParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddEquipmentAdjustmentRequestWithBody(c.Server, equipmentId, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddEquipmentAdjustment(ctx context.Context, equipmentId string, params *AddEquipmentAdjustmentParams, body AddEquipmentAdjustmentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddEquipmentAdjustmentRequest(c.Server, equipmentId, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetEquipmentReservations(ctx context.Context, equipmentId string, params *GetEquipmentReservationsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetEquipmentReservationsRequest(c.Server, equipmentId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateEquipmentReservationWithBody(ctx context.Context, equipmentId string, params *CreateEquipmentReservationParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateEquipmentReservationRequestWithBody(c.Server, equipmentId, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateEquipmentReservation(ctx context.Context, equipmentId string, params *CreateEquipmentReservationParams, body CreateEquipmentReservationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateEquipmentReservationRequest(c.Server, equipmentId, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RestoreEquipment(ctx context.Context, equipmentId string, params *RestoreEquipmentParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRestoreEquipmentRequest(c.Server, equipmentId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetEquipmentVersions(ctx context.Context, equipmentId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetEquipmentVersionsRequest(c.Server, equipmentId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteRequest(ctx context.Context, requestId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteRequestRequest(c.Server, requestId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchRequestWithBody(ctx context.Context, requestId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchRequestRequestWithBody(c.Server, requestId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchRequest(ctx context.Context, requestId string, body PatchRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchRequestRequest(c.Server, requestId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchRequestWithApplicationJSONPatchPlusJSONBody(ctx context.Context, requestId string, body PatchRequestApplicationJSONPatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchRequestRequestWithApplicationJSONPatchPlusJSONBody(c.Server, requestId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchRequestWithApplicationMergePatchPlusJSONBody(ctx context.Context, requestId string, body PatchRequestApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchRequestRequestWithApplicationMergePatchPlusJSONBody(c.Server, requestId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateRequestWithBody(ctx context.Context, requestId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateRequestRequestWithBody(c.Server, requestId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateRequest(ctx context.Context, requestId string, body UpdateRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateRequestRequest(c.Server, requestId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RestoreRequest(ctx context.Context, requestId string, params *RestoreRequestParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRestoreRequestRequest(c.Server, requestId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetRequestVersions(ctx context.Context, requestId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRequestVersionsRequest(c.Server, requestId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CancelReservation(ctx context.Context, reservationId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCancelReservationRequest(c.Server, reservationId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddRoomEquipmentWithBody(ctx context.Context, roomId string, params *AddRoomEquipmentParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddRoomEquipmentRequestWithBody(c.Server, roomId, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddRoomEquipment(ctx context.Context, roomId string, params *AddRoomEquipmentParams, body AddRoomEquipmentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddRoomEquipmentRequest(c.Server, roomId, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddRoomRequestWithBody(ctx context.Context, roomId string, params *AddRoomRequestParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddRoomRequestRequestWithBody(c.Server, roomId, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddRoomRequest(ctx context.Context, roomId string, params *AddRoomRequestParams, body AddRoomRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddRoomRequestRequest(c.Server, roomId, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewExecuteBatchRequest calls the generic ExecuteBatch builder with application/json body
+func NewExecuteBatchRequest(server string, params *ExecuteBatchParams, body ExecuteBatchJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewExecuteBatchRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewExecuteBatchRequestWithBody generates requests for ExecuteBatch with any type of body
+func NewExecuteBatchRequestWithBody(server string, params *ExecuteBatchParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/batch")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IdempotencyKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, *params.IdempotencyKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Idempotency-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetDepartmentsRequest generates requests for GetDepartments
+func NewGetDepartmentsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/departments/")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetDepartmentEquipmentRequest generates requests for GetDepartmentEquipment
+func NewGetDepartmentEquipmentRequest(server string, departmentId string, params *GetDepartmentEquipmentParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "departmentId", runtime.ParamLocationPath, departmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/departments/%s/equipment", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.IncludeDeleted != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "include_deleted", runtime.ParamLocationQuery, *params.IncludeDeleted); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.AsOf != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "as_of", runtime.ParamLocationQuery, *params.AsOf); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetDepartmentRequestsRequest generates requests for GetDepartmentRequests
+func NewGetDepartmentRequestsRequest(server string, departmentId string, params *GetDepartmentRequestsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "departmentId", runtime.ParamLocationPath, departmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/departments/%s/requests", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.IncludeDeleted != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "include_deleted", runtime.ParamLocationQuery, *params.IncludeDeleted); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetDepartmentReservationsRequest generates requests for GetDepartmentReservations
+func NewGetDepartmentReservationsRequest(server string, departmentId string, params *GetDepartmentReservationsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "departmentId", runtime.ParamLocationPath, departmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/departments/%s/reservations", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Format != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "format", runtime.ParamLocationQuery, *params.Format); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteEquipmentRequest generates requests for DeleteEquipment
+func NewDeleteEquipmentRequest(server string, equipmentId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "equipmentId", runtime.ParamLocationPath, equipmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/equipment/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPatchEquipmentRequest calls the generic PatchEquipment builder with application/json body
+func NewPatchEquipmentRequest(server string, equipmentId string, body PatchEquipmentJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchEquipmentRequestWithBody(server, equipmentId, "application/json", bodyReader)
+}
+
+// NewPatchEquipmentRequestWithApplicationJSONPatchPlusJSONBody calls the generic PatchEquipment builder with application/json-patch+json body
+func NewPatchEquipmentRequestWithApplicationJSONPatchPlusJSONBody(server string, equipmentId string, body PatchEquipmentApplicationJSONPatchPlusJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchEquipmentRequestWithBody(server, equipmentId, "application/json-patch+json", bodyReader)
+}
+
+// NewPatchEquipmentRequestWithApplicationMergePatchPlusJSONBody calls the generic PatchEquipment builder with application/merge-patch+json body
+func NewPatchEquipmentRequestWithApplicationMergePatchPlusJSONBody(server string, equipmentId string, body PatchEquipmentApplicationMergePatchPlusJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchEquipmentRequestWithBody(server, equipmentId, "application/merge-patch+json", bodyReader)
+}
+
+// NewPatchEquipmentRequestWithBody generates requests for PatchEquipment with any type of body
+func NewPatchEquipmentRequestWithBody(server string, equipmentId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "equipmentId", runtime.ParamLocationPath, equipmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/equipment/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewUpdateEquipmentRequest calls the generic UpdateEquipment builder with application/json body
+func NewUpdateEquipmentRequest(server string, equipmentId string, body UpdateEquipmentJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateEquipmentRequestWithBody(server, equipmentId, "application/json", bodyReader)
+}
+
+// NewUpdateEquipmentRequestWithBody generates requests for UpdateEquipment with any type of body
+func NewUpdateEquipmentRequestWithBody(server string, equipmentId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "equipmentId", runtime.ParamLocationPath, equipmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/equipment/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetEquipmentAdjustmentsRequest generates requests for GetEquipmentAdjustments
+func NewGetEquipmentAdjustmentsRequest(server string, equipmentId string, params *GetEquipmentAdjustmentsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "equipmentId", runtime.ParamLocationPath, equipmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/equipment/%s/adjustments", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Reason != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "reason", runtime.ParamLocationQuery, *params.Reason); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Since != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "since", runtime.ParamLocationQuery, *params.Since); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Until != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "until", runtime.ParamLocationQuery, *params.Until); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAddEquipmentAdjustmentRequest calls the generic AddEquipmentAdjustment builder with application/json body
+func NewAddEquipmentAdjustmentRequest(server string, equipmentId string, params *AddEquipmentAdjustmentParams, body AddEquipmentAdjustmentJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAddEquipmentAdjustmentRequestWithBody(server, equipmentId, params, "application/json", bodyReader)
+}
+
+// NewAddEquipmentAdjustmentRequestWithBody generates requests for AddEquipmentAdjustment with any type of body
+func NewAddEquipmentAdjustmentRequestWithBody(server string, equipmentId string, params *AddEquipmentAdjustmentParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "equipmentId", runtime.ParamLocationPath, equipmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/equipment/%s/adjustments", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IdempotencyKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, *params.IdempotencyKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Idempotency-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetEquipmentReservationsRequest generates requests for GetEquipmentReservations
+func NewGetEquipmentReservationsRequest(server string, equipmentId string, params *GetEquipmentReservationsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "equipmentId", runtime.ParamLocationPath, equipmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/equipment/%s/reservations", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Format != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "format", runtime.ParamLocationQuery, *params.Format); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateEquipmentReservationRequest calls the generic CreateEquipmentReservation builder with application/json body
+func NewCreateEquipmentReservationRequest(server string, equipmentId string, params *CreateEquipmentReservationParams, body CreateEquipmentReservationJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateEquipmentReservationRequestWithBody(server, equipmentId, params, "application/json", bodyReader)
+}
+
+// NewCreateEquipmentReservationRequestWithBody generates requests for CreateEquipmentReservation with any type of body
+func NewCreateEquipmentReservationRequestWithBody(server string, equipmentId string, params *CreateEquipmentReservationParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "equipmentId", runtime.ParamLocationPath, equipmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/equipment/%s/reservations", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IdempotencyKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, *params.IdempotencyKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Idempotency-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewRestoreEquipmentRequest generates requests for RestoreEquipment
+func NewRestoreEquipmentRequest(server string, equipmentId string, params *RestoreEquipmentParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "equipmentId", runtime.ParamLocationPath, equipmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/equipment/%s/restore", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.IdempotencyKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, *params.IdempotencyKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Idempotency-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetEquipmentVersionsRequest generates requests for GetEquipmentVersions
+func NewGetEquipmentVersionsRequest(server string, equipmentId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "equipmentId", runtime.ParamLocationPath, equipmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/equipment/%s/versions", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteRequestRequest generates requests for DeleteRequest
+func NewDeleteRequestRequest(server string, requestId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "requestId", runtime.ParamLocationPath, requestId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/requests/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPatchRequestRequest calls the generic PatchRequest builder with application/json body
+func NewPatchRequestRequest(server string, requestId string, body PatchRequestJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchRequestRequestWithBody(server, requestId, "application/json", bodyReader)
+}
+
+// NewPatchRequestRequestWithApplicationJSONPatchPlusJSONBody calls the generic PatchRequest builder with application/json-patch+json body
+func NewPatchRequestRequestWithApplicationJSONPatchPlusJSONBody(server string, requestId string, body PatchRequestApplicationJSONPatchPlusJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchRequestRequestWithBody(server, requestId, "application/json-patch+json", bodyReader)
+}
+
+// NewPatchRequestRequestWithApplicationMergePatchPlusJSONBody calls the generic PatchRequest builder with application/merge-patch+json body
+func NewPatchRequestRequestWithApplicationMergePatchPlusJSONBody(server string, requestId string, body PatchRequestApplicationMergePatchPlusJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchRequestRequestWithBody(server, requestId, "application/merge-patch+json", bodyReader)
+}
+
+// NewPatchRequestRequestWithBody generates requests for PatchRequest with any type of body
+func NewPatchRequestRequestWithBody(server string, requestId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "requestId", runtime.ParamLocationPath, requestId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/requests/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewUpdateRequestRequest calls the generic UpdateRequest builder with application/json body
+func NewUpdateRequestRequest(server string, requestId string, body UpdateRequestJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateRequestRequestWithBody(server, requestId, "application/json", bodyReader)
+}
+
+// NewUpdateRequestRequestWithBody generates requests for UpdateRequest with any type of body
+func NewUpdateRequestRequestWithBody(server string, requestId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "requestId", runtime.ParamLocationPath, requestId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/requests/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRestoreRequestRequest generates requests for RestoreRequest
+func NewRestoreRequestRequest(server string, requestId string, params *RestoreRequestParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "requestId", runtime.ParamLocationPath, requestId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/requests/%s/restore", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.IdempotencyKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, *params.IdempotencyKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Idempotency-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetRequestVersionsRequest generates requests for GetRequestVersions
+func NewGetRequestVersionsRequest(server string, requestId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "requestId", runtime.ParamLocationPath, requestId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/requests/%s/versions", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCancelReservationRequest generates requests for CancelReservation
+func NewCancelReservationRequest(server string, reservationId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "reservationId", runtime.ParamLocationPath, reservationId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/reservations/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAddRoomEquipmentRequest calls the generic AddRoomEquipment builder with application/json body
+func NewAddRoomEquipmentRequest(server string, roomId string, params *AddRoomEquipmentParams, body AddRoomEquipmentJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAddRoomEquipmentRequestWithBody(server, roomId, params, "application/json", bodyReader)
+}
+
+// NewAddRoomEquipmentRequestWithBody generates requests for AddRoomEquipment with any type of body
+func NewAddRoomEquipmentRequestWithBody(server string, roomId string, params *AddRoomEquipmentParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "roomId", runtime.ParamLocationPath, roomId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/rooms/%s/equipment", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IdempotencyKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, *params.IdempotencyKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Idempotency-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewAddRoomRequestRequest calls the generic AddRoomRequest builder with application/json body
+func NewAddRoomRequestRequest(server string, roomId string, params *AddRoomRequestParams, body AddRoomRequestJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAddRoomRequestRequestWithBody(server, roomId, params, "application/json", bodyReader)
+}
+
+// NewAddRoomRequestRequestWithBody generates requests for AddRoomRequest with any type of body
+func NewAddRoomRequestRequestWithBody(server string, roomId string, params *AddRoomRequestParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "roomId", runtime.ParamLocationPath, roomId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/rooms/%s/requests", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IdempotencyKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, *params.IdempotencyKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Idempotency-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// ExecuteBatchWithBodyWithResponse request with any body
+	ExecuteBatchWithBodyWithResponse(ctx context.Context, params *ExecuteBatchParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ExecuteBatchResponse, error)
+
+	ExecuteBatchWithResponse(ctx context.Context, params *ExecuteBatchParams, body ExecuteBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*ExecuteBatchResponse, error)
+
+	// GetDepartmentsWithResponse request
+	GetDepartmentsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetDepartmentsResponse, error)
+
+	// GetDepartmentEquipmentWithResponse request
+	GetDepartmentEquipmentWithResponse(ctx context.Context, departmentId string, params *GetDepartmentEquipmentParams, reqEditors ...RequestEditorFn) (*GetDepartmentEquipmentResponse, error)
+
+	// GetDepartmentRequestsWithResponse request
+	GetDepartmentRequestsWithResponse(ctx context.Context, departmentId string, params *GetDepartmentRequestsParams, reqEditors ...RequestEditorFn) (*GetDepartmentRequestsResponse, error)
+
+	// GetDepartmentReservationsWithResponse request
+	GetDepartmentReservationsWithResponse(ctx context.Context, departmentId string, params *GetDepartmentReservationsParams, reqEditors ...RequestEditorFn) (*GetDepartmentReservationsResponse, error)
+
+	// DeleteEquipmentWithResponse request
+	DeleteEquipmentWithResponse(ctx context.Context, equipmentId string, reqEditors ...RequestEditorFn) (*DeleteEquipmentResponse, error)
+
+	// PatchEquipmentWithBodyWithResponse request with any body
+	PatchEquipmentWithBodyWithResponse(ctx context.Context, equipmentId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchEquipmentResponse, error)
+
+	PatchEquipmentWithResponse(ctx context.Context, equipmentId string, body PatchEquipmentJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchEquipmentResponse, error)
+
+	PatchEquipmentWithApplicationJSONPatchPlusJSONBodyWithResponse(ctx context.Context, equipmentId string, body PatchEquipmentApplicationJSONPatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchEquipmentResponse, error)
+
+	PatchEquipmentWithApplicationMergePatchPlusJSONBodyWithResponse(ctx context.Context, equipmentId string, body PatchEquipmentApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchEquipmentResponse, error)
+
+	// UpdateEquipmentWithBodyWithResponse request with any body
+	UpdateEquipmentWithBodyWithResponse(ctx context.Context, equipmentId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateEquipmentResponse, error)
+
+	UpdateEquipmentWithResponse(ctx context.Context, equipmentId string, body UpdateEquipmentJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateEquipmentResponse, error)
+
+	// GetEquipmentAdjustmentsWithResponse request
+	GetEquipmentAdjustmentsWithResponse(ctx context.Context, equipmentId string, params *GetEquipmentAdjustmentsParams, reqEditors ...RequestEditorFn) (*GetEquipmentAdjustmentsResponse, error)
+
+	// AddEquipmentAdjustmentWithBodyWithResponse request with any body
+	AddEquipmentAdjustmentWithBodyWithResponse(ctx context.Context, equipmentId string, params *AddEquipmentAdjustmentParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddEquipmentAdjustmentResponse, error)
+
+	AddEquipmentAdjustmentWithResponse(ctx context.Context, equipmentId string, params *AddEquipmentAdjustmentParams, body AddEquipmentAdjustmentJSONRequestBody, reqEditors ...RequestEditorFn) (*AddEquipmentAdjustmentResponse, error)
+
+	// GetEquipmentReservationsWithResponse request
+	GetEquipmentReservationsWithResponse(ctx context.Context, equipmentId string, params *GetEquipmentReservationsParams, reqEditors ...RequestEditorFn) (*GetEquipmentReservationsResponse, error)
+
+	// CreateEquipmentReservationWithBodyWithResponse request with any body
+	CreateEquipmentReservationWithBodyWithResponse(ctx context.Context, equipmentId string, params *CreateEquipmentReservationParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateEquipmentReservationResponse, error)
+
+	CreateEquipmentReservationWithResponse(ctx context.Context, equipmentId string, params *CreateEquipmentReservationParams, body CreateEquipmentReservationJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateEquipmentReservationResponse, error)
+
+	// RestoreEquipmentWithResponse request
+	RestoreEquipmentWithResponse(ctx context.Context, equipmentId string, params *RestoreEquipmentParams, reqEditors ...RequestEditorFn) (*RestoreEquipmentResponse, error)
+
+	// GetEquipmentVersionsWithResponse request
+	GetEquipmentVersionsWithResponse(ctx context.Context, equipmentId string, reqEditors ...RequestEditorFn) (*GetEquipmentVersionsResponse, error)
+
+	// DeleteRequestWithResponse request
+	DeleteRequestWithResponse(ctx context.Context, requestId string, reqEditors ...RequestEditorFn) (*DeleteRequestResponse, error)
+
+	// PatchRequestWithBodyWithResponse request with any body
+	PatchRequestWithBodyWithResponse(ctx context.Context, requestId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchRequestResponse, error)
+
+	PatchRequestWithResponse(ctx context.Context, requestId string, body PatchRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchRequestResponse, error)
+
+	PatchRequestWithApplicationJSONPatchPlusJSONBodyWithResponse(ctx context.Context, requestId string, body PatchRequestApplicationJSONPatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchRequestResponse, error)
+
+	PatchRequestWithApplicationMergePatchPlusJSONBodyWithResponse(ctx context.Context, requestId string, body PatchRequestApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchRequestResponse, error)
+
+	// UpdateRequestWithBodyWithResponse request with any body
+	UpdateRequestWithBodyWithResponse(ctx context.Context, requestId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateRequestResponse, error)
+
+	UpdateRequestWithResponse(ctx context.Context, requestId string, body UpdateRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateRequestResponse, error)
+
+	// RestoreRequestWithResponse request
+	RestoreRequestWithResponse(ctx context.Context, requestId string, params *RestoreRequestParams, reqEditors ...RequestEditorFn) (*RestoreRequestResponse, error)
+
+	// GetRequestVersionsWithResponse request
+	GetRequestVersionsWithResponse(ctx context.Context, requestId string, reqEditors ...RequestEditorFn) (*GetRequestVersionsResponse, error)
+
+	// CancelReservationWithResponse request
+	CancelReservationWithResponse(ctx context.Context, reservationId string, reqEditors ...RequestEditorFn) (*CancelReservationResponse, error)
+
+	// AddRoomEquipmentWithBodyWithResponse request with any body
+	AddRoomEquipmentWithBodyWithResponse(ctx context.Context, roomId string, params *AddRoomEquipmentParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddRoomEquipmentResponse, error)
+
+	AddRoomEquipmentWithResponse(ctx context.Context, roomId string, params *AddRoomEquipmentParams, body AddRoomEquipmentJSONRequestBody, reqEditors ...RequestEditorFn) (*AddRoomEquipmentResponse, error)
+
+	// AddRoomRequestWithBodyWithResponse request with any body
+	AddRoomRequestWithBodyWithResponse(ctx context.Context, roomId string, params *AddRoomRequestParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddRoomRequestResponse, error)
+
+	AddRoomRequestWithResponse(ctx context.Context, roomId string, params *AddRoomRequestParams, body AddRoomRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*AddRoomRequestResponse, error)
+}
+
+type ExecuteBatchResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *BatchResponse
+	JSON207                   *BatchResponse
+	ApplicationproblemJSON400 *Problem
+	ApplicationproblemJSON409 *IdempotencyKeyInProgress
+	ApplicationproblemJSON422 *IdempotencyKeyReused
+	ApplicationproblemJSON501 *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r ExecuteBatchResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ExecuteBatchResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetDepartmentsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Department
+}
+
+// Status returns HTTPResponse.Status
+func (r GetDepartmentsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetDepartmentsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetDepartmentEquipmentResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		// Id Unique identifier of the department
+		Id string `json:"id"`
+
+		// Name Name of the department
+		Name string `json:"name"`
+
+		// Rooms Rooms of the department, null when the department has no rooms
+		Rooms *[]struct {
+			Equipment []Equipment `json:"equipment"`
+
+			// Id Unique identifier of the room
+			Id string `json:"id"`
+
+			// Name Name of the room
+			Name string `json:"name"`
+		} `json:"rooms"`
+	}
+	ApplicationproblemJSON403 *Problem
+	ApplicationproblemJSON404 *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetDepartmentEquipmentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetDepartmentEquipmentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetDepartmentRequestsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		// Id Unique identifier of the department
+		Id string `json:"id"`
+
+		// Name Name of the department
+		Name string `json:"name"`
+
+		// Rooms Rooms of the department, null when the department has no rooms
+		Rooms *[]struct {
+			// Id Unique identifier of the room
+			Id string `json:"id"`
+
+			// Name Name of the room
+			Name     string    `json:"name"`
+			Requests []Request `json:"requests"`
+		} `json:"rooms"`
+	}
+	ApplicationproblemJSON403 *Problem
+	ApplicationproblemJSON404 *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetDepartmentRequestsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetDepartmentRequestsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetDepartmentReservationsResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *ReservationCalendar
+	ApplicationproblemJSON400 *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetDepartmentReservationsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetDepartmentReservationsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteEquipmentResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteEquipmentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteEquipmentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PatchEquipmentResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *Equipment
+	ApplicationproblemJSON400 *Problem
+	ApplicationproblemJSON404 *Problem
+	ApplicationproblemJSON415 *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchEquipmentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchEquipmentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateEquipmentResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Equipment
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateEquipmentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateEquipmentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetEquipmentAdjustmentsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StockLedger
+}
+
+// Status returns HTTPResponse.Status
+func (r GetEquipmentAdjustmentsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetEquipmentAdjustmentsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AddEquipmentAdjustmentResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON201                   *StockMovement
+	ApplicationproblemJSON400 *Problem
+	ApplicationproblemJSON404 *Problem
+	ApplicationproblemJSON409 *Problem
+	ApplicationproblemJSON422 *IdempotencyKeyReused
+}
+
+// Status returns HTTPResponse.Status
+func (r AddEquipmentAdjustmentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AddEquipmentAdjustmentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetEquipmentReservationsResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *[]Reservation
+	ApplicationproblemJSON400 *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetEquipmentReservationsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetEquipmentReservationsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateEquipmentReservationResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON201                   *Reservation
+	ApplicationproblemJSON400 *Problem
+	ApplicationproblemJSON404 *Problem
+	ApplicationproblemJSON409 *struct {
+		// Code Stable machine readable code of the problem, e.g. invalid-body, invalid-parameter, id-mismatch, forbidden, already-exists, unsupported-media-type, database-error, internal-error, insufficient-stock, equipment-not-available, reason-mismatch, not-versioned, transactions-not-supported, not-applied, idempotency-key-in-progress, idempotency-key-reused or <resource>-not-found
+		Code      string         `json:"code"`
+		Conflicts *[]Reservation `json:"conflicts,omitempty"`
+
+		// Detail Human readable explanation of the problem
+		Detail *string `json:"detail,omitempty"`
+
+		// Errors Details about the invalid fields of the body or parameters
+		Errors *[]ProblemFieldError `json:"errors,omitempty"`
+
+		// Instance Path of the request which caused the problem
+		Instance *string `json:"instance,omitempty"`
+
+		// RequestId Identifier of the request for correlation with the service logs
+		RequestId *string `json:"requestId,omitempty"`
+
+		// Status HTTP status code of the response
+		Status int `json:"status"`
+
+		// Title Short summary of the problem type
+		Title string `json:"title"`
+
+		// Type URI reference identifying the problem type
+		Type                 string                 `json:"type"`
+		AdditionalProperties map[string]interface{} `json:"-"`
+	}
+	ApplicationproblemJSON422 *IdempotencyKeyReused
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateEquipmentReservationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateEquipmentReservationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RestoreEquipmentResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *Equipment
+	ApplicationproblemJSON404 *Problem
+	ApplicationproblemJSON409 *IdempotencyKeyInProgress
+	ApplicationproblemJSON422 *IdempotencyKeyReused
+}
+
+// Status returns HTTPResponse.Status
+func (r RestoreEquipmentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RestoreEquipmentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetEquipmentVersionsResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *[]EquipmentVersion
+	ApplicationproblemJSON404 *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetEquipmentVersionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetEquipmentVersionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteRequestResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteRequestResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteRequestResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PatchRequestResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *Request
+	ApplicationproblemJSON400 *Problem
+	ApplicationproblemJSON404 *Problem
+	ApplicationproblemJSON415 *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchRequestResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchRequestResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateRequestResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Request
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateRequestResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateRequestResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RestoreRequestResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *Request
+	ApplicationproblemJSON404 *Problem
+	ApplicationproblemJSON409 *IdempotencyKeyInProgress
+	ApplicationproblemJSON422 *IdempotencyKeyReused
+}
+
+// Status returns HTTPResponse.Status
+func (r RestoreRequestResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RestoreRequestResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRequestVersionsResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *[]RequestVersion
+	ApplicationproblemJSON404 *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRequestVersionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRequestVersionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CancelReservationResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	ApplicationproblemJSON404 *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r CancelReservationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CancelReservationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AddRoomEquipmentResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *Equipment
+	ApplicationproblemJSON409 *IdempotencyKeyInProgress
+	ApplicationproblemJSON422 *IdempotencyKeyReused
+}
+
+// Status returns HTTPResponse.Status
+func (r AddRoomEquipmentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AddRoomEquipmentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AddRoomRequestResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *Request
+	ApplicationproblemJSON409 *IdempotencyKeyInProgress
+	ApplicationproblemJSON422 *IdempotencyKeyReused
+}
+
+// Status returns HTTPResponse.Status
+func (r AddRoomRequestResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AddRoomRequestResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ExecuteBatchWithBodyWithResponse request with arbitrary body returning *ExecuteBatchResponse
+func (c *ClientWithResponses) ExecuteBatchWithBodyWithResponse(ctx context.Context, params *ExecuteBatchParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ExecuteBatchResponse, error) {
+	rsp, err := c.ExecuteBatchWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExecuteBatchResponse(rsp)
+}
+
+func (c *ClientWithResponses) ExecuteBatchWithResponse(ctx context.Context, params *ExecuteBatchParams, body ExecuteBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*ExecuteBatchResponse, error) {
+	rsp, err := c.ExecuteBatch(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExecuteBatchResponse(rsp)
+}
+
+// GetDepartmentsWithResponse request returning *GetDepartmentsResponse
+func (c *ClientWithResponses) GetDepartmentsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetDepartmentsResponse, error) {
+	rsp, err := c.GetDepartments(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetDepartmentsResponse(rsp)
+}
+
+// GetDepartmentEquipmentWithResponse request returning *GetDepartmentEquipmentResponse
+func (c *ClientWithResponses) GetDepartmentEquipmentWithResponse(ctx context.Context, departmentId string, params *GetDepartmentEquipmentParams, reqEditors ...RequestEditorFn) (*GetDepartmentEquipmentResponse, error) {
+	rsp, err := c.GetDepartmentEquipment(ctx, departmentId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetDepartmentEquipmentResponse(rsp)
+}
+
+// GetDepartmentRequestsWithResponse request returning *GetDepartmentRequestsResponse
+func (c *ClientWithResponses) GetDepartmentRequestsWithResponse(ctx context.Context, departmentId string, params *GetDepartmentRequestsParams, reqEditors ...RequestEditorFn) (*GetDepartmentRequestsResponse, error) {
+	rsp, err := c.GetDepartmentRequests(ctx, departmentId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetDepartmentRequestsResponse(rsp)
+}
+
+// GetDepartmentReservationsWithResponse request returning *GetDepartmentReservationsResponse
+func (c *ClientWithResponses) GetDepartmentReservationsWithResponse(ctx context.Context, departmentId string, params *GetDepartmentReservationsParams, reqEditors ...RequestEditorFn) (*GetDepartmentReservationsResponse, error) {
+	rsp, err := c.GetDepartmentReservations(ctx, departmentId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetDepartmentReservationsResponse(rsp)
+}
+
+// DeleteEquipmentWithResponse request returning *DeleteEquipmentResponse
+func (c *ClientWithResponses) DeleteEquipmentWithResponse(ctx context.Context, equipmentId string, reqEditors ...RequestEditorFn) (*DeleteEquipmentResponse, error) {
+	rsp, err := c.DeleteEquipment(ctx, equipmentId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteEquipmentResponse(rsp)
+}
+
+// PatchEquipmentWithBodyWithResponse request with arbitrary body returning *PatchEquipmentResponse
+func (c *ClientWithResponses) PatchEquipmentWithBodyWithResponse(ctx context.Context, equipmentId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchEquipmentResponse, error) {
+	rsp, err := c.PatchEquipmentWithBody(ctx, equipmentId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchEquipmentResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchEquipmentWithResponse(ctx context.Context, equipmentId string, body PatchEquipmentJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchEquipmentResponse, error) {
+	rsp, err := c.PatchEquipment(ctx, equipmentId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchEquipmentResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchEquipmentWithApplicationJSONPatchPlusJSONBodyWithResponse(ctx context.Context, equipmentId string, body PatchEquipmentApplicationJSONPatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchEquipmentResponse, error) {
+	rsp, err := c.PatchEquipmentWithApplicationJSONPatchPlusJSONBody(ctx, equipmentId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchEquipmentResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchEquipmentWithApplicationMergePatchPlusJSONBodyWithResponse(ctx context.Context, equipmentId string, body PatchEquipmentApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchEquipmentResponse, error) {
+	rsp, err := c.PatchEquipmentWithApplicationMergePatchPlusJSONBody(ctx, equipmentId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchEquipmentResponse(rsp)
+}
+
+// UpdateEquipmentWithBodyWithResponse request with arbitrary body returning *UpdateEquipmentResponse
+func (c *ClientWithResponses) UpdateEquipmentWithBodyWithResponse(ctx context.Context, equipmentId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateEquipmentResponse, error) {
+	rsp, err := c.UpdateEquipmentWithBody(ctx, equipmentId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateEquipmentResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateEquipmentWithResponse(ctx context.Context, equipmentId string, body UpdateEquipmentJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateEquipmentResponse, error) {
+	rsp, err := c.UpdateEquipment(ctx, equipmentId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateEquipmentResponse(rsp)
+}
+
+// GetEquipmentAdjustmentsWithResponse request returning *GetEquipmentAdjustmentsResponse
+func (c *ClientWithResponses) GetEquipmentAdjustmentsWithResponse(ctx context.Context, equipmentId string, params *GetEquipmentAdjustmentsParams, reqEditors ...RequestEditorFn) (*GetEquipmentAdjustmentsResponse, error) {
+	rsp, err := c.GetEquipmentAdjustments(ctx, equipmentId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetEquipmentAdjustmentsResponse(rsp)
+}
+
+// AddEquipmentAdjustmentWithBodyWithResponse request with arbitrary body returning *AddEquipmentAdjustmentResponse
+func (c *ClientWithResponses) AddEquipmentAdjustmentWithBodyWithResponse(ctx context.Context, equipmentId string, params *AddEquipmentAdjustmentParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddEquipmentAdjustmentResponse, error) {
+	rsp, err := c.AddEquipmentAdjustmentWithBody(ctx, equipmentId, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddEquipmentAdjustmentResponse(rsp)
+}
+
+func (c *ClientWithResponses) AddEquipmentAdjustmentWithResponse(ctx context.Context, equipmentId string, params *AddEquipmentAdjustmentParams, body AddEquipmentAdjustmentJSONRequestBody, reqEditors ...RequestEditorFn) (*AddEquipmentAdjustmentResponse, error) {
+	rsp, err := c.AddEquipmentAdjustment(ctx, equipmentId, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddEquipmentAdjustmentResponse(rsp)
+}
+
+// GetEquipmentReservationsWithResponse request returning *GetEquipmentReservationsResponse
+func (c *ClientWithResponses) GetEquipmentReservationsWithResponse(ctx context.Context, equipmentId string, params *GetEquipmentReservationsParams, reqEditors ...RequestEditorFn) (*GetEquipmentReservationsResponse, error) {
+	rsp, err := c.GetEquipmentReservations(ctx, equipmentId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetEquipmentReservationsResponse(rsp)
+}
+
+// CreateEquipmentReservationWithBodyWithResponse request with arbitrary body returning *CreateEquipmentReservationResponse
+func (c *ClientWithResponses) CreateEquipmentReservationWithBodyWithResponse(ctx context.Context, equipmentId string, params *CreateEquipmentReservationParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateEquipmentReservationResponse, error) {
+	rsp, err := c.CreateEquipmentReservationWithBody(ctx, equipmentId, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateEquipmentReservationResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateEquipmentReservationWithResponse(ctx context.Context, equipmentId string, params *CreateEquipmentReservationParams, body CreateEquipmentReservationJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateEquipmentReservationResponse, error) {
+	rsp, err := c.CreateEquipmentReservation(ctx, equipmentId, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateEquipmentReservationResponse(rsp)
+}
+
+// RestoreEquipmentWithResponse request returning *RestoreEquipmentResponse
+func (c *ClientWithResponses) RestoreEquipmentWithResponse(ctx context.Context, equipmentId string, params *RestoreEquipmentParams, reqEditors ...RequestEditorFn) (*RestoreEquipmentResponse, error) {
+	rsp, err := c.RestoreEquipment(ctx, equipmentId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRestoreEquipmentResponse(rsp)
+}
+
+// GetEquipmentVersionsWithResponse request returning *GetEquipmentVersionsResponse
+func (c *ClientWithResponses) GetEquipmentVersionsWithResponse(ctx context.Context, equipmentId string, reqEditors ...RequestEditorFn) (*GetEquipmentVersionsResponse, error) {
+	rsp, err := c.GetEquipmentVersions(ctx, equipmentId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetEquipmentVersionsResponse(rsp)
+}
+
+// DeleteRequestWithResponse request returning *DeleteRequestResponse
+func (c *ClientWithResponses) DeleteRequestWithResponse(ctx context.Context, requestId string, reqEditors ...RequestEditorFn) (*DeleteRequestResponse, error) {
+	rsp, err := c.DeleteRequest(ctx, requestId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteRequestResponse(rsp)
+}
+
+// PatchRequestWithBodyWithResponse request with arbitrary body returning *PatchRequestResponse
+func (c *ClientWithResponses) PatchRequestWithBodyWithResponse(ctx context.Context, requestId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchRequestResponse, error) {
+	rsp, err := c.PatchRequestWithBody(ctx, requestId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchRequestResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchRequestWithResponse(ctx context.Context, requestId string, body PatchRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchRequestResponse, error) {
+	rsp, err := c.PatchRequest(ctx, requestId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchRequestResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchRequestWithApplicationJSONPatchPlusJSONBodyWithResponse(ctx context.Context, requestId string, body PatchRequestApplicationJSONPatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchRequestResponse, error) {
+	rsp, err := c.PatchRequestWithApplicationJSONPatchPlusJSONBody(ctx, requestId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchRequestResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchRequestWithApplicationMergePatchPlusJSONBodyWithResponse(ctx context.Context, requestId string, body PatchRequestApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchRequestResponse, error) {
+	rsp, err := c.PatchRequestWithApplicationMergePatchPlusJSONBody(ctx, requestId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchRequestResponse(rsp)
+}
+
+// UpdateRequestWithBodyWithResponse request with arbitrary body returning *UpdateRequestResponse
+func (c *ClientWithResponses) UpdateRequestWithBodyWithResponse(ctx context.Context, requestId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateRequestResponse, error) {
+	rsp, err := c.UpdateRequestWithBody(ctx, requestId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateRequestResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateRequestWithResponse(ctx context.Context, requestId string, body UpdateRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateRequestResponse, error) {
+	rsp, err := c.UpdateRequest(ctx, requestId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateRequestResponse(rsp)
+}
+
+// RestoreRequestWithResponse request returning *RestoreRequestResponse
+func (c *ClientWithResponses) RestoreRequestWithResponse(ctx context.Context, requestId string, params *RestoreRequestParams, reqEditors ...RequestEditorFn) (*RestoreRequestResponse, error) {
+	rsp, err := c.RestoreRequest(ctx, requestId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRestoreRequestResponse(rsp)
+}
+
+// GetRequestVersionsWithResponse request returning *GetRequestVersionsResponse
+func (c *ClientWithResponses) GetRequestVersionsWithResponse(ctx context.Context, requestId string, reqEditors ...RequestEditorFn) (*GetRequestVersionsResponse, error) {
+	rsp, err := c.GetRequestVersions(ctx, requestId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRequestVersionsResponse(rsp)
+}
+
+// CancelReservationWithResponse request returning *CancelReservationResponse
+func (c *ClientWithResponses) CancelReservationWithResponse(ctx context.Context, reservationId string, reqEditors ...RequestEditorFn) (*CancelReservationResponse, error) {
+	rsp, err := c.CancelReservation(ctx, reservationId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCancelReservationResponse(rsp)
+}
+
+// AddRoomEquipmentWithBodyWithResponse request with arbitrary body returning *AddRoomEquipmentResponse
+func (c *ClientWithResponses) AddRoomEquipmentWithBodyWithResponse(ctx context.Context, roomId string, params *AddRoomEquipmentParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddRoomEquipmentResponse, error) {
+	rsp, err := c.AddRoomEquipmentWithBody(ctx, roomId, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddRoomEquipmentResponse(rsp)
+}
+
+func (c *ClientWithResponses) AddRoomEquipmentWithResponse(ctx context.Context, roomId string, params *AddRoomEquipmentParams, body AddRoomEquipmentJSONRequestBody, reqEditors ...RequestEditorFn) (*AddRoomEquipmentResponse, error) {
+	rsp, err := c.AddRoomEquipment(ctx, roomId, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddRoomEquipmentResponse(rsp)
+}
+
+// AddRoomRequestWithBodyWithResponse request with arbitrary body returning *AddRoomRequestResponse
+func (c *ClientWithResponses) AddRoomRequestWithBodyWithResponse(ctx context.Context, roomId string, params *AddRoomRequestParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddRoomRequestResponse, error) {
+	rsp, err := c.AddRoomRequestWithBody(ctx, roomId, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddRoomRequestResponse(rsp)
+}
+
+func (c *ClientWithResponses) AddRoomRequestWithResponse(ctx context.Context, roomId string, params *AddRoomRequestParams, body AddRoomRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*AddRoomRequestResponse, error) {
+	rsp, err := c.AddRoomRequest(ctx, roomId, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddRoomRequestResponse(rsp)
+}
+
+// ParseExecuteBatchResponse parses an HTTP response from a ExecuteBatchWithResponse call
+func ParseExecuteBatchResponse(rsp *http.Response) (*ExecuteBatchResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ExecuteBatchResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BatchResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 207:
+		var dest BatchResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON207 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest IdempotencyKeyInProgress
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest IdempotencyKeyReused
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON422 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 501:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON501 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetDepartmentsResponse parses an HTTP response from a GetDepartmentsWithResponse call
+func ParseGetDepartmentsResponse(rsp *http.Response) (*GetDepartmentsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetDepartmentsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Department
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetDepartmentEquipmentResponse parses an HTTP response from a GetDepartmentEquipmentWithResponse call
+func ParseGetDepartmentEquipmentResponse(rsp *http.Response) (*GetDepartmentEquipmentResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetDepartmentEquipmentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			// Id Unique identifier of the department
+			Id string `json:"id"`
+
+			// Name Name of the department
+			Name string `json:"name"`
+
+			// Rooms Rooms of the department, null when the department has no rooms
+			Rooms *[]struct {
+				Equipment []Equipment `json:"equipment"`
+
+				// Id Unique identifier of the room
+				Id string `json:"id"`
+
+				// Name Name of the room
+				Name string `json:"name"`
+			} `json:"rooms"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetDepartmentRequestsResponse parses an HTTP response from a GetDepartmentRequestsWithResponse call
+func ParseGetDepartmentRequestsResponse(rsp *http.Response) (*GetDepartmentRequestsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetDepartmentRequestsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			// Id Unique identifier of the department
+			Id string `json:"id"`
+
+			// Name Name of the department
+			Name string `json:"name"`
+
+			// Rooms Rooms of the department, null when the department has no rooms
+			Rooms *[]struct {
+				// Id Unique identifier of the room
+				Id string `json:"id"`
+
+				// Name Name of the room
+				Name     string    `json:"name"`
+				Requests []Request `json:"requests"`
+			} `json:"rooms"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetDepartmentReservationsResponse parses an HTTP response from a GetDepartmentReservationsWithResponse call
+func ParseGetDepartmentReservationsResponse(rsp *http.Response) (*GetDepartmentReservationsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetDepartmentReservationsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReservationCalendar
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case rsp.StatusCode == 200:
+		// Content-type (text/calendar) unsupported
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteEquipmentResponse parses an HTTP response from a DeleteEquipmentWithResponse call
+func ParseDeleteEquipmentResponse(rsp *http.Response) (*DeleteEquipmentResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteEquipmentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParsePatchEquipmentResponse parses an HTTP response from a PatchEquipmentWithResponse call
+func ParsePatchEquipmentResponse(rsp *http.Response) (*PatchEquipmentResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PatchEquipmentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Equipment
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 415:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON415 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateEquipmentResponse parses an HTTP response from a UpdateEquipmentWithResponse call
+func ParseUpdateEquipmentResponse(rsp *http.Response) (*UpdateEquipmentResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateEquipmentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Equipment
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetEquipmentAdjustmentsResponse parses an HTTP response from a GetEquipmentAdjustmentsWithResponse call
+func ParseGetEquipmentAdjustmentsResponse(rsp *http.Response) (*GetEquipmentAdjustmentsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetEquipmentAdjustmentsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StockLedger
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAddEquipmentAdjustmentResponse parses an HTTP response from a AddEquipmentAdjustmentWithResponse call
+func ParseAddEquipmentAdjustmentResponse(rsp *http.Response) (*AddEquipmentAdjustmentResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AddEquipmentAdjustmentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest StockMovement
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest IdempotencyKeyReused
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON422 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetEquipmentReservationsResponse parses an HTTP response from a GetEquipmentReservationsWithResponse call
+func ParseGetEquipmentReservationsResponse(rsp *http.Response) (*GetEquipmentReservationsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetEquipmentReservationsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Reservation
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case rsp.StatusCode == 200:
+		// Content-type (text/calendar) unsupported
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateEquipmentReservationResponse parses an HTTP response from a CreateEquipmentReservationWithResponse call
+func ParseCreateEquipmentReservationResponse(rsp *http.Response) (*CreateEquipmentReservationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateEquipmentReservationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Reservation
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest struct {
+			// Code Stable machine readable code of the problem, e.g. invalid-body, invalid-parameter, id-mismatch, forbidden, already-exists, unsupported-media-type, database-error, internal-error, insufficient-stock, equipment-not-available, reason-mismatch, not-versioned, transactions-not-supported, not-applied, idempotency-key-in-progress, idempotency-key-reused or <resource>-not-found
+			Code      string         `json:"code"`
+			Conflicts *[]Reservation `json:"conflicts,omitempty"`
+
+			// Detail Human readable explanation of the problem
+			Detail *string `json:"detail,omitempty"`
+
+			// Errors Details about the invalid fields of the body or parameters
+			Errors *[]ProblemFieldError `json:"errors,omitempty"`
+
+			// Instance Path of the request which caused the problem
+			Instance *string `json:"instance,omitempty"`
+
+			// RequestId Identifier of the request for correlation with the service logs
+			RequestId *string `json:"requestId,omitempty"`
+
+			// Status HTTP status code of the response
+			Status int `json:"status"`
+
+			// Title Short summary of the problem type
+			Title string `json:"title"`
+
+			// Type URI reference identifying the problem type
+			Type                 string                 `json:"type"`
+			AdditionalProperties map[string]interface{} `json:"-"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest IdempotencyKeyReused
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON422 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRestoreEquipmentResponse parses an HTTP response from a RestoreEquipmentWithResponse call
+func ParseRestoreEquipmentResponse(rsp *http.Response) (*RestoreEquipmentResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RestoreEquipmentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Equipment
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest IdempotencyKeyInProgress
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest IdempotencyKeyReused
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON422 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetEquipmentVersionsResponse parses an HTTP response from a GetEquipmentVersionsWithResponse call
+func ParseGetEquipmentVersionsResponse(rsp *http.Response) (*GetEquipmentVersionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetEquipmentVersionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []EquipmentVersion
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteRequestResponse parses an HTTP response from a DeleteRequestWithResponse call
+func ParseDeleteRequestResponse(rsp *http.Response) (*DeleteRequestResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteRequestResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParsePatchRequestResponse parses an HTTP response from a PatchRequestWithResponse call
+func ParsePatchRequestResponse(rsp *http.Response) (*PatchRequestResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PatchRequestResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Request
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 415:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON415 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateRequestResponse parses an HTTP response from a UpdateRequestWithResponse call
+func ParseUpdateRequestResponse(rsp *http.Response) (*UpdateRequestResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateRequestResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Request
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRestoreRequestResponse parses an HTTP response from a RestoreRequestWithResponse call
+func ParseRestoreRequestResponse(rsp *http.Response) (*RestoreRequestResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RestoreRequestResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Request
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest IdempotencyKeyInProgress
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest IdempotencyKeyReused
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON422 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetRequestVersionsResponse parses an HTTP response from a GetRequestVersionsWithResponse call
+func ParseGetRequestVersionsResponse(rsp *http.Response) (*GetRequestVersionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRequestVersionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []RequestVersion
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCancelReservationResponse parses an HTTP response from a CancelReservationWithResponse call
+func ParseCancelReservationResponse(rsp *http.Response) (*CancelReservationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CancelReservationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAddRoomEquipmentResponse parses an HTTP response from a AddRoomEquipmentWithResponse call
+func ParseAddRoomEquipmentResponse(rsp *http.Response) (*AddRoomEquipmentResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AddRoomEquipmentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Equipment
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest IdempotencyKeyInProgress
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest IdempotencyKeyReused
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON422 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAddRoomRequestResponse parses an HTTP response from a AddRoomRequestWithResponse call
+func ParseAddRoomRequestResponse(rsp *http.Response) (*AddRoomRequestResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AddRoomRequestResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Request
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest IdempotencyKeyInProgress
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest IdempotencyKeyReused
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON422 = &dest
+
+	}
+
+	return response, nil
+}
@@ -0,0 +1,170 @@
+package client
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen@v2.4.1 -config oapi-codegen.yaml ../../api/fpjp.openapi.yaml
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// headers carrying identity of the caller, the authenticating proxy sets them for the service
+const (
+	userHeader   = "X-Forwarded-User"
+	groupsHeader = "X-Forwarded-Groups"
+)
+
+type Config struct {
+	// URL of the API including the base path, e.g. http://localhost:8080/api/v1
+	Server string
+	// client used to send the requests, http.DefaultClient by default
+	HTTPClient *http.Client
+	// provides value of the Authorization header, e.g. "Bearer <token>" when
+	// the API is called through the authenticating proxy
+	Authorization func(ctx context.Context) (string, error)
+	// identity of the caller when the service is called directly, without the proxy
+	User   string
+	Groups []string
+	// number of repeated attempts of idempotent requests failing with network
+	// error or temporarily unavailable service, 3 by default, negative disables retries
+	MaxRetries int
+	// delay before the first retry, doubled with each next attempt, 200ms by default
+	RetryBackoff time.Duration
+}
+
+// New creates client of the API, error responses of the API are returned as *Error
+//
+//	api, err := client.New(client.Config{Server: "http://localhost:8080/api/v1"})
+//	response, err := api.RestoreEquipmentWithResponse(ctx, "eq1")
+//	if errors.Is(err, client.ErrNotFound) { ... }
+//	equipment := response.JSON200
+func New(config Config) (*ClientWithResponses, error) {
+	if config.Server == "" {
+		return nil, errors.New("server URL of the API is required")
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+	if config.MaxRetries == 0 {
+		config.MaxRetries = 3
+	}
+	if config.MaxRetries < 0 {
+		config.MaxRetries = 0
+	}
+	if config.RetryBackoff == 0 {
+		config.RetryBackoff = 200 * time.Millisecond
+	}
+
+	return NewClientWithResponses(
+		config.Server,
+		WithHTTPClient(&doer{config: config}),
+		WithRequestEditorFn(config.identify),
+	)
+}
+
+// identify adds the identity of the caller to the request
+func (this Config) identify(ctx context.Context, req *http.Request) error {
+	if this.Authorization != nil {
+		authorization, err := this.Authorization(ctx)
+		if err != nil {
+			return fmt.Errorf("cannot get authorization: %w", err)
+		}
+		req.Header.Set("Authorization", authorization)
+	}
+	if this.User != "" {
+		req.Header.Set(userHeader, this.User)
+	}
+	if len(this.Groups) > 0 {
+		req.Header.Set(groupsHeader, strings.Join(this.Groups, ","))
+	}
+	return nil
+}
+
+// doer retries idempotent requests and turns error responses into *Error
+type doer struct {
+	config Config
+}
+
+func (this *doer) Do(req *http.Request) (*http.Response, error) {
+	var response *http.Response
+	var err error
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			if err := this.rewind(req); err != nil {
+				return nil, err
+			}
+		}
+
+		response, err = this.config.HTTPClient.Do(req)
+		if attempt >= this.config.MaxRetries || !retryable(req, response, err) {
+			break
+		}
+
+		delay := this.config.RetryBackoff << attempt
+		if response != nil {
+			delay = retryAfter(response, delay)
+			response.Body.Close()
+		}
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode >= http.StatusBadRequest {
+		defer response.Body.Close()
+		return nil, newError(response)
+	}
+	return response, nil
+}
+
+// rewind prepares body of the request for repeated attempt
+func (this *doer) rewind(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+	if req.GetBody == nil {
+		return errors.New("body of the request cannot be sent repeatedly")
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+	req.Body = body
+	return nil
+}
+
+// retryable checks if the failed request may be repeated without side effects
+func retryable(req *http.Request, response *http.Response, err error) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+	default:
+		return false
+	}
+
+	if err != nil {
+		return req.Context().Err() == nil
+	}
+	switch response.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryAfter returns delay requested by the service, only the delay in seconds is supported
+func retryAfter(response *http.Response, delay time.Duration) time.Duration {
+	if seconds, err := strconv.Atoi(response.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return delay
+}
@@ -0,0 +1,153 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient creates the client of the server handling the requests by the handler
+func newTestClient(t *testing.T, handler http.HandlerFunc, config Config) *ClientWithResponses {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	config.Server = server.URL + "/api/v1"
+	config.RetryBackoff = time.Millisecond
+	api, err := New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return api
+}
+
+func TestClientSendsIdentityAndDecodesResponse(t *testing.T) {
+	api := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/departments/" {
+			t.Errorf("unexpected path %v", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer token" || r.Header.Get(userHeader) != "nurse" || r.Header.Get(groupsHeader) != "staff,admin" {
+			t.Errorf("unexpected identity headers %v", r.Header)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `[{"id": "dep-1", "name": "Surgery"}]`)
+	}, Config{
+		Authorization: func(ctx context.Context) (string, error) { return "Bearer token", nil },
+		User:          "nurse",
+		Groups:        []string{"staff", "admin"},
+	})
+
+	response, err := api.GetDepartmentsWithResponse(context.Background())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.JSON200 == nil || len(*response.JSON200) != 1 || (*response.JSON200)[0].Name != "Surgery" {
+		t.Errorf("unexpected departments %s", response.Body)
+	}
+}
+
+func TestClientReturnsProblemAsError(t *testing.T) {
+	api := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusBadRequest)
+		io.WriteString(w, `{"type": "about:blank", "title": "Bad Request", "status": 400, "code": "invalid-body",
+			"detail": "Request body is not valid.", "errors": [{"field": "name", "code": "required", "message": "is required"}]}`)
+	}, Config{})
+
+	_, err := api.UpdateEquipmentWithResponse(context.Background(), "eq-1", Equipment{Room: "room-1"})
+
+	var apiError *Error
+	if !errors.As(err, &apiError) {
+		t.Fatalf("expected *Error, got %v", err)
+	}
+	if !errors.Is(err, ErrBadRequest) || errors.Is(err, ErrNotFound) {
+		t.Errorf("unexpected category of %v", err)
+	}
+	if apiError.Problem.Code != "invalid-body" || len(apiError.FieldErrors()) != 1 || apiError.FieldErrors()[0].Field != "name" {
+		t.Errorf("unexpected problem %+v", apiError.Problem)
+	}
+}
+
+func TestClientKeepsBodyOfOtherErrors(t *testing.T) {
+	api := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "upstream connect error", http.StatusNotFound)
+	}, Config{MaxRetries: -1})
+
+	_, err := api.GetDepartmentsWithResponse(context.Background())
+
+	var apiError *Error
+	if !errors.As(err, &apiError) || !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected not found *Error, got %v", err)
+	}
+	if apiError.Problem.Detail == nil || *apiError.Problem.Detail != "upstream connect error" {
+		t.Errorf("unexpected detail %v", apiError.Problem.Detail)
+	}
+}
+
+func TestClientRetriesIdempotentRequests(t *testing.T) {
+	attempts := atomic.Int32{}
+	api := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if len(body) == 0 {
+			t.Errorf("attempt %v has no body", attempts.Load())
+		}
+		if attempts.Add(1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"id": "eq-1", "room": "room-1", "type": "monitor", "name": "Monitor", "count": 1}`)
+	}, Config{})
+
+	response, err := api.UpdateEquipmentWithResponse(context.Background(), "eq-1",
+		Equipment{Room: "room-1", Type: "monitor", Name: "Monitor", Count: 1})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attempts.Load() != 3 || response.JSON200 == nil {
+		t.Errorf("expected success after 3 attempts, got %v attempts", attempts.Load())
+	}
+}
+
+func TestClientDoesNotRetryPost(t *testing.T) {
+	attempts := atomic.Int32{}
+	api := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, Config{})
+
+	_, err := api.AddRoomEquipmentWithResponse(context.Background(), "room-1", nil,
+		Equipment{Room: "room-1", Type: "monitor", Name: "Monitor", Count: 1})
+
+	if !errors.Is(err, ErrUnavailable) {
+		t.Errorf("expected unavailable, got %v", err)
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("POST must not be repeated, got %v attempts", attempts.Load())
+	}
+}
+
+func TestClientGivesUpAfterMaxRetries(t *testing.T) {
+	attempts := atomic.Int32{}
+	api := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}, Config{MaxRetries: 2})
+
+	_, err := api.GetDepartmentsWithResponse(context.Background())
+
+	if !errors.Is(err, ErrUnavailable) {
+		t.Errorf("expected unavailable, got %v", err)
+	}
+	if attempts.Load() != 3 {
+		t.Errorf("expected first attempt and 2 retries, got %v attempts", attempts.Load())
+	}
+}
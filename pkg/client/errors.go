@@ -0,0 +1,92 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// categories of the error responses, use errors.Is to check the category
+// and errors.As with *Error to get the details of the problem
+var (
+	ErrBadRequest           = errors.New("bad request")
+	ErrForbidden            = errors.New("forbidden")
+	ErrNotFound             = errors.New("not found")
+	ErrConflict             = errors.New("conflict")
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+	ErrUnavailable          = errors.New("service unavailable")
+)
+
+// problem responses larger than this are truncated
+const maxProblemSize = 1 << 20
+
+// Error is error response of the API, the problem describes it in the format
+// of RFC 7807 with stable Code which identifies the kind of the problem
+type Error struct {
+	StatusCode int
+	Problem    Problem
+}
+
+func newError(response *http.Response) *Error {
+	apiError := &Error{
+		StatusCode: response.StatusCode,
+		Problem: Problem{
+			Type:   "about:blank",
+			Title:  http.StatusText(response.StatusCode),
+			Status: response.StatusCode,
+		},
+	}
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxProblemSize))
+	if err != nil || len(body) == 0 {
+		return apiError
+	}
+	if err := json.Unmarshal(body, &apiError.Problem); err != nil || apiError.Problem.Code == "" {
+		// response of a proxy or other component in front of the service
+		detail := strings.TrimSpace(string(body))
+		apiError.Problem.Detail = &detail
+	}
+	return apiError
+}
+
+func (this *Error) Error() string {
+	message := fmt.Sprintf("fpjp api responded with %v", this.StatusCode)
+	if this.Problem.Code != "" {
+		message += " " + this.Problem.Code
+	}
+	if this.Problem.Detail != nil {
+		message += ": " + *this.Problem.Detail
+	}
+	return message
+}
+
+// Is reports the category of the error
+func (this *Error) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return this.StatusCode == http.StatusBadRequest
+	case ErrForbidden:
+		return this.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return this.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return this.StatusCode == http.StatusConflict
+	case ErrUnsupportedMediaType:
+		return this.StatusCode == http.StatusUnsupportedMediaType
+	case ErrUnavailable:
+		return this.StatusCode >= http.StatusInternalServerError
+	default:
+		return false
+	}
+}
+
+// FieldErrors returns the invalid fields of the body or parameters
+func (this *Error) FieldErrors() []ProblemFieldError {
+	if this.Problem.Errors == nil {
+		return nil
+	}
+	return *this.Problem.Errors
+}
@@ -0,0 +1,5 @@
+// Package inventoryv1 contains the protobuf messages and gRPC stubs of the inventory service,
+// the code is generated from api/proto by buf with protoc-gen-go and protoc-gen-go-grpc plugins.
+package inventoryv1
+
+//go:generate sh -c "cd ../../../api/proto && buf generate"
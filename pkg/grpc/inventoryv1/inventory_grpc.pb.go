@@ -0,0 +1,772 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             (unknown)
+// source: fpjp/inventory/v1/inventory.proto
+
+// Inventory of the equipment in the hospital departments and the requests for missing or broken equipment.
+// The service applies the same business rules as the REST API, identity of the caller is provided by the
+// authenticating proxy in the x-forwarded-user and x-forwarded-groups metadata.
+
+package inventoryv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	InventoryService_ListDepartments_FullMethodName        = "/fpjp.inventory.v1.InventoryService/ListDepartments"
+	InventoryService_ListRooms_FullMethodName              = "/fpjp.inventory.v1.InventoryService/ListRooms"
+	InventoryService_GetDepartmentEquipment_FullMethodName = "/fpjp.inventory.v1.InventoryService/GetDepartmentEquipment"
+	InventoryService_GetDepartmentRequests_FullMethodName  = "/fpjp.inventory.v1.InventoryService/GetDepartmentRequests"
+	InventoryService_GetEquipment_FullMethodName           = "/fpjp.inventory.v1.InventoryService/GetEquipment"
+	InventoryService_CreateEquipment_FullMethodName        = "/fpjp.inventory.v1.InventoryService/CreateEquipment"
+	InventoryService_UpdateEquipment_FullMethodName        = "/fpjp.inventory.v1.InventoryService/UpdateEquipment"
+	InventoryService_DeleteEquipment_FullMethodName        = "/fpjp.inventory.v1.InventoryService/DeleteEquipment"
+	InventoryService_RestoreEquipment_FullMethodName       = "/fpjp.inventory.v1.InventoryService/RestoreEquipment"
+	InventoryService_GetRequest_FullMethodName             = "/fpjp.inventory.v1.InventoryService/GetRequest"
+	InventoryService_CreateRequest_FullMethodName          = "/fpjp.inventory.v1.InventoryService/CreateRequest"
+	InventoryService_UpdateRequest_FullMethodName          = "/fpjp.inventory.v1.InventoryService/UpdateRequest"
+	InventoryService_DeleteRequest_FullMethodName          = "/fpjp.inventory.v1.InventoryService/DeleteRequest"
+	InventoryService_RestoreRequest_FullMethodName         = "/fpjp.inventory.v1.InventoryService/RestoreRequest"
+	InventoryService_WatchEquipment_FullMethodName         = "/fpjp.inventory.v1.InventoryService/WatchEquipment"
+	InventoryService_WatchRequests_FullMethodName          = "/fpjp.inventory.v1.InventoryService/WatchRequests"
+)
+
+// InventoryServiceClient is the client API for InventoryService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type InventoryServiceClient interface {
+	// Provides list of all departments
+	ListDepartments(ctx context.Context, in *ListDepartmentsRequest, opts ...grpc.CallOption) (*ListDepartmentsResponse, error)
+	// Provides list of the rooms in the department
+	ListRooms(ctx context.Context, in *ListRoomsRequest, opts ...grpc.CallOption) (*ListRoomsResponse, error)
+	// Provides the rooms of the department with the equipment located in them
+	GetDepartmentEquipment(ctx context.Context, in *GetDepartmentEquipmentRequest, opts ...grpc.CallOption) (*DepartmentEquipment, error)
+	// Provides the rooms of the department with the requests associated with them
+	GetDepartmentRequests(ctx context.Context, in *GetDepartmentRequestsRequest, opts ...grpc.CallOption) (*DepartmentRequests, error)
+	// Provides specific equipment
+	GetEquipment(ctx context.Context, in *GetEquipmentRequest, opts ...grpc.CallOption) (*Equipment, error)
+	// Adds new equipment to a room
+	CreateEquipment(ctx context.Context, in *CreateEquipmentRequest, opts ...grpc.CallOption) (*Equipment, error)
+	// Updates specific equipment
+	UpdateEquipment(ctx context.Context, in *UpdateEquipmentRequest, opts ...grpc.CallOption) (*Equipment, error)
+	// Deletes specific equipment, it can be restored until it is purged
+	DeleteEquipment(ctx context.Context, in *DeleteEquipmentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Restores previously deleted equipment
+	RestoreEquipment(ctx context.Context, in *RestoreEquipmentRequest, opts ...grpc.CallOption) (*Equipment, error)
+	// Provides specific request
+	GetRequest(ctx context.Context, in *GetRequestRequest, opts ...grpc.CallOption) (*Request, error)
+	// Adds new request to a room
+	CreateRequest(ctx context.Context, in *CreateRequestRequest, opts ...grpc.CallOption) (*Request, error)
+	// Updates specific request
+	UpdateRequest(ctx context.Context, in *UpdateRequestRequest, opts ...grpc.CallOption) (*Request, error)
+	// Deletes specific request, it can be restored until it is purged
+	DeleteRequest(ctx context.Context, in *DeleteRequestRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Restores previously deleted request
+	RestoreRequest(ctx context.Context, in *RestoreRequestRequest, opts ...grpc.CallOption) (*Request, error)
+	// Streams the changes of the equipment until the client cancels the call
+	WatchEquipment(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (InventoryService_WatchEquipmentClient, error)
+	// Streams the changes of the requests until the client cancels the call
+	WatchRequests(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (InventoryService_WatchRequestsClient, error)
+}
+
+type inventoryServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewInventoryServiceClient(cc grpc.ClientConnInterface) InventoryServiceClient {
+	return &inventoryServiceClient{cc}
+}
+
+func (c *inventoryServiceClient) ListDepartments(ctx context.Context, in *ListDepartmentsRequest, opts ...grpc.CallOption) (*ListDepartmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDepartmentsResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListDepartments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListRooms(ctx context.Context, in *ListRoomsRequest, opts ...grpc.CallOption) (*ListRoomsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRoomsResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListRooms_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetDepartmentEquipment(ctx context.Context, in *GetDepartmentEquipmentRequest, opts ...grpc.CallOption) (*DepartmentEquipment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DepartmentEquipment)
+	err := c.cc.Invoke(ctx, InventoryService_GetDepartmentEquipment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetDepartmentRequests(ctx context.Context, in *GetDepartmentRequestsRequest, opts ...grpc.CallOption) (*DepartmentRequests, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DepartmentRequests)
+	err := c.cc.Invoke(ctx, InventoryService_GetDepartmentRequests_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetEquipment(ctx context.Context, in *GetEquipmentRequest, opts ...grpc.CallOption) (*Equipment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Equipment)
+	err := c.cc.Invoke(ctx, InventoryService_GetEquipment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) CreateEquipment(ctx context.Context, in *CreateEquipmentRequest, opts ...grpc.CallOption) (*Equipment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Equipment)
+	err := c.cc.Invoke(ctx, InventoryService_CreateEquipment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) UpdateEquipment(ctx context.Context, in *UpdateEquipmentRequest, opts ...grpc.CallOption) (*Equipment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Equipment)
+	err := c.cc.Invoke(ctx, InventoryService_UpdateEquipment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) DeleteEquipment(ctx context.Context, in *DeleteEquipmentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, InventoryService_DeleteEquipment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) RestoreEquipment(ctx context.Context, in *RestoreEquipmentRequest, opts ...grpc.CallOption) (*Equipment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Equipment)
+	err := c.cc.Invoke(ctx, InventoryService_RestoreEquipment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetRequest(ctx context.Context, in *GetRequestRequest, opts ...grpc.CallOption) (*Request, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Request)
+	err := c.cc.Invoke(ctx, InventoryService_GetRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) CreateRequest(ctx context.Context, in *CreateRequestRequest, opts ...grpc.CallOption) (*Request, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Request)
+	err := c.cc.Invoke(ctx, InventoryService_CreateRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) UpdateRequest(ctx context.Context, in *UpdateRequestRequest, opts ...grpc.CallOption) (*Request, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Request)
+	err := c.cc.Invoke(ctx, InventoryService_UpdateRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) DeleteRequest(ctx context.Context, in *DeleteRequestRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, InventoryService_DeleteRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) RestoreRequest(ctx context.Context, in *RestoreRequestRequest, opts ...grpc.CallOption) (*Request, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Request)
+	err := c.cc.Invoke(ctx, InventoryService_RestoreRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) WatchEquipment(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (InventoryService_WatchEquipmentClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &InventoryService_ServiceDesc.Streams[0], InventoryService_WatchEquipment_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &inventoryServiceWatchEquipmentClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type InventoryService_WatchEquipmentClient interface {
+	Recv() (*EquipmentChange, error)
+	grpc.ClientStream
+}
+
+type inventoryServiceWatchEquipmentClient struct {
+	grpc.ClientStream
+}
+
+func (x *inventoryServiceWatchEquipmentClient) Recv() (*EquipmentChange, error) {
+	m := new(EquipmentChange)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *inventoryServiceClient) WatchRequests(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (InventoryService_WatchRequestsClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &InventoryService_ServiceDesc.Streams[1], InventoryService_WatchRequests_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &inventoryServiceWatchRequestsClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type InventoryService_WatchRequestsClient interface {
+	Recv() (*RequestChange, error)
+	grpc.ClientStream
+}
+
+type inventoryServiceWatchRequestsClient struct {
+	grpc.ClientStream
+}
+
+func (x *inventoryServiceWatchRequestsClient) Recv() (*RequestChange, error) {
+	m := new(RequestChange)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// InventoryServiceServer is the server API for InventoryService service.
+// All implementations must embed UnimplementedInventoryServiceServer
+// for forward compatibility
+type InventoryServiceServer interface {
+	// Provides list of all departments
+	ListDepartments(context.Context, *ListDepartmentsRequest) (*ListDepartmentsResponse, error)
+	// Provides list of the rooms in the department
+	ListRooms(context.Context, *ListRoomsRequest) (*ListRoomsResponse, error)
+	// Provides the rooms of the department with the equipment located in them
+	GetDepartmentEquipment(context.Context, *GetDepartmentEquipmentRequest) (*DepartmentEquipment, error)
+	// Provides the rooms of the department with the requests associated with them
+	GetDepartmentRequests(context.Context, *GetDepartmentRequestsRequest) (*DepartmentRequests, error)
+	// Provides specific equipment
+	GetEquipment(context.Context, *GetEquipmentRequest) (*Equipment, error)
+	// Adds new equipment to a room
+	CreateEquipment(context.Context, *CreateEquipmentRequest) (*Equipment, error)
+	// Updates specific equipment
+	UpdateEquipment(context.Context, *UpdateEquipmentRequest) (*Equipment, error)
+	// Deletes specific equipment, it can be restored until it is purged
+	DeleteEquipment(context.Context, *DeleteEquipmentRequest) (*emptypb.Empty, error)
+	// Restores previously deleted equipment
+	RestoreEquipment(context.Context, *RestoreEquipmentRequest) (*Equipment, error)
+	// Provides specific request
+	GetRequest(context.Context, *GetRequestRequest) (*Request, error)
+	// Adds new request to a room
+	CreateRequest(context.Context, *CreateRequestRequest) (*Request, error)
+	// Updates specific request
+	UpdateRequest(context.Context, *UpdateRequestRequest) (*Request, error)
+	// Deletes specific request, it can be restored until it is purged
+	DeleteRequest(context.Context, *DeleteRequestRequest) (*emptypb.Empty, error)
+	// Restores previously deleted request
+	RestoreRequest(context.Context, *RestoreRequestRequest) (*Request, error)
+	// Streams the changes of the equipment until the client cancels the call
+	WatchEquipment(*WatchRequest, InventoryService_WatchEquipmentServer) error
+	// Streams the changes of the requests until the client cancels the call
+	WatchRequests(*WatchRequest, InventoryService_WatchRequestsServer) error
+	mustEmbedUnimplementedInventoryServiceServer()
+}
+
+// UnimplementedInventoryServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedInventoryServiceServer struct {
+}
+
+func (UnimplementedInventoryServiceServer) ListDepartments(context.Context, *ListDepartmentsRequest) (*ListDepartmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDepartments not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListRooms(context.Context, *ListRoomsRequest) (*ListRoomsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRooms not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetDepartmentEquipment(context.Context, *GetDepartmentEquipmentRequest) (*DepartmentEquipment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDepartmentEquipment not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetDepartmentRequests(context.Context, *GetDepartmentRequestsRequest) (*DepartmentRequests, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDepartmentRequests not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetEquipment(context.Context, *GetEquipmentRequest) (*Equipment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEquipment not implemented")
+}
+func (UnimplementedInventoryServiceServer) CreateEquipment(context.Context, *CreateEquipmentRequest) (*Equipment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateEquipment not implemented")
+}
+func (UnimplementedInventoryServiceServer) UpdateEquipment(context.Context, *UpdateEquipmentRequest) (*Equipment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateEquipment not implemented")
+}
+func (UnimplementedInventoryServiceServer) DeleteEquipment(context.Context, *DeleteEquipmentRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteEquipment not implemented")
+}
+func (UnimplementedInventoryServiceServer) RestoreEquipment(context.Context, *RestoreEquipmentRequest) (*Equipment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreEquipment not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetRequest(context.Context, *GetRequestRequest) (*Request, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRequest not implemented")
+}
+func (UnimplementedInventoryServiceServer) CreateRequest(context.Context, *CreateRequestRequest) (*Request, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRequest not implemented")
+}
+func (UnimplementedInventoryServiceServer) UpdateRequest(context.Context, *UpdateRequestRequest) (*Request, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRequest not implemented")
+}
+func (UnimplementedInventoryServiceServer) DeleteRequest(context.Context, *DeleteRequestRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRequest not implemented")
+}
+func (UnimplementedInventoryServiceServer) RestoreRequest(context.Context, *RestoreRequestRequest) (*Request, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreRequest not implemented")
+}
+func (UnimplementedInventoryServiceServer) WatchEquipment(*WatchRequest, InventoryService_WatchEquipmentServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEquipment not implemented")
+}
+func (UnimplementedInventoryServiceServer) WatchRequests(*WatchRequest, InventoryService_WatchRequestsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchRequests not implemented")
+}
+func (UnimplementedInventoryServiceServer) mustEmbedUnimplementedInventoryServiceServer() {}
+
+// UnsafeInventoryServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to InventoryServiceServer will
+// result in compilation errors.
+type UnsafeInventoryServiceServer interface {
+	mustEmbedUnimplementedInventoryServiceServer()
+}
+
+func RegisterInventoryServiceServer(s grpc.ServiceRegistrar, srv InventoryServiceServer) {
+	s.RegisterService(&InventoryService_ServiceDesc, srv)
+}
+
+func _InventoryService_ListDepartments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDepartmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListDepartments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListDepartments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListDepartments(ctx, req.(*ListDepartmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListRooms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoomsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListRooms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListRooms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListRooms(ctx, req.(*ListRoomsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetDepartmentEquipment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDepartmentEquipmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetDepartmentEquipment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetDepartmentEquipment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetDepartmentEquipment(ctx, req.(*GetDepartmentEquipmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetDepartmentRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDepartmentRequestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetDepartmentRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetDepartmentRequests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetDepartmentRequests(ctx, req.(*GetDepartmentRequestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetEquipment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEquipmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetEquipment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetEquipment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetEquipment(ctx, req.(*GetEquipmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_CreateEquipment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateEquipmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CreateEquipment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CreateEquipment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CreateEquipment(ctx, req.(*CreateEquipmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_UpdateEquipment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateEquipmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).UpdateEquipment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_UpdateEquipment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).UpdateEquipment(ctx, req.(*UpdateEquipmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_DeleteEquipment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteEquipmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).DeleteEquipment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_DeleteEquipment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).DeleteEquipment(ctx, req.(*DeleteEquipmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_RestoreEquipment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreEquipmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).RestoreEquipment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_RestoreEquipment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).RestoreEquipment(ctx, req.(*RestoreEquipmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetRequest(ctx, req.(*GetRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_CreateRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CreateRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CreateRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CreateRequest(ctx, req.(*CreateRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_UpdateRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).UpdateRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_UpdateRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).UpdateRequest(ctx, req.(*UpdateRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_DeleteRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).DeleteRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_DeleteRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).DeleteRequest(ctx, req.(*DeleteRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_RestoreRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).RestoreRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_RestoreRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).RestoreRequest(ctx, req.(*RestoreRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_WatchEquipment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(InventoryServiceServer).WatchEquipment(m, &inventoryServiceWatchEquipmentServer{ServerStream: stream})
+}
+
+type InventoryService_WatchEquipmentServer interface {
+	Send(*EquipmentChange) error
+	grpc.ServerStream
+}
+
+type inventoryServiceWatchEquipmentServer struct {
+	grpc.ServerStream
+}
+
+func (x *inventoryServiceWatchEquipmentServer) Send(m *EquipmentChange) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _InventoryService_WatchRequests_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(InventoryServiceServer).WatchRequests(m, &inventoryServiceWatchRequestsServer{ServerStream: stream})
+}
+
+type InventoryService_WatchRequestsServer interface {
+	Send(*RequestChange) error
+	grpc.ServerStream
+}
+
+type inventoryServiceWatchRequestsServer struct {
+	grpc.ServerStream
+}
+
+func (x *inventoryServiceWatchRequestsServer) Send(m *RequestChange) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// InventoryService_ServiceDesc is the grpc.ServiceDesc for InventoryService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var InventoryService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "fpjp.inventory.v1.InventoryService",
+	HandlerType: (*InventoryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListDepartments",
+			Handler:    _InventoryService_ListDepartments_Handler,
+		},
+		{
+			MethodName: "ListRooms",
+			Handler:    _InventoryService_ListRooms_Handler,
+		},
+		{
+			MethodName: "GetDepartmentEquipment",
+			Handler:    _InventoryService_GetDepartmentEquipment_Handler,
+		},
+		{
+			MethodName: "GetDepartmentRequests",
+			Handler:    _InventoryService_GetDepartmentRequests_Handler,
+		},
+		{
+			MethodName: "GetEquipment",
+			Handler:    _InventoryService_GetEquipment_Handler,
+		},
+		{
+			MethodName: "CreateEquipment",
+			Handler:    _InventoryService_CreateEquipment_Handler,
+		},
+		{
+			MethodName: "UpdateEquipment",
+			Handler:    _InventoryService_UpdateEquipment_Handler,
+		},
+		{
+			MethodName: "DeleteEquipment",
+			Handler:    _InventoryService_DeleteEquipment_Handler,
+		},
+		{
+			MethodName: "RestoreEquipment",
+			Handler:    _InventoryService_RestoreEquipment_Handler,
+		},
+		{
+			MethodName: "GetRequest",
+			Handler:    _InventoryService_GetRequest_Handler,
+		},
+		{
+			MethodName: "CreateRequest",
+			Handler:    _InventoryService_CreateRequest_Handler,
+		},
+		{
+			MethodName: "UpdateRequest",
+			Handler:    _InventoryService_UpdateRequest_Handler,
+		},
+		{
+			MethodName: "DeleteRequest",
+			Handler:    _InventoryService_DeleteRequest_Handler,
+		},
+		{
+			MethodName: "RestoreRequest",
+			Handler:    _InventoryService_RestoreRequest_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchEquipment",
+			Handler:       _InventoryService_WatchEquipment_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchRequests",
+			Handler:       _InventoryService_WatchRequests_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "fpjp/inventory/v1/inventory.proto",
+}
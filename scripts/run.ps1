@@ -10,6 +10,7 @@ $ProjectRoot = "${PSScriptRoot}/.."
 
 $env:AMBULANCE_API_ENVIRONMENT="Development"
 $env:AMBULANCE_API_PORT="8080"
+$env:AMBULANCE_API_GRPC_PORT="50051"
 $env:AMBULANCE_API_MONGODB_USERNAME="root"
 $env:AMBULANCE_API_MONGODB_PASSWORD="neUhaDnes"
 
@@ -20,6 +21,7 @@ function mongo {
 switch ($command) {
   "openapi" {
     docker run --rm -ti -v ${ProjectRoot}:/local openapitools/openapi-generator-cli generate -c /local/scripts/generator-cfg.yaml
+    docker run --rm -ti -v ${ProjectRoot}:/local openapitools/openapi-generator-cli generate -c /local/scripts/generator-cfg-v2.yaml
   }
   "start" {
     try {
@@ -32,6 +34,9 @@ switch ($command) {
   "mongo" {
     mongo up
   }
+  "admin" {
+    go run ${ProjectRoot}/cmd/fpjp-admin $args
+  }
   "docker" {
     docker build -t ghcr.io/ns-super-team/fpjp-ambulance-webapi:local-build -f ${ProjectRoot}/build/docker/Dockerfile .
   }
@@ -10,6 +10,7 @@ ProjectRoot="$(dirname "$(realpath "$0")")/.."
 
 export AMBULANCE_API_ENVIRONMENT="Development"
 export AMBULANCE_API_PORT="8080"
+export AMBULANCE_API_GRPC_PORT="50051"
 export AMBULANCE_API_MONGODB_USERNAME="root"
 export AMBULANCE_API_MONGODB_PASSWORD="neUhaDnes"
 
@@ -20,6 +21,7 @@ function mongo {
 case "$command" in
   "openapi")
     docker run --rm -ti -v "${ProjectRoot}:/local" openapitools/openapi-generator-cli generate -c /local/scripts/generator-cfg.yaml
+    docker run --rm -ti -v "${ProjectRoot}:/local" openapitools/openapi-generator-cli generate -c /local/scripts/generator-cfg-v2.yaml
     ;;
   "start")
     {
@@ -32,6 +34,10 @@ case "$command" in
   "mongo")
     mongo up
     ;;
+  "admin")
+    shift
+    go run "${ProjectRoot}/cmd/fpjp-admin" "$@"
+    ;;
   *)
     echo "Unknown command: $command"
     exit 1